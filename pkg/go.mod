@@ -22,7 +22,7 @@ require (
 	go.opentelemetry.io/otel/sdk v1.35.0
 	go.opentelemetry.io/otel/sdk/metric v1.35.0
 	go.opentelemetry.io/otel/trace v1.35.0
-	google.golang.org/grpc v1.71.0 // indirect; FIXME: not minimal
+	google.golang.org/grpc v1.71.0 // FIXME: not minimal
 )
 
 require (
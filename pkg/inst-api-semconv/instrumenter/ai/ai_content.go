@@ -0,0 +1,283 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EnvCaptureMessageContent turns on recording of prompts, completions and
+// tool calls as span events. Message content may contain sensitive data so
+// it is disabled by default.
+const EnvCaptureMessageContent = "OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT"
+
+// EnvMessageContentMaxLength limits the length in bytes of every recorded
+// content, tool call arguments included.
+const EnvMessageContentMaxLength = "OTEL_INSTRUMENTATION_GENAI_MESSAGE_CONTENT_MAX_LENGTH"
+
+// EnvMessageContentRedactPatterns is a comma separated list of regular
+// expressions whose matches are replaced before the content is recorded.
+const EnvMessageContentRedactPatterns = "OTEL_INSTRUMENTATION_GENAI_MESSAGE_CONTENT_REDACT_PATTERNS"
+
+const defaultMessageContentMaxLength = 8192
+
+const redactedPlaceholder = "[REDACTED]"
+
+const (
+	GenAISystemMessageEvent    = "gen_ai.system.message"
+	GenAIUserMessageEvent      = "gen_ai.user.message"
+	GenAIAssistantMessageEvent = "gen_ai.assistant.message"
+	GenAIToolMessageEvent      = "gen_ai.tool.message"
+	GenAIChoiceEvent           = "gen_ai.choice"
+)
+
+const (
+	GenAIRoleSystem    = "system"
+	GenAIRoleUser      = "user"
+	GenAIRoleAssistant = "assistant"
+	GenAIRoleTool      = "tool"
+)
+
+type GenAIToolCall struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+type GenAIMessage struct {
+	Role       string
+	Content    string
+	ToolCalls  []GenAIToolCall
+	ToolCallID string
+}
+
+type GenAIChoice struct {
+	Index        int
+	FinishReason string
+	Message      GenAIMessage
+}
+
+type contentConfig struct {
+	enabled   bool
+	maxLength int
+	patterns  []*regexp.Regexp
+}
+
+var (
+	contentOnce     sync.Once
+	contentSettings contentConfig
+	redactor        func(string) string
+	redactorMu      sync.RWMutex
+)
+
+func loadContentConfig() contentConfig {
+	contentOnce.Do(func() {
+		contentSettings = newContentConfig(os.Getenv(EnvCaptureMessageContent),
+			os.Getenv(EnvMessageContentMaxLength), os.Getenv(EnvMessageContentRedactPatterns))
+	})
+	return contentSettings
+}
+
+func newContentConfig(enabled, maxLength, patterns string) contentConfig {
+	c := contentConfig{maxLength: defaultMessageContentMaxLength}
+	c.enabled, _ = strconv.ParseBool(enabled)
+	if maxLength != "" {
+		if n, err := strconv.Atoi(maxLength); err == nil && n > 0 {
+			c.maxLength = n
+		} else {
+			log.Printf("invalid %s %q, using %d\n", EnvMessageContentMaxLength, maxLength, c.maxLength)
+		}
+	}
+	for _, p := range strings.Split(patterns, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			log.Printf("invalid %s pattern %q, err is %v\n", EnvMessageContentRedactPatterns, p, err)
+			continue
+		}
+		c.patterns = append(c.patterns, re)
+	}
+	return c
+}
+
+// CaptureMessageContent reports whether instrumentations should record
+// message content. Callers check it before converting messages so that the
+// default, disabled mode costs nothing.
+func CaptureMessageContent() bool {
+	return loadContentConfig().enabled
+}
+
+// SetMessageContentRedactor registers a hook that rewrites every content
+// and tool call arguments before they are recorded, it runs after the
+// patterns configured by OTEL_INSTRUMENTATION_GENAI_MESSAGE_CONTENT_REDACT_PATTERNS.
+func SetMessageContentRedactor(fn func(string) string) {
+	redactorMu.Lock()
+	defer redactorMu.Unlock()
+	redactor = fn
+}
+
+func (c contentConfig) sanitize(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, re := range c.patterns {
+		s = re.ReplaceAllString(s, redactedPlaceholder)
+	}
+	redactorMu.RLock()
+	fn := redactor
+	redactorMu.RUnlock()
+	if fn != nil {
+		s = fn(s)
+	}
+	if len(s) > c.maxLength {
+		n := c.maxLength
+		// never cut a multi-byte character in half
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n]
+	}
+	return s
+}
+
+type toolCallBody struct {
+	ID       string           `json:"id,omitempty"`
+	Type     string           `json:"type"`
+	Function toolCallFunction `json:"function"`
+}
+
+type toolCallFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+type choiceMessageBody struct {
+	Role      string         `json:"role,omitempty"`
+	Content   string         `json:"content,omitempty"`
+	ToolCalls []toolCallBody `json:"tool_calls,omitempty"`
+}
+
+func (c contentConfig) toolCalls(calls []GenAIToolCall) []toolCallBody {
+	if len(calls) == 0 {
+		return nil
+	}
+	res := make([]toolCallBody, 0, len(calls))
+	for _, call := range calls {
+		res = append(res, toolCallBody{
+			ID:   call.ID,
+			Type: "function",
+			Function: toolCallFunction{
+				Name:      call.Name,
+				Arguments: c.sanitize(call.Arguments),
+			},
+		})
+	}
+	return res
+}
+
+func messageEventName(role string) string {
+	switch role {
+	case GenAIRoleSystem:
+		return GenAISystemMessageEvent
+	case GenAIRoleAssistant:
+		return GenAIAssistantMessageEvent
+	case GenAIRoleTool:
+		return GenAIToolMessageEvent
+	default:
+		return GenAIUserMessageEvent
+	}
+}
+
+func marshalString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// RecordInputMessages adds one event per prompt message to the span, it is
+// a no-op unless message content capturing is enabled.
+func RecordInputMessages(span trace.Span, system string, messages []GenAIMessage) {
+	c := loadContentConfig()
+	recordInputMessages(c, span, system, messages)
+}
+
+func recordInputMessages(c contentConfig, span trace.Span, system string, messages []GenAIMessage) {
+	if !c.enabled || span == nil || !span.IsRecording() {
+		return
+	}
+	for _, msg := range messages {
+		attrs := []attribute.KeyValue{semconv.GenAISystemKey.String(system)}
+		if msg.Content != "" {
+			attrs = append(attrs, attribute.String("content", c.sanitize(msg.Content)))
+		}
+		if calls := c.toolCalls(msg.ToolCalls); calls != nil {
+			attrs = append(attrs, attribute.String("tool_calls", marshalString(calls)))
+		}
+		if msg.ToolCallID != "" {
+			attrs = append(attrs, attribute.String("id", msg.ToolCallID))
+		}
+		span.AddEvent(messageEventName(msg.Role), trace.WithAttributes(attrs...))
+	}
+}
+
+// RecordChoices adds one gen_ai.choice event per model output to the span,
+// streamed outputs should be reassembled by the caller first.
+func RecordChoices(span trace.Span, system string, choices []GenAIChoice) {
+	c := loadContentConfig()
+	recordChoices(c, span, system, choices)
+}
+
+func recordChoices(c contentConfig, span trace.Span, system string, choices []GenAIChoice) {
+	if !c.enabled || span == nil || !span.IsRecording() {
+		return
+	}
+	for _, choice := range choices {
+		finishReason := choice.FinishReason
+		if finishReason == "" {
+			finishReason = "error"
+		}
+		role := choice.Message.Role
+		if role == "" {
+			role = GenAIRoleAssistant
+		}
+		message := choiceMessageBody{
+			Role:      role,
+			Content:   c.sanitize(choice.Message.Content),
+			ToolCalls: c.toolCalls(choice.Message.ToolCalls),
+		}
+		span.AddEvent(GenAIChoiceEvent, trace.WithAttributes(
+			semconv.GenAISystemKey.String(system),
+			attribute.Int("index", choice.Index),
+			attribute.String("finish_reason", finishReason),
+			attribute.String("message", marshalString(message)),
+		))
+	}
+}
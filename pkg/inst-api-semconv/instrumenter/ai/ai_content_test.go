@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func recordWith(t *testing.T, fn func(tp *sdktrace.TracerProvider)) []sdktrace.Event {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	fn(tp)
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	return spans[0].Events()
+}
+
+func eventAttr(event sdktrace.Event, key string) (attribute.Value, bool) {
+	for _, attr := range event.Attributes {
+		if string(attr.Key) == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestRecordMessagesDisabled(t *testing.T) {
+	c := newContentConfig("", "", "")
+	events := recordWith(t, func(tp *sdktrace.TracerProvider) {
+		_, span := tp.Tracer("test").Start(context.Background(), "chat")
+		recordInputMessages(c, span, "openai", []GenAIMessage{{Role: GenAIRoleUser, Content: "hi"}})
+		recordChoices(c, span, "openai", []GenAIChoice{{FinishReason: "stop", Message: GenAIMessage{Content: "hello"}}})
+		span.End()
+	})
+	assert.Empty(t, events)
+}
+
+func TestRecordInputMessages(t *testing.T) {
+	c := newContentConfig("true", "", "")
+	events := recordWith(t, func(tp *sdktrace.TracerProvider) {
+		_, span := tp.Tracer("test").Start(context.Background(), "chat")
+		recordInputMessages(c, span, "openai", []GenAIMessage{
+			{Role: GenAIRoleSystem, Content: "be brief"},
+			{Role: GenAIRoleUser, Content: "weather?"},
+			{Role: GenAIRoleAssistant, ToolCalls: []GenAIToolCall{{ID: "call_1", Name: "weather", Arguments: `{"city":"Paris"}`}}},
+			{Role: GenAIRoleTool, Content: "sunny", ToolCallID: "call_1"},
+		})
+		span.End()
+	})
+	assert.Len(t, events, 4)
+	assert.Equal(t, GenAISystemMessageEvent, events[0].Name)
+	assert.Equal(t, GenAIUserMessageEvent, events[1].Name)
+	assert.Equal(t, GenAIAssistantMessageEvent, events[2].Name)
+	assert.Equal(t, GenAIToolMessageEvent, events[3].Name)
+	content, _ := eventAttr(events[1], "content")
+	assert.Equal(t, "weather?", content.AsString())
+	calls, _ := eventAttr(events[2], "tool_calls")
+	assert.Equal(t, `[{"id":"call_1","type":"function","function":{"name":"weather","arguments":"{\"city\":\"Paris\"}"}}]`, calls.AsString())
+	id, _ := eventAttr(events[3], "id")
+	assert.Equal(t, "call_1", id.AsString())
+}
+
+func TestRecordChoices(t *testing.T) {
+	c := newContentConfig("true", "", "")
+	events := recordWith(t, func(tp *sdktrace.TracerProvider) {
+		_, span := tp.Tracer("test").Start(context.Background(), "chat")
+		recordChoices(c, span, "openai", []GenAIChoice{{Index: 1, Message: GenAIMessage{Content: "hello"}}})
+		span.End()
+	})
+	assert.Len(t, events, 1)
+	assert.Equal(t, GenAIChoiceEvent, events[0].Name)
+	index, _ := eventAttr(events[0], "index")
+	assert.Equal(t, int64(1), index.AsInt64())
+	reason, _ := eventAttr(events[0], "finish_reason")
+	assert.Equal(t, "error", reason.AsString())
+	message, _ := eventAttr(events[0], "message")
+	assert.Equal(t, `{"role":"assistant","content":"hello"}`, message.AsString())
+}
+
+func TestContentRedactionAndLimit(t *testing.T) {
+	c := newContentConfig("1", "12", `sk-[a-z0-9]+, [`)
+	assert.Len(t, c.patterns, 1)
+	assert.Equal(t, "key [REDACTE", c.sanitize("key sk-abc123 please"))
+
+	SetMessageContentRedactor(strings.ToUpper)
+	assert.Equal(t, "HELLO", c.sanitize("hello"))
+	SetMessageContentRedactor(nil)
+
+	c = newContentConfig("true", "4", "")
+	// the multi-byte character does not fit and is dropped as a whole
+	assert.Equal(t, "abc", c.sanitize("abc世"))
+}
+
+func TestContentConfigDefaults(t *testing.T) {
+	c := newContentConfig("not-a-bool", "-1", "")
+	assert.False(t, c.enabled)
+	assert.Equal(t, defaultMessageContentMaxLength, c.maxLength)
+}
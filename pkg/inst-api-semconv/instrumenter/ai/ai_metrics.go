@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+const gen_ai_client_token_usage = "gen_ai.client.token.usage"
+
+const gen_ai_client_operation_duration = "gen_ai.client.operation.duration"
+
+const gen_ai_server_time_to_first_token = "gen_ai.server.time_to_first_token"
+
+type AIClientMetric struct {
+	key               attribute.Key
+	tokenUsage        metric.Int64Histogram
+	operationDuration metric.Float64Histogram
+	timeToFirstToken  metric.Float64Histogram
+}
+
+var mu sync.Mutex
+
+var aiMetricsConv = map[attribute.Key]bool{
+	semconv.GenAIOperationNameKey: true,
+	semconv.GenAISystemKey:        true,
+	semconv.GenAIRequestModelKey:  true,
+	semconv.GenAIResponseModelKey: true,
+	semconv.ServerAddressKey:      true,
+	semconv.ErrorTypeKey:          true,
+}
+
+var globalMeter metric.Meter
+
+// InitAIMetrics so we need to make sure the otel_setup is executed before all the init() function
+// related to issue https://github.com/alibaba/loongsuite-go-agent/issues/48
+func InitAIMetrics(m metric.Meter) {
+	mu.Lock()
+	defer mu.Unlock()
+	globalMeter = m
+}
+
+func AIClientMetrics(key string) *AIClientMetric {
+	mu.Lock()
+	defer mu.Unlock()
+	return &AIClientMetric{key: attribute.Key(key)}
+}
+
+// for test only
+func newAIClientMetric(key string, meter metric.Meter) (*AIClientMetric, error) {
+	m := &AIClientMetric{
+		key: attribute.Key(key),
+	}
+	err := m.initMeasures(meter)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (h *AIClientMetric) initMeasures(meter metric.Meter) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return errors.New("nil meter")
+	}
+	if h.tokenUsage != nil {
+		return nil
+	}
+	u, err := meter.Int64Histogram(gen_ai_client_token_usage,
+		metric.WithUnit("{token}"),
+		metric.WithDescription("Measures number of input and output tokens used."),
+		metric.WithExplicitBucketBoundaries(1, 4, 16, 64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864))
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to create gen_ai.client.token.usage histogram, %v", err))
+	}
+	d, err := meter.Float64Histogram(gen_ai_client_operation_duration,
+		metric.WithUnit("s"),
+		metric.WithDescription("GenAI operation duration."),
+		metric.WithExplicitBucketBoundaries(0.01, 0.02, 0.04, 0.08, 0.16, 0.32, 0.64, 1.28, 2.56, 5.12, 10.24, 20.48, 40.96, 81.92))
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to create gen_ai.client.operation.duration histogram, %v", err))
+	}
+	t, err := meter.Float64Histogram(gen_ai_server_time_to_first_token,
+		metric.WithUnit("s"),
+		metric.WithDescription("Time to receive the first token of a streaming response, measured on the client."),
+		metric.WithExplicitBucketBoundaries(0.001, 0.005, 0.01, 0.02, 0.04, 0.06, 0.08, 0.1, 0.25, 0.5, 0.75, 1.0, 2.5, 5.0, 7.5, 10.0))
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to create gen_ai.server.time_to_first_token histogram, %v", err))
+	}
+	h.tokenUsage, h.operationDuration, h.timeToFirstToken = u, d, t
+	return nil
+}
+
+type aiMetricContext struct {
+	startTime       time.Time
+	startAttributes []attribute.KeyValue
+}
+
+type timeToFirstTokenKey struct{}
+
+// ContextWithTimeToFirstToken records how long a streaming call took to
+// produce its first chunk. Instrumentations call it right before End so
+// that AIClientMetric can report the measurement with the span attributes.
+func ContextWithTimeToFirstToken(ctx context.Context, ttft time.Duration) context.Context {
+	return context.WithValue(ctx, timeToFirstTokenKey{}, ttft)
+}
+
+func (h *AIClientMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
+	return parentContext
+}
+
+func (h *AIClientMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
+	return context.WithValue(ctx, h.key, aiMetricContext{
+		startTime:       startTime,
+		startAttributes: startAttributes,
+	})
+}
+
+func (h *AIClientMetric) OnAfterStart(context context.Context, endTime time.Time) {
+	return
+}
+
+func (h *AIClientMetric) OnAfterEnd(context context.Context, endAttributes []attribute.KeyValue, endTime time.Time) {
+	mc, ok := context.Value(h.key).(aiMetricContext)
+	if !ok {
+		return
+	}
+	startTime, startAttributes := mc.startTime, mc.startAttributes
+	if h.tokenUsage == nil {
+		// second change to init the metric
+		if err := h.initMeasures(globalMeter); err != nil {
+			log.Printf("failed to create ai client metrics, err is %v\n", err)
+			return
+		}
+	}
+	// token usage may only be known when the call ends, so the end
+	// attributes take precedence over the ones captured at start
+	inputTokens := lookupInt64(semconv.GenAIUsageInputTokensKey, endAttributes, startAttributes)
+	outputTokens := lookupInt64(semconv.GenAIUsageOutputTokensKey, endAttributes, startAttributes)
+	attrs := make([]attribute.KeyValue, 0, len(endAttributes)+len(startAttributes))
+	attrs = append(attrs, endAttributes...)
+	attrs = append(attrs, startAttributes...)
+	n, metricsAttrs := utils.Shadow(attrs, aiMetricsConv)
+	metricsAttrs = dedupAttrs(metricsAttrs[0:n])
+	set := attribute.NewSet(metricsAttrs...)
+	h.operationDuration.Record(context, endTime.Sub(startTime).Seconds(), metric.WithAttributeSet(set))
+	if ttft, ok := context.Value(timeToFirstTokenKey{}).(time.Duration); ok {
+		h.timeToFirstToken.Record(context, ttft.Seconds(), metric.WithAttributeSet(set))
+	}
+	// attribute.NewSet sorts its argument in place, clip the shared attributes
+	// so that every set is built from a fresh copy
+	if inputTokens > 0 {
+		h.tokenUsage.Record(context, inputTokens, metric.WithAttributeSet(attribute.NewSet(
+			append(slices.Clip(metricsAttrs), semconv.GenAITokenTypeInput)...)))
+	}
+	if outputTokens > 0 {
+		h.tokenUsage.Record(context, outputTokens, metric.WithAttributeSet(attribute.NewSet(
+			append(slices.Clip(metricsAttrs), semconv.GenAITokenTypeCompletion)...)))
+	}
+}
+
+func lookupInt64(key attribute.Key, attrsList ...[]attribute.KeyValue) int64 {
+	for _, attrs := range attrsList {
+		for i := len(attrs) - 1; i >= 0; i-- {
+			if attrs[i].Key == key && attrs[i].Value.AsInt64() > 0 {
+				return attrs[i].Value.AsInt64()
+			}
+		}
+	}
+	return 0
+}
+
+// dedupAttrs keeps the first non-empty value of every key, end attributes
+// come first so they win over the start attributes.
+func dedupAttrs(attrs []attribute.KeyValue) []attribute.KeyValue {
+	seen := make(map[attribute.Key]bool, len(attrs))
+	res := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		if seen[attr.Key] || attr.Value.Emit() == "" {
+			continue
+		}
+		seen[attr.Key] = true
+		res = append(res, attr)
+	}
+	return res
+}
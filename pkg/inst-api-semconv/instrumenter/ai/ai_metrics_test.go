@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func collectAIMetrics(t *testing.T, reader *metric.ManualReader) map[string]metricdata.Aggregation {
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), rm); err != nil {
+		t.Fatal(err)
+	}
+	res := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			res[m.Name] = m.Data
+		}
+	}
+	return res
+}
+
+func newTestMeterProvider() (*metric.MeterProvider, *metric.ManualReader) {
+	reader := metric.NewManualReader()
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("my-service"),
+		semconv.ServiceVersion("v0.1.0"),
+	)
+	return metric.NewMeterProvider(metric.WithResource(res), metric.WithReader(reader)), reader
+}
+
+func TestAIClientMetrics(t *testing.T) {
+	mp, reader := newTestMeterProvider()
+	client, err := newAIClientMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	ctx = client.OnBeforeStart(ctx, start)
+	ctx = client.OnBeforeEnd(ctx, []attribute.KeyValue{
+		semconv.GenAIOperationNameKey.String("chat"),
+		semconv.GenAISystemKey.String("openai"),
+		semconv.GenAIRequestModelKey.String("gpt-4o"),
+		semconv.GenAIUsageInputTokensKey.Int64(0),
+		semconv.GenAIRequestTemperatureKey.Float64(0.5),
+	}, start)
+	client.OnAfterStart(ctx, start)
+	client.OnAfterEnd(ctx, []attribute.KeyValue{
+		semconv.GenAIUsageInputTokensKey.Int64(12),
+		semconv.GenAIUsageOutputTokensKey.Int64(34),
+		semconv.GenAIResponseModelKey.String("gpt-4o-2024-08-06"),
+	}, start.Add(time.Second))
+	data := collectAIMetrics(t, reader)
+	duration, ok := data[gen_ai_client_operation_duration].(metricdata.Histogram[float64])
+	if !ok || len(duration.DataPoints) != 1 {
+		t.Fatalf("unexpected operation duration %v", data[gen_ai_client_operation_duration])
+	}
+	if duration.DataPoints[0].Sum != 1 {
+		t.Fatalf("expected 1s duration, got %v", duration.DataPoints[0].Sum)
+	}
+	if _, ok := duration.DataPoints[0].Attributes.Value(semconv.GenAIRequestTemperatureKey); ok {
+		t.Fatal("request temperature should not be a metric attribute")
+	}
+	usage, ok := data[gen_ai_client_token_usage].(metricdata.Histogram[int64])
+	if !ok || len(usage.DataPoints) != 2 {
+		t.Fatalf("unexpected token usage %v", data[gen_ai_client_token_usage])
+	}
+	for _, dp := range usage.DataPoints {
+		tokenType, _ := dp.Attributes.Value(semconv.GenAITokenTypeKey)
+		switch tokenType.AsString() {
+		case "input":
+			if dp.Sum != 12 {
+				t.Fatalf("expected 12 input tokens, got %v", dp.Sum)
+			}
+		case "output":
+			if dp.Sum != 34 {
+				t.Fatalf("expected 34 output tokens, got %v", dp.Sum)
+			}
+		default:
+			t.Fatalf("unexpected token type %v", tokenType.AsString())
+		}
+	}
+	if _, ok := data[gen_ai_server_time_to_first_token]; ok {
+		t.Fatal("time to first token should only be recorded for streaming calls")
+	}
+}
+
+func TestAIClientMetricsTokenUsageAttributes(t *testing.T) {
+	mp, reader := newTestMeterProvider()
+	client, err := newAIClientMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	// The empty and duplicate attributes are dropped, which leaves spare
+	// capacity behind the metric attributes
+	ctx = client.OnBeforeEnd(ctx, []attribute.KeyValue{
+		semconv.GenAIOperationNameKey.String("chat"),
+		semconv.GenAISystemKey.String("openai"),
+		semconv.GenAIRequestModelKey.String("gpt-4o"),
+		semconv.ServerAddressKey.String("api.openai.com"),
+		semconv.ErrorTypeKey.String(""),
+	}, start)
+	client.OnAfterEnd(ctx, []attribute.KeyValue{
+		semconv.GenAIUsageInputTokensKey.Int64(12),
+		semconv.GenAIUsageOutputTokensKey.Int64(34),
+		semconv.GenAIRequestModelKey.String("gpt-4o"),
+	}, start.Add(time.Second))
+	data := collectAIMetrics(t, reader)
+	usage, ok := data[gen_ai_client_token_usage].(metricdata.Histogram[int64])
+	if !ok || len(usage.DataPoints) != 2 {
+		t.Fatalf("unexpected token usage %v", data[gen_ai_client_token_usage])
+	}
+	for _, dp := range usage.DataPoints {
+		tokenType, _ := dp.Attributes.Value(semconv.GenAITokenTypeKey)
+		if dp.Attributes.Len() != 5 {
+			t.Fatalf("unexpected %s token attributes %v", tokenType.AsString(),
+				dp.Attributes.ToSlice())
+		}
+		address, ok := dp.Attributes.Value(semconv.ServerAddressKey)
+		if !ok || address.AsString() != "api.openai.com" {
+			t.Fatalf("expected server address of %s tokens, got %v",
+				tokenType.AsString(), dp.Attributes.ToSlice())
+		}
+	}
+}
+
+func TestAIClientMetricsTimeToFirstToken(t *testing.T) {
+	mp, reader := newTestMeterProvider()
+	client, err := newAIClientMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	ctx = client.OnBeforeEnd(ctx, []attribute.KeyValue{}, start)
+	ctx = ContextWithTimeToFirstToken(ctx, 250*time.Millisecond)
+	client.OnAfterEnd(ctx, []attribute.KeyValue{}, time.Now())
+	data := collectAIMetrics(t, reader)
+	ttft, ok := data[gen_ai_server_time_to_first_token].(metricdata.Histogram[float64])
+	if !ok || len(ttft.DataPoints) != 1 {
+		t.Fatalf("unexpected time to first token %v", data[gen_ai_server_time_to_first_token])
+	}
+	if ttft.DataPoints[0].Sum != 0.25 {
+		t.Fatalf("expected 0.25s, got %v", ttft.DataPoints[0].Sum)
+	}
+	if _, ok := data[gen_ai_client_token_usage]; ok {
+		t.Fatal("token usage should not be recorded without tokens")
+	}
+}
+
+func TestLazyAIClientMetrics(t *testing.T) {
+	mp, reader := newTestMeterProvider()
+	InitAIMetrics(mp.Meter("test-meter"))
+	client := AIClientMetrics("ai.client")
+	ctx := context.Background()
+	start := time.Now()
+	ctx = client.OnBeforeStart(ctx, start)
+	ctx = client.OnBeforeEnd(ctx, []attribute.KeyValue{}, start)
+	client.OnAfterStart(ctx, start)
+	client.OnAfterEnd(ctx, []attribute.KeyValue{}, time.Now())
+	data := collectAIMetrics(t, reader)
+	if _, ok := data[gen_ai_client_operation_duration]; !ok {
+		t.Fatal("expected gen_ai.client.operation.duration to be recorded")
+	}
+}
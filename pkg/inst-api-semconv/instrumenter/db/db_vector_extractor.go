@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+// db.vector.query.top_k is not part of the semantic conventions yet, it
+// follows the name used by the existing vector database instrumentations.
+const dbVectorQueryTopKKey = attribute.Key("db.vector.query.top_k")
+
+type DbVectorAttrsGetter[REQUEST any] interface {
+	GetTopK(REQUEST) int
+	// GetReturnedRows returns the number of rows returned by the operation,
+	// the second result is false if the operation does not return rows.
+	GetReturnedRows(REQUEST) (int, bool)
+}
+
+// DbVectorAttrsExtractor records the attributes specific to vector database
+// operations, it is meant to be used along with DbClientAttrsExtractor.
+type DbVectorAttrsExtractor[REQUEST any, RESPONSE any, GETTER DbVectorAttrsGetter[REQUEST]] struct {
+	Getter GETTER
+}
+
+func (d *DbVectorAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnStart(attrs []attribute.KeyValue, parentContext context.Context, request REQUEST) ([]attribute.KeyValue, context.Context) {
+	if topK := d.Getter.GetTopK(request); topK > 0 {
+		attrs = append(attrs, attribute.KeyValue{Key: dbVectorQueryTopKKey, Value: attribute.IntValue(topK)})
+	}
+	return attrs, parentContext
+}
+
+func (d *DbVectorAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnEnd(attrs []attribute.KeyValue, context context.Context, request REQUEST, response RESPONSE, err error) ([]attribute.KeyValue, context.Context) {
+	if err != nil {
+		return attrs, context
+	}
+	if rows, ok := d.Getter.GetReturnedRows(request); ok {
+		attrs = append(attrs, attribute.KeyValue{Key: semconv.DBResponseReturnedRowsKey, Value: attribute.IntValue(rows)})
+	}
+	return attrs, context
+}
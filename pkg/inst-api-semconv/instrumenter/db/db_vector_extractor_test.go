@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+type vectorTestRequest struct {
+	topK     int
+	returned int
+	search   bool
+}
+
+type vectorAttrsGetter struct {
+}
+
+func (v vectorAttrsGetter) GetTopK(request vectorTestRequest) int {
+	return request.topK
+}
+
+func (v vectorAttrsGetter) GetReturnedRows(request vectorTestRequest) (int, bool) {
+	return request.returned, request.search
+}
+
+func TestDbVectorExtractorSearch(t *testing.T) {
+	extractor := DbVectorAttrsExtractor[vectorTestRequest, any, vectorAttrsGetter]{}
+	request := vectorTestRequest{topK: 5, returned: 3, search: true}
+	attrs, _ := extractor.OnStart(nil, context.Background(), request)
+	if len(attrs) != 1 || attrs[0].Key != dbVectorQueryTopKKey || attrs[0].Value.AsInt64() != 5 {
+		t.Fatalf("unexpected top_k attributes %v", attrs)
+	}
+	attrs, _ = extractor.OnEnd(nil, context.Background(), request, nil, nil)
+	if len(attrs) != 1 || attrs[0].Key != semconv.DBResponseReturnedRowsKey || attrs[0].Value.AsInt64() != 3 {
+		t.Fatalf("unexpected returned rows attributes %v", attrs)
+	}
+}
+
+func TestDbVectorExtractorNoSearch(t *testing.T) {
+	extractor := DbVectorAttrsExtractor[vectorTestRequest, any, vectorAttrsGetter]{}
+	request := vectorTestRequest{}
+	attrs, _ := extractor.OnStart(nil, context.Background(), request)
+	attrs, _ = extractor.OnEnd(attrs, context.Background(), request, nil, nil)
+	if len(attrs) != 0 {
+		t.Fatalf("expected no attributes, got %v", attrs)
+	}
+}
+
+func TestDbVectorExtractorError(t *testing.T) {
+	extractor := DbVectorAttrsExtractor[vectorTestRequest, any, vectorAttrsGetter]{}
+	request := vectorTestRequest{topK: 5, search: true}
+	attrs, _ := extractor.OnEnd([]attribute.KeyValue{}, context.Background(), request, nil, errors.New("failed"))
+	if len(attrs) != 0 {
+		t.Fatalf("expected no returned rows on error, got %v", attrs)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package experimental
+
+import (
+	"log"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	SentinelPassCount        metric.Int64Counter
+	SentinelBlockCount       metric.Int64Counter
+	SentinelErrorCount       metric.Int64Counter
+	SentinelStateChangeCount metric.Int64Counter
+)
+
+func InitSentinelMetrics(m metric.Meter) {
+	if m == nil {
+		return
+	}
+	var err error
+	SentinelPassCount, err = m.Int64Counter("sentinel.entry.pass", metric.WithDescription("Number of entries passed by sentinel"))
+	if err != nil {
+		log.Printf("failed to init SentinelPassCount metrics")
+	}
+	SentinelBlockCount, err = m.Int64Counter("sentinel.entry.block", metric.WithDescription("Number of entries blocked by sentinel"))
+	if err != nil {
+		log.Printf("failed to init SentinelBlockCount metrics")
+	}
+	SentinelErrorCount, err = m.Int64Counter("sentinel.entry.error", metric.WithDescription("Number of errors traced by sentinel"))
+	if err != nil {
+		log.Printf("failed to init SentinelErrorCount metrics")
+	}
+	SentinelStateChangeCount, err = m.Int64Counter("sentinel.circuit_breaker.state_change", metric.WithDescription("Number of circuit breaker state transitions"))
+	if err != nil {
+		log.Printf("failed to init SentinelStateChangeCount metrics")
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package experimental
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestInitSentinelMetrics_MeterNil_NoMetricsInitialized(t *testing.T) {
+	InitSentinelMetrics(nil)
+	assert.Nil(t, SentinelPassCount)
+	assert.Nil(t, SentinelBlockCount)
+	assert.Nil(t, SentinelErrorCount)
+	assert.Nil(t, SentinelStateChangeCount)
+}
+
+func TestInitSentinelMetrics_MeterNotNull_AllMetricsInitialized(t *testing.T) {
+	mp := metric.NewMeterProvider()
+	InitSentinelMetrics(mp.Meter("a"))
+	assert.NotNil(t, SentinelPassCount)
+	assert.NotNil(t, SentinelBlockCount)
+	assert.NotNil(t, SentinelErrorCount)
+	assert.NotNil(t, SentinelStateChangeCount)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+// MethodToolsCall is the JSON-RPC method of calling a tool
+const MethodToolsCall = "tools/call"
+
+// Request describes an MCP request sent by a client, whichever SDK release
+// sends it
+type Request struct {
+	OperationName string
+	System        string
+	MethodName    string
+	MethodType    string
+	CallId        string
+	Input         map[string]any
+	Output        map[string]any
+}
+
+var ClientInstrumenter = BuildClientInstrumenter()
+
+type aiCommonRequest struct {
+}
+
+func (aiCommonRequest) GetAIOperationName(request Request) string {
+	return request.OperationName
+}
+func (aiCommonRequest) GetAISystem(request Request) string {
+	return request.System
+}
+
+type LExperimentalAttributeExtractor struct {
+	Base ai.AICommonAttrsExtractor[Request, any, aiCommonRequest]
+}
+
+func (l LExperimentalAttributeExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request Request) ([]attribute.KeyValue, context.Context) {
+	attributes, parentContext = l.Base.OnStart(attributes, parentContext, request)
+	if request.MethodType == MethodToolsCall {
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   "gen_ai.tool.name",
+			Value: attribute.StringValue(request.MethodName),
+		}, attribute.KeyValue{
+			Key:   "gen_ai.tool.call.id",
+			Value: attribute.StringValue(request.CallId),
+		})
+	}
+	return appendOtherAttributes(attributes, "gen_ai.other_input.", request.Input), parentContext
+}
+
+func (l LExperimentalAttributeExtractor) OnEnd(attributes []attribute.KeyValue, context context.Context, request Request, response any, err error) ([]attribute.KeyValue, context.Context) {
+	attributes, context = l.Base.OnEnd(attributes, context, request, response, err)
+	return appendOtherAttributes(attributes, "gen_ai.other_output.", request.Output), context
+}
+
+func appendOtherAttributes(attributes []attribute.KeyValue, prefix string, values map[string]any) []attribute.KeyValue {
+	for k, v := range values {
+		var val attribute.Value
+		switch v := v.(type) {
+		case string:
+			val = attribute.StringValue(v)
+		case int:
+			val = attribute.IntValue(v)
+		case int64:
+			val = attribute.Int64Value(v)
+		case float64:
+			val = attribute.Float64Value(v)
+		case bool:
+			val = attribute.BoolValue(v)
+		default:
+			val = attribute.StringValue(fmt.Sprintf("%#v", v))
+		}
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   attribute.Key(prefix + k),
+			Value: val,
+		})
+	}
+	return attributes
+}
+
+func BuildClientInstrumenter() instrumenter.Instrumenter[Request, any] {
+	builder := instrumenter.Builder[Request, any]{}
+	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[Request, any]{Getter: aiCommonRequest{}}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[Request]{}).
+		AddAttributesExtractor(&LExperimentalAttributeExtractor{}).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.MCP_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordToolCall adds the arguments of a tools/call request to the span in
+// ctx as the tool call of an assistant message. Like other GenAI content it
+// is recorded only when OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT is
+// enabled, and goes through the same redaction and length limit.
+func RecordToolCall(ctx context.Context, id string, name string, arguments any) {
+	if !ai.CaptureMessageContent() {
+		return
+	}
+	ai.RecordInputMessages(trace.SpanFromContext(ctx), "mcp",
+		toolCallMessages(id, name, arguments))
+}
+
+// RecordToolResult adds the result of a tools/call request to the span in ctx
+// as a choice of the tool role, see RecordToolCall.
+func RecordToolResult(ctx context.Context, result any, err error) {
+	if !ai.CaptureMessageContent() {
+		return
+	}
+	ai.RecordChoices(trace.SpanFromContext(ctx), "mcp",
+		toolResultChoices(result, err))
+}
+
+func toolCallMessages(id string, name string, arguments any) []ai.GenAIMessage {
+	return []ai.GenAIMessage{{
+		Role: ai.GenAIRoleAssistant,
+		ToolCalls: []ai.GenAIToolCall{{
+			ID:        id,
+			Name:      name,
+			Arguments: marshalContent(arguments),
+		}},
+	}}
+}
+
+func toolResultChoices(result any, err error) []ai.GenAIChoice {
+	choice := ai.GenAIChoice{
+		FinishReason: "stop",
+		Message: ai.GenAIMessage{
+			Role:    ai.GenAIRoleTool,
+			Content: marshalContent(result),
+		},
+	}
+	if err != nil {
+		choice.FinishReason = "error"
+		choice.Message.Content = err.Error()
+	}
+	return []ai.GenAIChoice{choice}
+}
+
+// marshalContent converts the arguments or result to their JSON form, raw
+// messages received by clients are kept as they are
+func marshalContent(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case json.RawMessage:
+		return string(v)
+	case *json.RawMessage:
+		if v == nil {
+			return ""
+		}
+		return string(*v)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
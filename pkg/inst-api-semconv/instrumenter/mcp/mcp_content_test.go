@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolCallMessages(t *testing.T) {
+	messages := toolCallMessages("1", "weather", map[string]any{"city": "Paris"})
+	assert.Len(t, messages, 1)
+	assert.Equal(t, ai.GenAIRoleAssistant, messages[0].Role)
+	assert.Equal(t, []ai.GenAIToolCall{{
+		ID:        "1",
+		Name:      "weather",
+		Arguments: `{"city":"Paris"}`,
+	}}, messages[0].ToolCalls)
+}
+
+func TestToolResultChoices(t *testing.T) {
+	raw := json.RawMessage(`{"content":[{"type":"text","text":"sunny"}]}`)
+	choices := toolResultChoices(&raw, nil)
+	assert.Len(t, choices, 1)
+	assert.Equal(t, "stop", choices[0].FinishReason)
+	assert.Equal(t, ai.GenAIRoleTool, choices[0].Message.Role)
+	assert.Equal(t, string(raw), choices[0].Message.Content)
+
+	choices = toolResultChoices(nil, errors.New("tool not found"))
+	assert.Equal(t, "error", choices[0].FinishReason)
+	assert.Equal(t, "tool not found", choices[0].Message.Content)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel"
+)
+
+// MetaCarrier adapts the _meta object of an MCP request so that traceparent
+// and baggage can be carried as request metadata.
+type MetaCarrier map[string]any
+
+func (c MetaCarrier) Get(key string) string {
+	v, _ := c[key].(string)
+	return v
+}
+
+func (c MetaCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+func (c MetaCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// InjectMeta returns a copy of meta that carries the span context of ctx,
+// leaving the metadata owned by the caller untouched.
+func InjectMeta(ctx context.Context, meta map[string]any) (map[string]any, bool) {
+	carrier := MetaCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return meta, false
+	}
+	injected := make(map[string]any, len(meta)+len(carrier))
+	for k, v := range meta {
+		injected[k] = v
+	}
+	for k, v := range carrier {
+		injected[k] = v
+	}
+	return injected, true
+}
+
+// InjectParams returns a copy of params whose _meta carries the span context
+// of ctx. The params are round-tripped through JSON because they are always
+// sent as an object, whatever their Go type is.
+func InjectParams(ctx context.Context, params any) (map[string]any, bool) {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var object map[string]any
+	if err = decoder.Decode(&object); err != nil {
+		return nil, false
+	}
+	if object == nil {
+		object = map[string]any{}
+	}
+	existing, _ := object["_meta"].(map[string]any)
+	meta, ok := InjectMeta(ctx, existing)
+	if !ok {
+		return nil, false
+	}
+	object["_meta"] = meta
+	return object, true
+}
+
+// ExtractMeta returns ctx carrying the remote span context found in meta, or
+// ctx itself when there is none.
+func ExtractMeta(ctx context.Context, meta map[string]any) context.Context {
+	carrier := MetaCarrier(meta)
+	propagator := otel.GetTextMapPropagator()
+	for _, field := range propagator.Fields() {
+		if carrier.Get(field) != "" {
+			return propagator.Extract(ctx, carrier)
+		}
+	}
+	return ctx
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func spanContext(t *testing.T) context.Context {
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	assert.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestInjectExtractMeta(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	ctx := spanContext(t)
+	meta := map[string]any{"progressToken": "1"}
+	injected, ok := InjectMeta(ctx, meta)
+	assert.True(t, ok)
+	assert.Equal(t, "1", injected["progressToken"])
+	assert.NotContains(t, meta, "traceparent")
+
+	extracted := ExtractMeta(context.Background(), injected)
+	assert.Equal(t, trace.SpanContextFromContext(ctx).TraceID(),
+		trace.SpanContextFromContext(extracted).TraceID())
+}
+
+func TestExtractMetaWithoutContext(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	ctx := context.Background()
+	assert.Equal(t, ctx, ExtractMeta(ctx, nil))
+	assert.Equal(t, ctx, ExtractMeta(ctx, map[string]any{"progressToken": "1"}))
+}
+
+func TestInjectParams(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	params := struct {
+		Name string         `json:"name"`
+		Meta map[string]any `json:"_meta,omitempty"`
+	}{Name: "echo", Meta: map[string]any{"progressToken": "1"}}
+	injected, ok := InjectParams(spanContext(t), params)
+	assert.True(t, ok)
+	assert.Equal(t, "echo", injected["name"])
+	meta, ok := injected["_meta"].(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, "1", meta["progressToken"])
+	assert.NotEmpty(t, meta["traceparent"])
+
+	_, ok = InjectParams(context.Background(), params)
+	assert.False(t, ok)
+}
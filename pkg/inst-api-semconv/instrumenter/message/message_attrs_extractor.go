@@ -26,6 +26,8 @@ type MessageOperation string
 const PUBLISH MessageOperation = "publish"
 const RECEIVE MessageOperation = "receive"
 const PROCESS MessageOperation = "process"
+const CREATE MessageOperation = "create"
+const SETTLE MessageOperation = "settle"
 
 type MessageAttrsExtractor[REQUEST any, RESPONSE any, GETTER MessageAttrsGetter[REQUEST, RESPONSE]] struct {
 	Getter    GETTER
@@ -40,6 +42,10 @@ func (m *MessageAttrsExtractor[REQUEST, RESPONSE, GETTER]) GetSpanKey() attribut
 		return utils.CONSUMER_RECEIVE_KEY
 	case PROCESS:
 		return utils.CONSUMER_PROCESS_KEY
+	case CREATE:
+		return utils.PRODUCER_CREATE_KEY
+	case SETTLE:
+		return utils.CONSUMER_SETTLE_KEY
 	}
 	panic("Operation" + m.Operation + "not supported")
 }
@@ -90,6 +90,14 @@ func TestMessageGetSpanKey(t *testing.T) {
 	if messageExtractor.GetSpanKey() != utils.CONSUMER_PROCESS_KEY {
 		t.Fatalf("Should have returned consumer process key")
 	}
+	messageExtractor.Operation = CREATE
+	if messageExtractor.GetSpanKey() != utils.PRODUCER_CREATE_KEY {
+		t.Fatalf("Should have returned producer create key")
+	}
+	messageExtractor.Operation = SETTLE
+	if messageExtractor.GetSpanKey() != utils.CONSUMER_SETTLE_KEY {
+		t.Fatalf("Should have returned consumer settle key")
+	}
 }
 
 func TestMessageClientExtractorStartWithTemporaryDestination(t *testing.T) {
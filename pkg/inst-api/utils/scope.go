@@ -44,6 +44,22 @@ const TRPCGO_SERVER_SCOPE_NAME = "pkg/rules/trpc/trpc_server_setup.go"
 const LANGCHAIN_SCOPE_NAME = "pkg/rules/langchain/setup.go"
 const AMQP091_SCOPE_NAME = "pkg/rules/amqp091/setup.go"
 const MCP_SCOPE_NAME = "pkg/rules/mcp/setup.go"
+const MCP_SDK_SCOPE_NAME = "pkg/rules/mcpsdk/client_setup.go"
 const KAFKAGO_PRODUCER_SCOPE_NAME = "pkg/rules/segmentio-kafka-go/kafka_producer_setup.go"
 const KAFKAGO_CONSUMER_SCOPE_NAME = "pkg/rules/segmentio-kafka-go/kafka_consumer_setup.go"
+const FRANZGO_PRODUCER_SCOPE_NAME = "pkg/rules/franz-go/franz_producer_setup.go"
+const FRANZGO_CONSUMER_SCOPE_NAME = "pkg/rules/franz-go/franz_consumer_setup.go"
+const NATS_PRODUCER_SCOPE_NAME = "pkg/rules/nats/nats_producer_setup.go"
+const NATS_CONSUMER_SCOPE_NAME = "pkg/rules/nats/nats_consumer_setup.go"
+const ROCKETMQ_PRODUCER_SCOPE_NAME = "pkg/rules/rocketmq/rocketmq_producer_setup.go"
+const ROCKETMQ_CONSUMER_SCOPE_NAME = "pkg/rules/rocketmq/rocketmq_consumer_setup.go"
+const PULSAR_PRODUCER_SCOPE_NAME = "pkg/rules/pulsar/pulsar_producer_setup.go"
+const PULSAR_CONSUMER_SCOPE_NAME = "pkg/rules/pulsar/pulsar_consumer_setup.go"
 const GOPG_SCOPE_NAME = "pkg/rules/gopg/setup.go"
+const OPENAI_SCOPE_NAME = "pkg/rules/openai/chat_setup.go"
+const GOOPENAI_SCOPE_NAME = "pkg/rules/goopenai/chat_setup.go"
+const ANTHROPIC_SCOPE_NAME = "pkg/rules/anthropic/message_setup.go"
+const GENAI_SCOPE_NAME = "pkg/rules/genai/models_setup.go"
+const MILVUS_SCOPE_NAME = "pkg/rules/milvus/client_setup.go"
+const QDRANT_SCOPE_NAME = "pkg/rules/qdrant/client_setup.go"
+const WEAVIATE_SCOPE_NAME = "pkg/rules/weaviate/client_setup.go"
@@ -24,8 +24,10 @@ const HTTP_CLIENT_KEY = attribute.Key("opentelemetry-traces-span-key-http-client
 const HTTP_SERVER_KEY = attribute.Key("opentelemetry-traces-span-key-http-server")
 
 const PRODUCER_KEY = attribute.Key("opentelemetry-traces-span-key-producer")
+const PRODUCER_CREATE_KEY = attribute.Key("opentelemetry-traces-span-key-producer-create")
 const CONSUMER_RECEIVE_KEY = attribute.Key("opentelemetry-traces-span-key-consumer-receive")
 const CONSUMER_PROCESS_KEY = attribute.Key("opentelemetry-traces-span-key-consumer-process")
+const CONSUMER_SETTLE_KEY = attribute.Key("opentelemetry-traces-span-key-consumer-settle")
 
 const KIND_SERVER = attribute.Key("opentelemetry-traces-span-key-kind-server")
 const KIND_CLIENT = attribute.Key("opentelemetry-traces-span-key-kind-client")
@@ -33,4 +35,3 @@ const KIND_CONSUMER = attribute.Key("opentelemetry-traces-span-key-kind-consumer
 const KIND_PRODUCER = attribute.Key("opentelemetry-traces-span-key-kind-producer")
 
 const OTEL_CONTEXT_KEY = attribute.Key("opentelemetry-http-server-route-key")
-
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const distroName = "loongsuite-go-agent"
+
+// otelManifest describes how the binary was instrumented, the otel tool links
+// it to a variable of the main package at build time, see otel inspect. It's
+// empty if the binary was not built by the wrapper, e.g. go build -toolexec.
+var otelManifest string
+
+// distroVersion returns the version of the tool that built the binary
+func distroVersion() string {
+	begin := strings.Index(otelManifest, "{")
+	end := strings.LastIndex(otelManifest, "}")
+	if begin < 0 || end < begin {
+		return ""
+	}
+	manifest := struct{ ToolVersion string }{}
+	err := json.Unmarshal([]byte(otelManifest[begin:end+1]), &manifest)
+	if err != nil {
+		return ""
+	}
+	return manifest.ToolVersion
+}
+
+// newResource adds the telemetry.distro.* attributes to the default resource
+func newResource() *resource.Resource {
+	attrs := []attribute.KeyValue{
+		attribute.String("telemetry.distro.name", distroName),
+	}
+	if version := distroVersion(); version != "" {
+		attrs = append(attrs,
+			attribute.String("telemetry.distro.version", version))
+	}
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(attrs...))
+	if err != nil {
+		// Schema conflicts are not expected as the attributes are schemaless
+		return resource.Default()
+	}
+	return res
+}
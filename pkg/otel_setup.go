@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/core/meter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/experimental"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/http"
@@ -132,9 +133,11 @@ func initOpenTelemetry(ctx context.Context) error {
 
 	if batchSpanProcessor != nil {
 		traceProvider = trace.NewTracerProvider(
+			trace.WithResource(newResource()),
 			trace.WithSpanProcessor(batchSpanProcessor))
 	} else {
-		traceProvider = trace.NewTracerProvider()
+		traceProvider = trace.NewTracerProvider(
+			trace.WithResource(newResource()))
 	}
 
 	otel.SetTracerProvider(traceProvider)
@@ -148,6 +151,7 @@ func initMetrics() error {
 	var err error
 	if testaccess.IsInTest() {
 		metricsProvider = metric.NewMeterProvider(
+			metric.WithResource(newResource()),
 			metric.WithReader(testaccess.ManualReader),
 		)
 	} else {
@@ -156,6 +160,7 @@ func initMetrics() error {
 		} else if os.Getenv(metrics_exporter) == "console" {
 			metricExporter, err = stdoutmetric.New()
 			metricsProvider = metric.NewMeterProvider(
+				metric.WithResource(newResource()),
 				metric.WithReader(metric.NewPeriodicReader(metricExporter)),
 			)
 		} else if os.Getenv(metrics_exporter) == "prometheus" {
@@ -164,6 +169,7 @@ func initMetrics() error {
 				log.Fatalf("Failed to create prometheus metric exporter: %v", err)
 			}
 			metricsProvider = metric.NewMeterProvider(
+				metric.WithResource(newResource()),
 				metric.WithReader(promExporter),
 			)
 			go serveMetrics()
@@ -171,11 +177,13 @@ func initMetrics() error {
 			if os.Getenv(report_protocol) == "grpc" || os.Getenv(trace_report_protocol) == "grpc" {
 				metricExporter, err = otlpmetricgrpc.New(ctx)
 				metricsProvider = metric.NewMeterProvider(
+					metric.WithResource(newResource()),
 					metric.WithReader(metric.NewPeriodicReader(metricExporter)),
 				)
 			} else {
 				metricExporter, err = otlpmetrichttp.New(ctx)
 				metricsProvider = metric.NewMeterProvider(
+					metric.WithResource(newResource()),
 					metric.WithReader(metric.NewPeriodicReader(metricExporter)),
 				)
 			}
@@ -196,8 +204,12 @@ func initMetrics() error {
 	rpc.InitRpcMetrics(m)
 	// init db metrics
 	db.InitDbMetrics(m)
+	// init gen ai metrics
+	ai.InitAIMetrics(m)
 	// nacos experimental metrics
 	experimental.InitNacosExperimentalMetrics(m)
+	// sentinel metrics
+	experimental.InitSentinelMetrics(m)
 	// DefaultMinimumReadMemStatsInterval is 15 second
 	return otelruntime.Start(otelruntime.WithMeterProvider(metricsProvider))
 }
@@ -15,11 +15,14 @@
 package amqp091
 
 import (
+	"context"
+
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 )
@@ -111,6 +114,83 @@ func (r *carrierGetter) Keys() []string {
 	return keys
 }
 
+type RabbitMQSettleGetter struct {
+}
+
+var _ message.MessageAttrsGetter[RabbitSettleRequest, any] = RabbitMQSettleGetter{}
+
+func (RabbitMQSettleGetter) GetSystem(request RabbitSettleRequest) string {
+	return "rabbitmq"
+}
+
+func (RabbitMQSettleGetter) GetDestination(request RabbitSettleRequest) string {
+	return request.destinationName
+}
+
+func (RabbitMQSettleGetter) GetDestinationTemplate(request RabbitSettleRequest) string {
+	return ""
+}
+
+func (RabbitMQSettleGetter) IsTemporaryDestination(request RabbitSettleRequest) bool {
+	return false
+}
+
+func (RabbitMQSettleGetter) IsAnonymousDestination(request RabbitSettleRequest) bool {
+	return false
+}
+
+func (RabbitMQSettleGetter) GetConversationId(request RabbitSettleRequest) string {
+	return request.conversationID
+}
+
+func (RabbitMQSettleGetter) GetMessageBodySize(request RabbitSettleRequest) int64 {
+	return 0
+}
+
+func (RabbitMQSettleGetter) GetMessageEnvelopSize(request RabbitSettleRequest) int64 {
+	return 0
+}
+
+func (RabbitMQSettleGetter) GetMessageId(request RabbitSettleRequest, response any) string {
+	return request.messageId
+}
+
+func (RabbitMQSettleGetter) GetClientId(request RabbitSettleRequest) string {
+	return ""
+}
+
+func (RabbitMQSettleGetter) GetBatchMessageCount(request RabbitSettleRequest, response any) int64 {
+	return 0
+}
+
+func (RabbitMQSettleGetter) GetMessageHeader(request RabbitSettleRequest, name string) []string {
+	return []string{}
+}
+
+func (RabbitMQSettleGetter) GetDestinationPartitionId(request RabbitSettleRequest) string {
+	return ""
+}
+
+// deliveryContextCustomizer stores the consume span context in the received
+// Delivery. The context is kept for the settle span and is also injected into
+// the Delivery headers, so the message handler can continue the trace with
+// otel.GetTextMapPropagator().Extract(ctx, carrier(delivery.Headers)).
+type deliveryContextCustomizer struct {
+}
+
+func (deliveryContextCustomizer) OnStart(ctx context.Context, request RabbitRequest, startAttributes []attribute.KeyValue) context.Context {
+	delivery := request.delivery
+	if delivery == nil {
+		return ctx
+	}
+	delivery.OtelContext = ctx
+	if delivery.Headers == nil {
+		delivery.Headers = make(map[string]interface{})
+	}
+	otel.GetTextMapPropagator().Inject(ctx, &carrierGetter{req: RabbitRequest{headers: delivery.Headers}})
+	return ctx
+}
+
 func BuildRabbitMQConsumeOtelInstrumenter() *instrumenter.PropagatingFromUpstreamInstrumenter[RabbitRequest, any] {
 	builder := instrumenter.Builder[RabbitRequest, any]{}
 	return builder.Init().SetSpanNameExtractor(&message.MessageSpanNameExtractor[RabbitRequest, any]{Getter: RabbitMQGetter{}, OperationName: message.RECEIVE}).
@@ -120,6 +200,7 @@ func BuildRabbitMQConsumeOtelInstrumenter() *instrumenter.PropagatingFromUpstrea
 			Name:    utils.AMQP091_SCOPE_NAME,
 			Version: version.Tag,
 		}).
+		AddContextCustomizers(deliveryContextCustomizer{}).
 		BuildPropagatingFromUpstreamInstrumenter(func(n RabbitRequest) propagation.TextMapCarrier {
 			return &carrierGetter{req: n}
 		}, otel.GetTextMapPropagator())
@@ -138,3 +219,15 @@ func BuildRabbitMQPublishOtelInstrumenter() *instrumenter.PropagatingToDownstrea
 		}, otel.GetTextMapPropagator())
 
 }
+
+func BuildRabbitMQSettleOtelInstrumenter() *instrumenter.InternalInstrumenter[RabbitSettleRequest, any] {
+	builder := instrumenter.Builder[RabbitSettleRequest, any]{}
+	return builder.Init().SetSpanNameExtractor(&message.MessageSpanNameExtractor[RabbitSettleRequest, any]{Getter: RabbitMQSettleGetter{}, OperationName: message.SETTLE}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[RabbitSettleRequest]{}).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.AMQP091_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[RabbitSettleRequest, any, RabbitMQSettleGetter]{Operation: message.SETTLE}).
+		BuildInstrumenter()
+}
@@ -16,6 +16,7 @@ package amqp091
 
 import (
 	"context"
+	"time"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
@@ -31,26 +32,11 @@ func consumeOnEnter(call api.CallContext,
 	tag string,
 	msg *amqp.Delivery,
 ) {
-	request := RabbitRequest{
-		operationName:   "receive",
-		destinationName: msg.Exchange + ":" + msg.RoutingKey,
-		messageId:       msg.MessageId,
-		bodySize:        int64(len(msg.Body)),
-		conversationID:  msg.CorrelationId,
-		headers:         msg.Headers,
-	}
+	request, attributes := buildConsumeRequest(msg)
+	// There is no caller context for pushed deliveries, the consume span is
+	// continued from the publisher context carried in the headers and is
+	// handed to the message handler through the Delivery
 	ctx := context.Background()
-	var attributes []attribute.KeyValue
-	attributes = append(attributes,
-		semconv.MessagingRabbitmqDestinationRoutingKey(msg.RoutingKey),
-		semconv.MessagingRabbitmqMessageDeliveryTag(int(msg.DeliveryTag)), attribute.KeyValue{
-			Key:   semconv.MessagingOperationTypeKey,
-			Value: attribute.StringValue(request.operationName),
-		}, attribute.KeyValue{
-			Key:   "messaging.rabbitmq.message.consumer_tag",
-			Value: attribute.StringValue(msg.ConsumerTag),
-		},
-	)
 	ctx = RabbitMQConsumeInstrumenter.Start(ctx, request, trace.WithAttributes(attributes...))
 	data := make(map[string]interface{})
 	data["ctx"] = ctx
@@ -74,3 +60,77 @@ func consumeOnExit(call api.CallContext, b bool) {
 	}
 	RabbitMQConsumeInstrumenter.End(ctx, request, nil, nil)
 }
+
+//go:linkname channelGetOnEnter github.com/rabbitmq/amqp091-go.channelGetOnEnter
+func channelGetOnEnter(call api.CallContext, ch *amqp.Channel, queue string, autoAck bool) {
+	data := make(map[string]interface{})
+	data["queue"] = queue
+	data["startTimestamp"] = time.Now()
+	call.SetData(data)
+}
+
+// channelGetOnExit records a receive span for a delivery pulled with
+// Channel.Get, polls on an empty queue are not recorded
+//
+//go:linkname channelGetOnExit github.com/rabbitmq/amqp091-go.channelGetOnExit
+func channelGetOnExit(call api.CallContext, msg amqp.Delivery, ok bool, err error) {
+	data, dataOk := call.GetData().(map[string]interface{})
+	if !dataOk {
+		return
+	}
+	startTimestamp, dataOk := data["startTimestamp"].(time.Time)
+	if !dataOk {
+		return
+	}
+	if !ok && err == nil {
+		return
+	}
+	var request RabbitRequest
+	var attributes []attribute.KeyValue
+	if ok {
+		request, attributes = buildConsumeRequest(&msg)
+	} else {
+		queue, _ := data["queue"].(string)
+		request = RabbitRequest{
+			operationName:   "receive",
+			destinationName: queue,
+		}
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   semconv.MessagingOperationTypeKey,
+			Value: attribute.StringValue(request.operationName),
+		})
+	}
+	RabbitMQConsumeInstrumenter.StartAndEndWithOptions(context.Background(), request, nil, err,
+		startTimestamp, time.Now(), []trace.SpanStartOption{trace.WithAttributes(attributes...)}, nil)
+	if ok {
+		// The consume span context is stored in the returned Delivery
+		call.SetReturnVal(0, msg)
+	}
+}
+
+func buildConsumeRequest(msg *amqp.Delivery) (RabbitRequest, []attribute.KeyValue) {
+	request := RabbitRequest{
+		operationName:   "receive",
+		destinationName: msg.Exchange + ":" + msg.RoutingKey,
+		messageId:       msg.MessageId,
+		bodySize:        int64(len(msg.Body)),
+		conversationID:  msg.CorrelationId,
+		headers:         msg.Headers,
+		delivery:        msg,
+	}
+	var attributes []attribute.KeyValue
+	attributes = append(attributes,
+		semconv.MessagingRabbitmqDestinationRoutingKey(msg.RoutingKey),
+		semconv.MessagingRabbitmqMessageDeliveryTag(int(msg.DeliveryTag)), attribute.KeyValue{
+			Key:   semconv.MessagingOperationTypeKey,
+			Value: attribute.StringValue(request.operationName),
+		},
+	)
+	if msg.ConsumerTag != "" {
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   "messaging.rabbitmq.message.consumer_tag",
+			Value: attribute.StringValue(msg.ConsumerTag),
+		})
+	}
+	return request, attributes
+}
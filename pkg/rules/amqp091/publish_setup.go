@@ -16,6 +16,7 @@ package amqp091
 
 import (
 	"context"
+	"errors"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
@@ -25,6 +26,8 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+var errPublishNacked = errors.New("publishing was not confirmed by the broker")
+
 //go:linkname publishWithDeferredConfirmOnEnter github.com/rabbitmq/amqp091-go.publishWithDeferredConfirmOnEnter
 func publishWithDeferredConfirmOnEnter(call api.CallContext,
 	ch *amqp.Channel,
@@ -71,5 +74,24 @@ func publishWithDeferredConfirmOnExit(call api.CallContext, confirm *amqp.Deferr
 	if !ok {
 		return
 	}
-	RabbitMQPublishInstrumenter.End(ctx, request, nil, err)
+	if err != nil || confirm == nil {
+		RabbitMQPublishInstrumenter.End(ctx, request, nil, err)
+		return
+	}
+	// The channel is in confirm mode, the publish span is ended once the
+	// broker confirms the message. Pending confirmations are nacked when the
+	// channel is closed, so the waiting goroutine always finishes.
+	go func() {
+		<-confirm.Done()
+		acked := confirm.Acked()
+		trace.SpanFromContext(ctx).SetAttributes(attribute.KeyValue{
+			Key:   "messaging.rabbitmq.message.confirmed",
+			Value: attribute.BoolValue(acked),
+		})
+		var confirmErr error
+		if !acked {
+			confirmErr = errPublishNacked
+		}
+		RabbitMQPublishInstrumenter.End(ctx, request, nil, confirmErr)
+	}()
 }
@@ -14,6 +14,10 @@
 
 package amqp091
 
+import (
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
 type RabbitRequest struct {
 	operationName   string
 	destinationName string
@@ -21,4 +25,19 @@ type RabbitRequest struct {
 	bodySize        int64
 	conversationID  string
 	headers         map[string]interface{}
+	// delivery is the received message, the consume span context is stored
+	// in it so that it can be read by the message handler
+	delivery *amqp.Delivery
+}
+
+// RabbitSettleRequest describes a Delivery.Ack, Delivery.Nack or
+// Delivery.Reject call
+type RabbitSettleRequest struct {
+	destinationName string
+	messageId       string
+	conversationID  string
+	deliveryTag     uint64
+	outcome         string
+	multiple        bool
+	requeue         bool
 }
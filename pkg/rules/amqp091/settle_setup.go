@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqp091
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//go:linkname deliveryAckOnEnter github.com/rabbitmq/amqp091-go.deliveryAckOnEnter
+func deliveryAckOnEnter(call api.CallContext, d amqp.Delivery, multiple bool) {
+	settleOnEnter(call, d, "ack", multiple, false)
+}
+
+//go:linkname deliveryNackOnEnter github.com/rabbitmq/amqp091-go.deliveryNackOnEnter
+func deliveryNackOnEnter(call api.CallContext, d amqp.Delivery, multiple, requeue bool) {
+	settleOnEnter(call, d, "nack", multiple, requeue)
+}
+
+//go:linkname deliveryRejectOnEnter github.com/rabbitmq/amqp091-go.deliveryRejectOnEnter
+func deliveryRejectOnEnter(call api.CallContext, d amqp.Delivery, requeue bool) {
+	settleOnEnter(call, d, "reject", false, requeue)
+}
+
+//go:linkname deliveryAckOnExit github.com/rabbitmq/amqp091-go.deliveryAckOnExit
+func deliveryAckOnExit(call api.CallContext, err error) {
+	settleOnExit(call, err)
+}
+
+//go:linkname deliveryNackOnExit github.com/rabbitmq/amqp091-go.deliveryNackOnExit
+func deliveryNackOnExit(call api.CallContext, err error) {
+	settleOnExit(call, err)
+}
+
+//go:linkname deliveryRejectOnExit github.com/rabbitmq/amqp091-go.deliveryRejectOnExit
+func deliveryRejectOnExit(call api.CallContext, err error) {
+	settleOnExit(call, err)
+}
+
+// settleOnEnter starts a settle span for the delivery. The span is a child of
+// the consume span stored in the Delivery, so settlement shows up in the same
+// trace as the consumption of the message.
+func settleOnEnter(call api.CallContext, d amqp.Delivery, outcome string, multiple, requeue bool) {
+	ctx, ok := d.OtelContext.(context.Context)
+	if !ok || ctx == nil {
+		ctx = context.Background()
+	}
+	request := RabbitSettleRequest{
+		destinationName: d.Exchange + ":" + d.RoutingKey,
+		messageId:       d.MessageId,
+		conversationID:  d.CorrelationId,
+		deliveryTag:     d.DeliveryTag,
+		outcome:         outcome,
+		multiple:        multiple,
+		requeue:         requeue,
+	}
+	var attributes []attribute.KeyValue
+	attributes = append(attributes,
+		semconv.MessagingRabbitmqDestinationRoutingKey(d.RoutingKey),
+		semconv.MessagingRabbitmqMessageDeliveryTag(int(d.DeliveryTag)), attribute.KeyValue{
+			Key:   semconv.MessagingOperationTypeKey,
+			Value: attribute.StringValue("settle"),
+		}, attribute.KeyValue{
+			Key:   "messaging.rabbitmq.message.settle_outcome",
+			Value: attribute.StringValue(outcome),
+		}, attribute.KeyValue{
+			Key:   "messaging.rabbitmq.message.settle_multiple",
+			Value: attribute.BoolValue(multiple),
+		},
+	)
+	if outcome != "ack" {
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   "messaging.rabbitmq.message.requeue",
+			Value: attribute.BoolValue(requeue),
+		})
+	}
+	ctx = RabbitMQSettleInstrumenter.Start(ctx, request, trace.WithAttributes(attributes...))
+	data := make(map[string]interface{})
+	data["ctx"] = ctx
+	data["rabbitMQ_settle_request"] = request
+	call.SetData(data)
+}
+
+func settleOnExit(call api.CallContext, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, ok := data["rabbitMQ_settle_request"].(RabbitSettleRequest)
+	if !ok {
+		return
+	}
+	RabbitMQSettleInstrumenter.End(ctx, request, nil, err)
+}
@@ -16,3 +16,4 @@ package amqp091
 
 var RabbitMQConsumeInstrumenter = BuildRabbitMQConsumeOtelInstrumenter()
 var RabbitMQPublishInstrumenter = BuildRabbitMQPublishOtelInstrumenter()
+var RabbitMQSettleInstrumenter = BuildRabbitMQSettleOtelInstrumenter()
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anthropic
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/anthropics/anthropic-sdk-go"
+)
+
+func marshalInput(input any) string {
+	if raw, ok := input.(json.RawMessage); ok {
+		return string(raw)
+	}
+	b, err := json.Marshal(input)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func joinTexts(blocks []anthropic.TextBlockParam) string {
+	texts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		texts = append(texts, block.Text)
+	}
+	return strings.Join(texts, "\n")
+}
+
+// toGenAIMessages converts the prompt of a request. Tool results travel in
+// user messages for Anthropic, they are reported as tool messages instead.
+func toGenAIMessages(system []anthropic.TextBlockParam, messages []anthropic.MessageParam) []ai.GenAIMessage {
+	res := make([]ai.GenAIMessage, 0, len(messages)+1)
+	if len(system) > 0 {
+		res = append(res, ai.GenAIMessage{Role: ai.GenAIRoleSystem, Content: joinTexts(system)})
+	}
+	for _, message := range messages {
+		msg := ai.GenAIMessage{Role: ai.GenAIRoleUser}
+		if message.Role == anthropic.MessageParamRoleAssistant {
+			msg.Role = ai.GenAIRoleAssistant
+		}
+		var texts []string
+		toolResults := 0
+		for _, block := range message.Content {
+			switch {
+			case block.OfText != nil:
+				texts = append(texts, block.OfText.Text)
+			case block.OfToolUse != nil:
+				msg.ToolCalls = append(msg.ToolCalls, ai.GenAIToolCall{
+					ID:        block.OfToolUse.ID,
+					Name:      block.OfToolUse.Name,
+					Arguments: marshalInput(block.OfToolUse.Input),
+				})
+			case block.OfToolResult != nil:
+				toolResults++
+				var results []string
+				for _, content := range block.OfToolResult.Content {
+					if content.OfText != nil {
+						results = append(results, content.OfText.Text)
+					}
+				}
+				res = append(res, ai.GenAIMessage{
+					Role:       ai.GenAIRoleTool,
+					Content:    strings.Join(results, "\n"),
+					ToolCallID: block.OfToolResult.ToolUseID,
+				})
+			}
+		}
+		if toolResults > 0 && len(texts) == 0 && len(msg.ToolCalls) == 0 {
+			continue
+		}
+		msg.Content = strings.Join(texts, "\n")
+		res = append(res, msg)
+	}
+	return res
+}
+
+func toGenAIChoices(res *anthropic.Message) []ai.GenAIChoice {
+	msg := ai.GenAIMessage{Role: ai.GenAIRoleAssistant}
+	var texts []string
+	for _, block := range res.Content {
+		switch block.Type {
+		case "text":
+			texts = append(texts, block.Text)
+		case "tool_use":
+			msg.ToolCalls = append(msg.ToolCalls, ai.GenAIToolCall{
+				ID:        block.ID,
+				Name:      block.Name,
+				Arguments: string(block.Input),
+			})
+		}
+	}
+	msg.Content = strings.Join(texts, "\n")
+	return []ai.GenAIChoice{{FinishReason: string(res.StopReason), Message: msg}}
+}
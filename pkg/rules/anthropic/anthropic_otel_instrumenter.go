@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anthropic
+
+import (
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+const anthropicSystem = "anthropic"
+
+type anthropicInnerEnabler struct {
+	enabled bool
+}
+
+func (a anthropicInnerEnabler) Enable() bool {
+	return a.enabled
+}
+
+var anthropicEnabler = anthropicInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_ANTHROPIC_ENABLED") != "false"}
+
+var anthropicInstrument = BuildAnthropicInstrumenter()
+
+type anthropicAttrsGetter struct{}
+
+var _ ai.LLMAttrsGetter[anthropicRequest, anthropicResponse] = anthropicAttrsGetter{}
+var _ ai.CommonAttrsGetter[anthropicRequest, anthropicResponse] = anthropicAttrsGetter{}
+
+func (anthropicAttrsGetter) GetAIOperationName(request anthropicRequest) string {
+	return request.operationName
+}
+
+func (anthropicAttrsGetter) GetAISystem(request anthropicRequest) string {
+	return anthropicSystem
+}
+
+func (anthropicAttrsGetter) GetAIRequestModel(request anthropicRequest) string {
+	return request.modelName
+}
+
+func (anthropicAttrsGetter) GetAIRequestEncodingFormats(request anthropicRequest) []string {
+	return nil
+}
+
+func (anthropicAttrsGetter) GetAIRequestFrequencyPenalty(request anthropicRequest) float64 {
+	return 0
+}
+
+func (anthropicAttrsGetter) GetAIRequestPresencePenalty(request anthropicRequest) float64 {
+	return 0
+}
+
+func (anthropicAttrsGetter) GetAIResponseFinishReasons(request anthropicRequest, response anthropicResponse) []string {
+	return response.responseFinishReasons
+}
+
+func (anthropicAttrsGetter) GetAIResponseModel(request anthropicRequest, response anthropicResponse) string {
+	return response.responseModel
+}
+
+func (anthropicAttrsGetter) GetAIRequestMaxTokens(request anthropicRequest) int64 {
+	return request.maxTokens
+}
+
+func (anthropicAttrsGetter) GetAIUsageInputTokens(request anthropicRequest) int64 {
+	return request.usageInputTokens
+}
+
+func (anthropicAttrsGetter) GetAIUsageOutputTokens(request anthropicRequest, response anthropicResponse) int64 {
+	return response.usageOutputTokens
+}
+
+func (anthropicAttrsGetter) GetAIRequestStopSequences(request anthropicRequest) []string {
+	return request.stopSequences
+}
+
+func (anthropicAttrsGetter) GetAIRequestTemperature(request anthropicRequest) float64 {
+	return request.temperature
+}
+
+func (anthropicAttrsGetter) GetAIRequestTopK(request anthropicRequest) float64 {
+	return float64(request.topK)
+}
+
+func (anthropicAttrsGetter) GetAIRequestTopP(request anthropicRequest) float64 {
+	return request.topP
+}
+
+func (anthropicAttrsGetter) GetAIResponseID(request anthropicRequest, response anthropicResponse) string {
+	return response.responseID
+}
+
+func (anthropicAttrsGetter) GetAIServerAddress(request anthropicRequest) string {
+	// the base url lives in the unexported request config of the client
+	return ""
+}
+
+func (anthropicAttrsGetter) GetAIRequestSeed(request anthropicRequest) int64 {
+	return 0
+}
+
+func BuildAnthropicInstrumenter() instrumenter.Instrumenter[anthropicRequest, anthropicResponse] {
+	builder := instrumenter.Builder[anthropicRequest, anthropicResponse]{}
+	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[anthropicRequest, anthropicResponse]{Getter: anthropicAttrsGetter{}}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[anthropicRequest]{}).
+		AddAttributesExtractor(&ai.AILLMAttrsExtractor[anthropicRequest, anthropicResponse, anthropicAttrsGetter, anthropicAttrsGetter]{}).
+		AddOperationListeners(ai.AIClientMetrics("anthropic.llm")).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.ANTHROPIC_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anthropic
+
+import (
+	"context"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+	"github.com/anthropics/anthropic-sdk-go/packages/ssestream"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func startMessage(call api.CallContext, ctx context.Context, body anthropic.MessageNewParams) {
+	if !anthropicEnabler.Enable() {
+		return
+	}
+	request := anthropicRequest{
+		operationName: "chat",
+		modelName:     string(body.Model),
+		maxTokens:     body.MaxTokens,
+		stopSequences: body.StopSequences,
+		temperature:   body.Temperature.Value,
+		topK:          body.TopK.Value,
+		topP:          body.TopP.Value,
+		startTime:     time.Now(),
+	}
+	ctx = anthropicInstrument.Start(ctx, request)
+	call.SetParam(1, ctx)
+	if ai.CaptureMessageContent() {
+		ai.RecordInputMessages(trace.SpanFromContext(ctx), anthropicSystem, toGenAIMessages(body.System, body.Messages))
+	}
+	data := make(map[string]interface{}, 2)
+	data["ctx"] = ctx
+	data["request"] = request
+	call.SetData(data)
+}
+
+// inputTokens sums up the prompt tokens, Anthropic reports the tokens read
+// from or written to the prompt cache apart from the uncached ones.
+func inputTokens(input, cacheCreation, cacheRead int64) int64 {
+	return input + cacheCreation + cacheRead
+}
+
+//go:linkname messageNewOnEnter github.com/anthropics/anthropic-sdk-go.messageNewOnEnter
+func messageNewOnEnter(call api.CallContext, r *anthropic.MessageService, ctx context.Context,
+	body anthropic.MessageNewParams, opts ...option.RequestOption) {
+	startMessage(call, ctx, body)
+}
+
+//go:linkname messageNewOnExit github.com/anthropics/anthropic-sdk-go.messageNewOnExit
+func messageNewOnExit(call api.CallContext, res *anthropic.Message, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx := data["ctx"].(context.Context)
+	request := data["request"].(anthropicRequest)
+	response := anthropicResponse{}
+	if res != nil {
+		response.responseID = res.ID
+		response.responseModel = string(res.Model)
+		if res.StopReason != "" {
+			response.responseFinishReasons = []string{string(res.StopReason)}
+		}
+		request.usageInputTokens = inputTokens(res.Usage.InputTokens,
+			res.Usage.CacheCreationInputTokens, res.Usage.CacheReadInputTokens)
+		response.usageOutputTokens = res.Usage.OutputTokens
+		if ai.CaptureMessageContent() {
+			ai.RecordChoices(trace.SpanFromContext(ctx), anthropicSystem, toGenAIChoices(res))
+		}
+	}
+	anthropicInstrument.End(ctx, request, response, err)
+}
+
+//go:linkname messageNewStreamingOnEnter github.com/anthropics/anthropic-sdk-go.messageNewStreamingOnEnter
+func messageNewStreamingOnEnter(call api.CallContext, r *anthropic.MessageService, ctx context.Context,
+	body anthropic.MessageNewParams, opts ...option.RequestOption) {
+	startMessage(call, ctx, body)
+}
+
+//go:linkname messageNewStreamingOnExit github.com/anthropics/anthropic-sdk-go.messageNewStreamingOnExit
+func messageNewStreamingOnExit(call api.CallContext, stream *ssestream.Stream[anthropic.MessageStreamEventUnion]) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	wrapStream(data["ctx"].(context.Context), data["request"].(anthropicRequest), stream)
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/anthropics/anthropic-sdk-go/packages/ssestream"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type streamUsage struct {
+	InputTokens              int64 `json:"input_tokens"`
+	OutputTokens             int64 `json:"output_tokens"`
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+}
+
+// streamEvent holds the fields of the stream events the instrumentation
+// needs, it is decoded apart from the SDK types so that it does not depend
+// on their layout across versions.
+type streamEvent struct {
+	Type    string `json:"type"`
+	Index   int    `json:"index"`
+	Message struct {
+		ID    string      `json:"id"`
+		Model string      `json:"model"`
+		Usage streamUsage `json:"usage"`
+	} `json:"message"`
+	ContentBlock struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"content_block"`
+	Delta struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+		StopReason  string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage *streamUsage `json:"usage"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type contentBlock struct {
+	kind      string
+	text      strings.Builder
+	id        string
+	name      string
+	arguments strings.Builder
+}
+
+// streamDecoder sits between ssestream.Stream and the real decoder so that
+// the span covers the whole stream and ends once it is drained or closed.
+type streamDecoder struct {
+	ssestream.Decoder
+	ctx            context.Context
+	request        anthropicRequest
+	response       anthropicResponse
+	usage          streamUsage
+	stopReason     string
+	blocks         []*contentBlock
+	capture        bool
+	firstTokenTime time.Time
+	once           sync.Once
+}
+
+func (d *streamDecoder) Next() bool {
+	if !d.Decoder.Next() {
+		d.end(d.Decoder.Err())
+		return false
+	}
+	var event streamEvent
+	if err := json.Unmarshal(d.Decoder.Event().Data, &event); err != nil {
+		return true
+	}
+	if event.Type == "" {
+		event.Type = d.Decoder.Event().Type
+	}
+	switch event.Type {
+	case "message_start":
+		d.response.responseID = event.Message.ID
+		d.response.responseModel = event.Message.Model
+		d.usage = event.Message.Usage
+	case "content_block_start":
+		d.block(event.Index).kind = event.ContentBlock.Type
+		d.block(event.Index).text.WriteString(event.ContentBlock.Text)
+		d.block(event.Index).id = event.ContentBlock.ID
+		d.block(event.Index).name = event.ContentBlock.Name
+	case "content_block_delta":
+		if d.firstTokenTime.IsZero() {
+			d.firstTokenTime = time.Now()
+		}
+		if d.capture {
+			d.block(event.Index).text.WriteString(event.Delta.Text)
+			d.block(event.Index).arguments.WriteString(event.Delta.PartialJSON)
+		}
+	case "message_delta":
+		if event.Delta.StopReason != "" {
+			d.stopReason = event.Delta.StopReason
+		}
+		// the usage of message_delta is cumulative
+		if event.Usage != nil {
+			if event.Usage.InputTokens > 0 {
+				d.usage.InputTokens = event.Usage.InputTokens
+			}
+			if event.Usage.CacheCreationInputTokens > 0 {
+				d.usage.CacheCreationInputTokens = event.Usage.CacheCreationInputTokens
+			}
+			if event.Usage.CacheReadInputTokens > 0 {
+				d.usage.CacheReadInputTokens = event.Usage.CacheReadInputTokens
+			}
+			d.usage.OutputTokens = event.Usage.OutputTokens
+		}
+	case "message_stop":
+		d.end(nil)
+	case "error":
+		// the stream stops reading once an error event is received
+		d.end(errors.New(event.Error.Type + ": " + event.Error.Message))
+	}
+	return true
+}
+
+func (d *streamDecoder) block(index int) *contentBlock {
+	for len(d.blocks) <= index {
+		d.blocks = append(d.blocks, &contentBlock{})
+	}
+	return d.blocks[index]
+}
+
+func (d *streamDecoder) Close() error {
+	err := d.Decoder.Close()
+	d.end(nil)
+	return err
+}
+
+func (d *streamDecoder) end(err error) {
+	d.once.Do(func() {
+		if d.stopReason != "" {
+			d.response.responseFinishReasons = []string{d.stopReason}
+		}
+		d.request.usageInputTokens = inputTokens(d.usage.InputTokens,
+			d.usage.CacheCreationInputTokens, d.usage.CacheReadInputTokens)
+		d.response.usageOutputTokens = d.usage.OutputTokens
+		if d.capture {
+			ai.RecordChoices(trace.SpanFromContext(d.ctx), anthropicSystem, d.choices())
+		}
+		ctx := d.ctx
+		if !d.firstTokenTime.IsZero() {
+			ctx = ai.ContextWithTimeToFirstToken(ctx, d.firstTokenTime.Sub(d.request.startTime))
+		}
+		anthropicInstrument.End(ctx, d.request, d.response, err)
+	})
+}
+
+func (d *streamDecoder) choices() []ai.GenAIChoice {
+	msg := ai.GenAIMessage{Role: ai.GenAIRoleAssistant}
+	var texts []string
+	for _, block := range d.blocks {
+		switch block.kind {
+		case "text":
+			texts = append(texts, block.text.String())
+		case "tool_use":
+			msg.ToolCalls = append(msg.ToolCalls, ai.GenAIToolCall{
+				ID:        block.id,
+				Name:      block.name,
+				Arguments: block.arguments.String(),
+			})
+		}
+	}
+	msg.Content = strings.Join(texts, "\n")
+	return []ai.GenAIChoice{{FinishReason: d.stopReason, Message: msg}}
+}
+
+// wrapStream replaces the unexported decoder of the stream returned by
+// MessageService.NewStreaming.
+func wrapStream(ctx context.Context, request anthropicRequest, stream any) {
+	v := reflect.ValueOf(stream)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		anthropicInstrument.End(ctx, request, anthropicResponse{}, errors.New("nil stream"))
+		return
+	}
+	decoder := v.Elem().FieldByName("decoder")
+	if !decoder.IsValid() {
+		anthropicInstrument.End(ctx, request, anthropicResponse{}, nil)
+		return
+	}
+	decoder = reflect.NewAt(decoder.Type(), unsafe.Pointer(decoder.UnsafeAddr())).Elem()
+	if decoder.IsNil() {
+		// the request failed, the stream only carries the error
+		var err error
+		if f := v.Elem().FieldByName("err"); f.IsValid() {
+			err, _ = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem().Interface().(error)
+		}
+		anthropicInstrument.End(ctx, request, anthropicResponse{}, err)
+		return
+	}
+	decoder.Set(reflect.ValueOf(&streamDecoder{
+		Decoder: decoder.Interface().(ssestream.Decoder),
+		ctx:     ctx,
+		request: request,
+		capture: ai.CaptureMessageContent(),
+	}))
+}
@@ -14,6 +14,8 @@
 
 package eino
 
+import "time"
+
 type (
 	promptRequestKey    struct{}
 	llmRequestKey       struct{}
@@ -48,6 +50,7 @@ type einoLLMRequest struct {
 	topP             float64
 	serverAddress    string
 	seed             int64
+	startTime        time.Time
 }
 type einoLLMResponse struct {
 	responseFinishReasons []string
@@ -19,7 +19,9 @@ import (
 	"io"
 	"log"
 	"runtime/debug"
+	"time"
 
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
 	"github.com/bytedance/sonic"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/document"
@@ -31,6 +33,7 @@ import (
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
 	callbacksutils "github.com/cloudwego/eino/utils/callbacks"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var einoLLMInstrument = BuildEinoLLMInstrumenter()
@@ -72,6 +75,7 @@ func einoModelCallHandler(config ChatModelConfig) *callbacksutils.ModelCallbackH
 				presencePenalty:  config.PresencePenalty,
 				seed:             config.Seed,
 				topK:             config.TopK,
+				startTime:        time.Now(),
 			}
 			if clientConfig != nil {
 				request.modelName = clientConfig.Model
@@ -81,13 +85,17 @@ func einoModelCallHandler(config ChatModelConfig) *callbacksutils.ModelCallbackH
 				request.topP = float64(clientConfig.TopP)
 			}
 			ctx = einoLLMInstrument.Start(ctx, request)
+			if ai.CaptureMessageContent() {
+				ai.RecordInputMessages(trace.SpanFromContext(ctx), "eino", toGenAIMessages(input.Messages))
+			}
 			return context.WithValue(ctx, llmRequestKey{}, request)
 		},
 		OnEnd: func(ctx context.Context, runInfo *callbacks.RunInfo, output *model.CallbackOutput) context.Context {
 			request := ctx.Value(llmRequestKey{}).(einoLLMRequest)
 			response := einoLLMResponse{}
 			if output.TokenUsage != nil {
-				response.usageOutputTokens = int64(output.TokenUsage.TotalTokens)
+				request.usageInputTokens = int64(output.TokenUsage.PromptTokens)
+				response.usageOutputTokens = int64(output.TokenUsage.CompletionTokens)
 			}
 			if output.Message != nil && output.Message.ResponseMeta != nil {
 				response.responseFinishReasons = []string{output.Message.ResponseMeta.FinishReason}
@@ -95,6 +103,9 @@ func einoModelCallHandler(config ChatModelConfig) *callbacksutils.ModelCallbackH
 			if output.Config != nil {
 				response.responseModel = output.Config.Model
 			}
+			if ai.CaptureMessageContent() {
+				ai.RecordChoices(trace.SpanFromContext(ctx), "eino", toGenAIChoices(output.Message))
+			}
 			einoLLMInstrument.End(ctx, request, response, nil)
 			return ctx
 		},
@@ -110,6 +121,7 @@ func einoModelCallHandler(config ChatModelConfig) *callbacksutils.ModelCallbackH
 				}()
 				response := einoLLMResponse{}
 				var outs []*model.CallbackOutput
+				var firstChunkTime time.Time
 				for {
 					chunk, err := output.Recv()
 					if err == io.EOF {
@@ -118,6 +130,9 @@ func einoModelCallHandler(config ChatModelConfig) *callbacksutils.ModelCallbackH
 					if err != nil {
 						log.Printf("read stream output error: %v, runinfo: %+v", err, runInfo)
 					}
+					if firstChunkTime.IsZero() {
+						firstChunkTime = time.Now()
+					}
 					outs = append(outs, chunk)
 				}
 
@@ -137,13 +152,23 @@ func einoModelCallHandler(config ChatModelConfig) *callbacksutils.ModelCallbackH
 				if len(mas) != 0 {
 					message, err := schema.ConcatMessages(mas)
 					if err == nil {
-						response.responseFinishReasons = []string{message.ResponseMeta.FinishReason}
+						if message.ResponseMeta != nil {
+							response.responseFinishReasons = []string{message.ResponseMeta.FinishReason}
+						}
+						if ai.CaptureMessageContent() {
+							// the concatenated message carries the reassembled content and tool calls
+							ai.RecordChoices(trace.SpanFromContext(ctx), "eino", toGenAIChoices(message))
+						}
 					}
 				}
 				if usage != nil {
-					response.usageOutputTokens = int64(usage.TotalTokens)
+					request.usageInputTokens = int64(usage.PromptTokens)
+					response.usageOutputTokens = int64(usage.CompletionTokens)
 				}
 				response.responseModel = request.modelName
+				if !firstChunkTime.IsZero() && !request.startTime.IsZero() {
+					ctx = ai.ContextWithTimeToFirstToken(ctx, firstChunkTime.Sub(request.startTime))
+				}
 				einoLLMInstrument.End(ctx, request, response, nil)
 			}()
 			return ctx
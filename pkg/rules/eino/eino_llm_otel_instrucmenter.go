@@ -104,6 +104,7 @@ func BuildEinoLLMInstrumenter() instrumenter.Instrumenter[einoLLMRequest, einoLL
 	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[einoLLMRequest, einoLLMResponse]{Getter: einoLLMAttrsGetter{}}).
 		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[einoLLMRequest]{}).
 		AddAttributesExtractor(&ai.AILLMAttrsExtractor[einoLLMRequest, einoLLMResponse, einoLLMAttrsGetter, einoLLMAttrsGetter]{}).
+		AddOperationListeners(ai.AIClientMetrics("eino.llm")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.EINO_SCOPE_NAME,
 			Version: version.Tag,
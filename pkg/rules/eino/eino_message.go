@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eino
+
+import (
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/cloudwego/eino/schema"
+)
+
+func toGenAIMessage(msg *schema.Message) ai.GenAIMessage {
+	res := ai.GenAIMessage{
+		Role:       string(msg.Role),
+		Content:    msg.Content,
+		ToolCallID: msg.ToolCallID,
+	}
+	if res.Content == "" && len(msg.MultiContent) > 0 {
+		var texts []string
+		for _, part := range msg.MultiContent {
+			if part.Type == schema.ChatMessagePartTypeText {
+				texts = append(texts, part.Text)
+			}
+		}
+		res.Content = strings.Join(texts, "\n")
+	}
+	for _, call := range msg.ToolCalls {
+		res.ToolCalls = append(res.ToolCalls, ai.GenAIToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+	return res
+}
+
+func toGenAIMessages(msgs []*schema.Message) []ai.GenAIMessage {
+	res := make([]ai.GenAIMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg != nil {
+			res = append(res, toGenAIMessage(msg))
+		}
+	}
+	return res
+}
+
+func toGenAIChoices(msg *schema.Message) []ai.GenAIChoice {
+	if msg == nil {
+		return nil
+	}
+	choice := ai.GenAIChoice{Message: toGenAIMessage(msg)}
+	if msg.ResponseMeta != nil {
+		choice.FinishReason = msg.ResponseMeta.FinishReason
+	}
+	return []ai.GenAIChoice{choice}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"context"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// franzConsumerInfoKey is the context key under which the client information
+// of a polled record is remembered for its process span
+type franzConsumerInfoKey struct{}
+
+type franzConsumerInfo struct {
+	clientId      string
+	consumerGroup string
+}
+
+//go:linkname consumerPollRecordsOnEnter github.com/twmb/franz-go/pkg/kgo.consumerPollRecordsOnEnter
+func consumerPollRecordsOnEnter(call api.CallContext, client *kgo.Client, ctx context.Context, maxPollRecords int) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	instrumentationData := map[string]interface{}{
+		"parentContext":  ctx,
+		"client":         client,
+		"startTimestamp": time.Now(),
+	}
+	call.SetData(instrumentationData)
+}
+
+// consumerPollRecordsOnExit records a single receive span for the whole poll,
+// the span links to the upstream context of every polled record. PollFetches
+// is a thin wrapper of PollRecords, so it is covered as well.
+//
+//go:linkname consumerPollRecordsOnExit github.com/twmb/franz-go/pkg/kgo.consumerPollRecordsOnExit
+func consumerPollRecordsOnExit(call api.CallContext, fetches kgo.Fetches) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	instrumentationData, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	records := fetches.Records()
+	if len(records) == 0 {
+		// Nothing was received, e.g. the poll was interrupted by a canceled
+		// context or a closed client
+		return
+	}
+	parentContext := instrumentationData["parentContext"].(context.Context)
+	client := instrumentationData["client"].(*kgo.Client)
+	startTimestamp := instrumentationData["startTimestamp"].(time.Time)
+
+	receiveRequest := franzReceiveReq{
+		records:  records,
+		topic:    records[0].Topic,
+		clientId: clientIdOf(client),
+	}
+	if client != nil {
+		receiveRequest.consumerGroup, _ = client.GroupMetadata()
+	}
+	links := make([]trace.Link, 0, len(records))
+	for _, record := range records {
+		if record.Topic != receiveRequest.topic {
+			receiveRequest.topic = ""
+		}
+		if link, ok := extractRecordLink(record); ok {
+			links = append(links, link)
+		}
+	}
+	receiveInstrumenter.StartAndEndWithOptions(parentContext, receiveRequest, nil, fetches.Err(),
+		startTimestamp, time.Now(), []trace.SpanStartOption{trace.WithLinks(links...)}, nil)
+}
+
+// franzReceiveContextCustomizer remembers the receive span and the client
+// information in every polled record, so that the process span started when
+// the record is iterated can refer to them
+type franzReceiveContextCustomizer struct{}
+
+func (customizer franzReceiveContextCustomizer) OnStart(ctx context.Context, request franzReceiveReq, startAttributes []attribute.KeyValue) context.Context {
+	receiveSpanContext := trace.SpanContextFromContext(ctx)
+	info := franzConsumerInfo{clientId: request.clientId, consumerGroup: request.consumerGroup}
+	for _, record := range request.records {
+		recordContext := record.Context
+		if recordContext == nil {
+			recordContext = context.Background()
+		}
+		recordContext = context.WithValue(recordContext, franzConsumerInfoKey{}, info)
+		record.Context = trace.ContextWithSpanContext(recordContext, receiveSpanContext)
+	}
+	return ctx
+}
+
+//go:linkname consumerRecordIterNextOnEnter github.com/twmb/franz-go/pkg/kgo.consumerRecordIterNextOnEnter
+func consumerRecordIterNextOnEnter(call api.CallContext, iter *kgo.FetchesRecordIter) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	// Processing of the previous record is finished once the next one is
+	// requested
+	endProcessSpan(iter)
+	call.SetData(iter)
+}
+
+//go:linkname consumerRecordIterNextOnExit github.com/twmb/franz-go/pkg/kgo.consumerRecordIterNextOnExit
+func consumerRecordIterNextOnExit(call api.CallContext, record *kgo.Record) {
+	if !franzEnabler.Enable() || record == nil {
+		return
+	}
+	iter, ok := call.GetData().(*kgo.FetchesRecordIter)
+	if !ok || iter == nil {
+		return
+	}
+	parentContext := record.Context
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	processRequest := franzProcessReq{record: record}
+	if info, ok := parentContext.Value(franzConsumerInfoKey{}).(franzConsumerInfo); ok {
+		processRequest.clientId = info.clientId
+		processRequest.consumerGroup = info.consumerGroup
+	}
+	var options []trace.SpanStartOption
+	if receiveSpanContext := trace.SpanContextFromContext(parentContext); receiveSpanContext.IsValid() {
+		options = append(options, trace.WithLinks(trace.Link{SpanContext: receiveSpanContext}))
+	}
+	processContext := processInstrumenter.Start(parentContext, processRequest, options...)
+	// The process span is reachable from the record so that user code can
+	// continue the trace with record.Context
+	record.Context = processContext
+	iter.OtelProcessRequest = processRequest
+	iter.OtelProcessContext = processContext
+}
+
+//go:linkname consumerRecordIterDoneOnEnter github.com/twmb/franz-go/pkg/kgo.consumerRecordIterDoneOnEnter
+func consumerRecordIterDoneOnEnter(call api.CallContext, iter *kgo.FetchesRecordIter) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	call.SetData(iter)
+}
+
+//go:linkname consumerRecordIterDoneOnExit github.com/twmb/franz-go/pkg/kgo.consumerRecordIterDoneOnExit
+func consumerRecordIterDoneOnExit(call api.CallContext, done bool) {
+	if !franzEnabler.Enable() || !done {
+		return
+	}
+	iter, ok := call.GetData().(*kgo.FetchesRecordIter)
+	if !ok || iter == nil {
+		return
+	}
+	endProcessSpan(iter)
+}
+
+func endProcessSpan(iter *kgo.FetchesRecordIter) {
+	if iter == nil {
+		return
+	}
+	processContext, ok := iter.OtelProcessContext.(context.Context)
+	if !ok {
+		return
+	}
+	processRequest, ok := iter.OtelProcessRequest.(franzProcessReq)
+	if !ok {
+		return
+	}
+	iter.OtelProcessContext = nil
+	iter.OtelProcessRequest = nil
+	processInstrumenter.End(processContext, processRequest, nil, nil)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// franzProducerReq describes a single record passed to Client.Produce
+type franzProducerReq struct {
+	record   *kgo.Record
+	clientId string
+}
+
+// franzReceiveReq describes all records returned by one Client.PollRecords
+type franzReceiveReq struct {
+	records       []*kgo.Record
+	topic         string
+	clientId      string
+	consumerGroup string
+}
+
+// franzProcessReq describes a single record handed out by the record iterator
+type franzProcessReq struct {
+	record        *kgo.Record
+	clientId      string
+	consumerGroup string
+}
@@ -0,0 +1,440 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Instrumentation enabler controller
+var franzEnabler = franzInnerEnabler{os.Getenv("OTEL_FRANZ_GO_ENABLED") != "false"}
+
+// Cache Instrumenter instances to avoid repeated creation
+var (
+	producerInstrumenter = buildFranzProducerInstrumenter()
+	receiveInstrumenter  = buildFranzReceiveInstrumenter()
+	processInstrumenter  = buildFranzProcessInstrumenter()
+)
+
+type franzInnerEnabler struct {
+	enabled bool
+}
+
+func (f franzInnerEnabler) Enable() bool {
+	return f.enabled
+}
+
+// franzRecordCarrier implements OpenTelemetry propagator carrier interface on
+// top of the record headers, it is used by both producer and consumer side
+type franzRecordCarrier struct {
+	record *kgo.Record
+}
+
+func (carrier franzRecordCarrier) Get(key string) string {
+	for _, header := range carrier.record.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (carrier franzRecordCarrier) Set(key, value string) {
+	// Overwrite the existing header so that re-producing a consumed record
+	// does not carry the upstream context along with the new one
+	for i, header := range carrier.record.Headers {
+		if header.Key == key {
+			carrier.record.Headers[i].Value = []byte(value)
+			return
+		}
+	}
+	carrier.record.Headers = append(carrier.record.Headers, kgo.RecordHeader{
+		Key:   key,
+		Value: []byte(value),
+	})
+}
+
+func (carrier franzRecordCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier.record.Headers))
+	for _, header := range carrier.record.Headers {
+		keys = append(keys, header.Key)
+	}
+	return keys
+}
+
+// extractRecordLink builds a span link pointing to the upstream context that
+// the producer injected into the record headers
+func extractRecordLink(record *kgo.Record) (trace.Link, bool) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(),
+		franzRecordCarrier{record: record})
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return trace.Link{}, false
+	}
+	return trace.Link{
+		SpanContext: spanContext,
+		Attributes: []attribute.KeyValue{
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(int(record.Partition))),
+			semconv.MessagingKafkaOffset(int(record.Offset)),
+		},
+	}, true
+}
+
+func recordBodySize(record *kgo.Record) int64 {
+	return int64(len(record.Value))
+}
+
+func kafkaRecordAttributes(record *kgo.Record) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		semconv.MessagingDestinationPartitionID(strconv.Itoa(int(record.Partition))),
+		semconv.MessagingKafkaOffset(int(record.Offset)),
+	}
+	if len(record.Key) > 0 {
+		attributes = append(attributes, semconv.MessagingKafkaMessageKey(string(record.Key)))
+	}
+	if record.Value == nil {
+		attributes = append(attributes, semconv.MessagingKafkaMessageTombstone(true))
+	}
+	return attributes
+}
+
+// franzStatusExtractor extracts operation status for all franz-go spans
+type franzStatusExtractor[REQUEST any] struct{}
+
+func (extractor *franzStatusExtractor[REQUEST]) Extract(span trace.Span, request REQUEST, response any, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+}
+
+// franzProducerAttrsGetter retrieves producer message attributes
+type franzProducerAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[franzProducerReq, any] = franzProducerAttrsGetter{}
+
+func (getter franzProducerAttrsGetter) GetSystem(request franzProducerReq) string {
+	return "kafka"
+}
+
+func (getter franzProducerAttrsGetter) GetDestination(request franzProducerReq) string {
+	return request.record.Topic
+}
+
+func (getter franzProducerAttrsGetter) GetDestinationTemplate(request franzProducerReq) string {
+	return ""
+}
+
+func (getter franzProducerAttrsGetter) IsTemporaryDestination(request franzProducerReq) bool {
+	return false
+}
+
+func (getter franzProducerAttrsGetter) IsAnonymousDestination(request franzProducerReq) bool {
+	return false
+}
+
+func (getter franzProducerAttrsGetter) GetConversationId(request franzProducerReq) string {
+	return ""
+}
+
+func (getter franzProducerAttrsGetter) GetMessageBodySize(request franzProducerReq) int64 {
+	return recordBodySize(request.record)
+}
+
+func (getter franzProducerAttrsGetter) GetMessageEnvelopSize(request franzProducerReq) int64 {
+	return 0
+}
+
+func (getter franzProducerAttrsGetter) GetMessageId(request franzProducerReq, response any) string {
+	return ""
+}
+
+func (getter franzProducerAttrsGetter) GetClientId(request franzProducerReq) string {
+	return request.clientId
+}
+
+func (getter franzProducerAttrsGetter) GetBatchMessageCount(request franzProducerReq, response any) int64 {
+	return 1
+}
+
+func (getter franzProducerAttrsGetter) GetMessageHeader(request franzProducerReq, name string) []string {
+	return headerValues(request.record, name)
+}
+
+func (getter franzProducerAttrsGetter) GetDestinationPartitionId(request franzProducerReq) string {
+	return ""
+}
+
+// franzReceiveAttrsGetter retrieves attributes of a whole poll
+type franzReceiveAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[franzReceiveReq, any] = franzReceiveAttrsGetter{}
+
+func (getter franzReceiveAttrsGetter) GetSystem(request franzReceiveReq) string {
+	return "kafka"
+}
+
+func (getter franzReceiveAttrsGetter) GetDestination(request franzReceiveReq) string {
+	return request.topic
+}
+
+func (getter franzReceiveAttrsGetter) GetDestinationTemplate(request franzReceiveReq) string {
+	return ""
+}
+
+func (getter franzReceiveAttrsGetter) IsTemporaryDestination(request franzReceiveReq) bool {
+	return false
+}
+
+func (getter franzReceiveAttrsGetter) IsAnonymousDestination(request franzReceiveReq) bool {
+	return false
+}
+
+func (getter franzReceiveAttrsGetter) GetConversationId(request franzReceiveReq) string {
+	return ""
+}
+
+func (getter franzReceiveAttrsGetter) GetMessageBodySize(request franzReceiveReq) int64 {
+	var size int64
+	for _, record := range request.records {
+		size += recordBodySize(record)
+	}
+	return size
+}
+
+func (getter franzReceiveAttrsGetter) GetMessageEnvelopSize(request franzReceiveReq) int64 {
+	return 0
+}
+
+func (getter franzReceiveAttrsGetter) GetMessageId(request franzReceiveReq, response any) string {
+	return ""
+}
+
+func (getter franzReceiveAttrsGetter) GetClientId(request franzReceiveReq) string {
+	return request.clientId
+}
+
+func (getter franzReceiveAttrsGetter) GetBatchMessageCount(request franzReceiveReq, response any) int64 {
+	return int64(len(request.records))
+}
+
+func (getter franzReceiveAttrsGetter) GetMessageHeader(request franzReceiveReq, name string) []string {
+	return []string{}
+}
+
+func (getter franzReceiveAttrsGetter) GetDestinationPartitionId(request franzReceiveReq) string {
+	return ""
+}
+
+// franzProcessAttrsGetter retrieves attributes of a single consumed record
+type franzProcessAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[franzProcessReq, any] = franzProcessAttrsGetter{}
+
+func (getter franzProcessAttrsGetter) GetSystem(request franzProcessReq) string {
+	return "kafka"
+}
+
+func (getter franzProcessAttrsGetter) GetDestination(request franzProcessReq) string {
+	return request.record.Topic
+}
+
+func (getter franzProcessAttrsGetter) GetDestinationTemplate(request franzProcessReq) string {
+	return ""
+}
+
+func (getter franzProcessAttrsGetter) IsTemporaryDestination(request franzProcessReq) bool {
+	return false
+}
+
+func (getter franzProcessAttrsGetter) IsAnonymousDestination(request franzProcessReq) bool {
+	return false
+}
+
+func (getter franzProcessAttrsGetter) GetConversationId(request franzProcessReq) string {
+	return ""
+}
+
+func (getter franzProcessAttrsGetter) GetMessageBodySize(request franzProcessReq) int64 {
+	return recordBodySize(request.record)
+}
+
+func (getter franzProcessAttrsGetter) GetMessageEnvelopSize(request franzProcessReq) int64 {
+	return 0
+}
+
+func (getter franzProcessAttrsGetter) GetMessageId(request franzProcessReq, response any) string {
+	return ""
+}
+
+func (getter franzProcessAttrsGetter) GetClientId(request franzProcessReq) string {
+	return request.clientId
+}
+
+func (getter franzProcessAttrsGetter) GetBatchMessageCount(request franzProcessReq, response any) int64 {
+	return 1
+}
+
+func (getter franzProcessAttrsGetter) GetMessageHeader(request franzProcessReq, name string) []string {
+	return headerValues(request.record, name)
+}
+
+func (getter franzProcessAttrsGetter) GetDestinationPartitionId(request franzProcessReq) string {
+	return strconv.Itoa(int(request.record.Partition))
+}
+
+func headerValues(record *kgo.Record, name string) []string {
+	var values []string
+	for _, header := range record.Headers {
+		if header.Key == name {
+			values = append(values, string(header.Value))
+		}
+	}
+	return values
+}
+
+// franzProducerAttrsExtractor extracts the kafka specific producer attributes,
+// partition and offset are only known once the broker acknowledged the record
+type franzProducerAttrsExtractor struct{}
+
+func (extractor *franzProducerAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request franzProducerReq) ([]attribute.KeyValue, context.Context) {
+	return attributes, parentContext
+}
+
+func (extractor *franzProducerAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request franzProducerReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	if err != nil {
+		return attributes, ctx
+	}
+	return append(attributes, kafkaRecordAttributes(request.record)...), ctx
+}
+
+// franzReceiveAttrsExtractor extracts the kafka specific attributes of a poll
+type franzReceiveAttrsExtractor struct{}
+
+func (extractor *franzReceiveAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request franzReceiveReq) ([]attribute.KeyValue, context.Context) {
+	if request.consumerGroup != "" {
+		attributes = append(attributes, semconv.MessagingConsumerGroupName(request.consumerGroup))
+	}
+	return attributes, parentContext
+}
+
+func (extractor *franzReceiveAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request franzReceiveReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+// franzProcessAttrsExtractor extracts the kafka specific consumer attributes
+type franzProcessAttrsExtractor struct{}
+
+func (extractor *franzProcessAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request franzProcessReq) ([]attribute.KeyValue, context.Context) {
+	if request.consumerGroup != "" {
+		attributes = append(attributes, semconv.MessagingConsumerGroupName(request.consumerGroup))
+	}
+	return append(attributes, kafkaRecordAttributes(request.record)...), parentContext
+}
+
+func (extractor *franzProcessAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request franzProcessReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+// Build franz-go producer instrumenter, one span per produced record
+func buildFranzProducerInstrumenter() instrumenter.Instrumenter[franzProducerReq, any] {
+	builder := instrumenter.Builder[franzProducerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.FRANZGO_PRODUCER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[franzProducerReq, any]{
+			Getter:        franzProducerAttrsGetter{},
+			OperationName: message.PUBLISH,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[franzProducerReq]{}).
+		SetSpanStatusExtractor(&franzStatusExtractor[franzProducerReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[franzProducerReq, any, franzProducerAttrsGetter]{
+			Operation: message.PUBLISH,
+		}).
+		AddAttributesExtractor(&franzProducerAttrsExtractor{}).
+		BuildPropagatingToDownstreamInstrumenter(
+			func(request franzProducerReq) propagation.TextMapCarrier {
+				return franzRecordCarrier{record: request.record}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build franz-go receive instrumenter, one span per poll which links to the
+// upstream context of every polled record
+func buildFranzReceiveInstrumenter() instrumenter.Instrumenter[franzReceiveReq, any] {
+	builder := instrumenter.Builder[franzReceiveReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.FRANZGO_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[franzReceiveReq, any]{
+			Getter:        franzReceiveAttrsGetter{},
+			OperationName: message.RECEIVE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[franzReceiveReq]{}).
+		SetSpanStatusExtractor(&franzStatusExtractor[franzReceiveReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[franzReceiveReq, any, franzReceiveAttrsGetter]{
+			Operation: message.RECEIVE,
+		}).
+		AddAttributesExtractor(&franzReceiveAttrsExtractor{}).
+		AddContextCustomizers(franzReceiveContextCustomizer{}).
+		BuildInstrumenter()
+}
+
+// Build franz-go process instrumenter, one span per iterated record
+func buildFranzProcessInstrumenter() instrumenter.Instrumenter[franzProcessReq, any] {
+	builder := instrumenter.Builder[franzProcessReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.FRANZGO_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[franzProcessReq, any]{
+			Getter:        franzProcessAttrsGetter{},
+			OperationName: message.PROCESS,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[franzProcessReq]{}).
+		SetSpanStatusExtractor(&franzStatusExtractor[franzProcessReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[franzProcessReq, any, franzProcessAttrsGetter]{
+			Operation: message.PROCESS,
+		}).
+		AddAttributesExtractor(&franzProcessAttrsExtractor{}).
+		BuildPropagatingFromUpstreamInstrumenter(
+			func(request franzProcessReq) propagation.TextMapCarrier {
+				return franzRecordCarrier{record: request.record}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
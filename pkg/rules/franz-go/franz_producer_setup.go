@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+//go:linkname producerProduceOnEnter github.com/twmb/franz-go/pkg/kgo.producerProduceOnEnter
+func producerProduceOnEnter(call api.CallContext, client *kgo.Client, ctx context.Context,
+	record *kgo.Record, promise func(*kgo.Record, error)) {
+	produceOnEnter(call, client, ctx, record, promise)
+}
+
+//go:linkname producerTryProduceOnEnter github.com/twmb/franz-go/pkg/kgo.producerTryProduceOnEnter
+func producerTryProduceOnEnter(call api.CallContext, client *kgo.Client, ctx context.Context,
+	record *kgo.Record, promise func(*kgo.Record, error)) {
+	produceOnEnter(call, client, ctx, record, promise)
+}
+
+// produceOnEnter starts a producer span for the record and injects its context
+// into the record headers. Produce is asynchronous, so the span is ended from
+// the promise, which is wrapped here. ProduceSync calls Produce for every
+// record and therefore gets one span per record as well.
+func produceOnEnter(call api.CallContext, client *kgo.Client, ctx context.Context,
+	record *kgo.Record, promise func(*kgo.Record, error)) {
+	if !franzEnabler.Enable() || record == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if record.Topic == "" {
+		// Same as what franz-go does before buffering the record
+		if topic, ok := client.OptValue(kgo.DefaultProduceTopic).(string); ok {
+			record.Topic = topic
+		}
+	}
+	producerRequest := franzProducerReq{
+		record:   record,
+		clientId: clientIdOf(client),
+	}
+	instrumentedContext := producerInstrumenter.Start(ctx, producerRequest)
+
+	call.SetParam(3, func(r *kgo.Record, err error) {
+		producerInstrumenter.End(instrumentedContext, producerRequest, nil, err)
+		if promise != nil {
+			promise(r, err)
+		}
+	})
+}
+
+func clientIdOf(client *kgo.Client) string {
+	if client == nil {
+		return ""
+	}
+	if clientId, ok := client.OptValue(kgo.ClientID).(string); ok {
+		return clientId
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import "time"
+
+type genaiRequest struct {
+	operationName    string
+	system           string
+	modelName        string
+	serverAddress    string
+	frequencyPenalty float64
+	presencePenalty  float64
+	maxTokens        int64
+	usageInputTokens int64
+	stopSequences    []string
+	temperature      float64
+	topK             float64
+	topP             float64
+	seed             int64
+	startTime        time.Time
+}
+
+type genaiResponse struct {
+	responseFinishReasons []string
+	responseModel         string
+	usageOutputTokens     int64
+	responseID            string
+}
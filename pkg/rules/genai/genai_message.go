@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"google.golang.org/genai"
+)
+
+func marshalMap(m map[string]any) string {
+	if m == nil {
+		return ""
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// toGenAIMessage converts one content, function responses are returned as
+// separate tool messages.
+func toGenAIMessage(content *genai.Content, role string) (ai.GenAIMessage, []ai.GenAIMessage) {
+	msg := ai.GenAIMessage{Role: role}
+	var texts []string
+	var toolMessages []ai.GenAIMessage
+	for _, part := range content.Parts {
+		if part == nil || part.Thought {
+			continue
+		}
+		switch {
+		case part.FunctionCall != nil:
+			msg.ToolCalls = append(msg.ToolCalls, ai.GenAIToolCall{
+				ID:        part.FunctionCall.ID,
+				Name:      part.FunctionCall.Name,
+				Arguments: marshalMap(part.FunctionCall.Args),
+			})
+		case part.FunctionResponse != nil:
+			id := part.FunctionResponse.ID
+			if id == "" {
+				// Gemini matches function responses by name
+				id = part.FunctionResponse.Name
+			}
+			toolMessages = append(toolMessages, ai.GenAIMessage{
+				Role:       ai.GenAIRoleTool,
+				Content:    marshalMap(part.FunctionResponse.Response),
+				ToolCallID: id,
+			})
+		case part.Text != "":
+			texts = append(texts, part.Text)
+		}
+	}
+	msg.Content = strings.Join(texts, "\n")
+	return msg, toolMessages
+}
+
+func genAIRole(role string) string {
+	if role == genai.RoleModel {
+		return ai.GenAIRoleAssistant
+	}
+	return ai.GenAIRoleUser
+}
+
+func toGenAIMessages(system *genai.Content, contents []*genai.Content) []ai.GenAIMessage {
+	res := make([]ai.GenAIMessage, 0, len(contents)+1)
+	if system != nil {
+		msg, _ := toGenAIMessage(system, ai.GenAIRoleSystem)
+		res = append(res, msg)
+	}
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+		msg, toolMessages := toGenAIMessage(content, genAIRole(content.Role))
+		res = append(res, toolMessages...)
+		if len(toolMessages) == 0 || msg.Content != "" || len(msg.ToolCalls) > 0 {
+			res = append(res, msg)
+		}
+	}
+	return res
+}
+
+func toGenAIChoices(res *genai.GenerateContentResponse) []ai.GenAIChoice {
+	choices := make([]ai.GenAIChoice, 0, len(res.Candidates))
+	for _, candidate := range res.Candidates {
+		if candidate == nil {
+			continue
+		}
+		choice := ai.GenAIChoice{Index: int(candidate.Index), FinishReason: string(candidate.FinishReason)}
+		if candidate.Content != nil {
+			choice.Message, _ = toGenAIMessage(candidate.Content, ai.GenAIRoleAssistant)
+		}
+		choices = append(choices, choice)
+	}
+	return choices
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+type genaiInnerEnabler struct {
+	enabled bool
+}
+
+func (g genaiInnerEnabler) Enable() bool {
+	return g.enabled
+}
+
+var genaiEnabler = genaiInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_GOOGLE_GENAI_ENABLED") != "false"}
+
+var genaiInstrument = BuildGenAIInstrumenter()
+
+type genaiAttrsGetter struct{}
+
+var _ ai.LLMAttrsGetter[genaiRequest, genaiResponse] = genaiAttrsGetter{}
+var _ ai.CommonAttrsGetter[genaiRequest, genaiResponse] = genaiAttrsGetter{}
+
+func (genaiAttrsGetter) GetAIOperationName(request genaiRequest) string {
+	return request.operationName
+}
+
+func (genaiAttrsGetter) GetAISystem(request genaiRequest) string {
+	return request.system
+}
+
+func (genaiAttrsGetter) GetAIRequestModel(request genaiRequest) string {
+	return request.modelName
+}
+
+func (genaiAttrsGetter) GetAIRequestEncodingFormats(request genaiRequest) []string {
+	return nil
+}
+
+func (genaiAttrsGetter) GetAIRequestFrequencyPenalty(request genaiRequest) float64 {
+	return request.frequencyPenalty
+}
+
+func (genaiAttrsGetter) GetAIRequestPresencePenalty(request genaiRequest) float64 {
+	return request.presencePenalty
+}
+
+func (genaiAttrsGetter) GetAIResponseFinishReasons(request genaiRequest, response genaiResponse) []string {
+	return response.responseFinishReasons
+}
+
+func (genaiAttrsGetter) GetAIResponseModel(request genaiRequest, response genaiResponse) string {
+	return response.responseModel
+}
+
+func (genaiAttrsGetter) GetAIRequestMaxTokens(request genaiRequest) int64 {
+	return request.maxTokens
+}
+
+func (genaiAttrsGetter) GetAIUsageInputTokens(request genaiRequest) int64 {
+	return request.usageInputTokens
+}
+
+func (genaiAttrsGetter) GetAIUsageOutputTokens(request genaiRequest, response genaiResponse) int64 {
+	return response.usageOutputTokens
+}
+
+func (genaiAttrsGetter) GetAIRequestStopSequences(request genaiRequest) []string {
+	return request.stopSequences
+}
+
+func (genaiAttrsGetter) GetAIRequestTemperature(request genaiRequest) float64 {
+	return request.temperature
+}
+
+func (genaiAttrsGetter) GetAIRequestTopK(request genaiRequest) float64 {
+	return request.topK
+}
+
+func (genaiAttrsGetter) GetAIRequestTopP(request genaiRequest) float64 {
+	return request.topP
+}
+
+func (genaiAttrsGetter) GetAIResponseID(request genaiRequest, response genaiResponse) string {
+	return response.responseID
+}
+
+func (genaiAttrsGetter) GetAIServerAddress(request genaiRequest) string {
+	return request.serverAddress
+}
+
+func (genaiAttrsGetter) GetAIRequestSeed(request genaiRequest) int64 {
+	return request.seed
+}
+
+func BuildGenAIInstrumenter() instrumenter.Instrumenter[genaiRequest, genaiResponse] {
+	builder := instrumenter.Builder[genaiRequest, genaiResponse]{}
+	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[genaiRequest, genaiResponse]{Getter: genaiAttrsGetter{}}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[genaiRequest]{}).
+		AddAttributesExtractor(&ai.AILLMAttrsExtractor[genaiRequest, genaiResponse, genaiAttrsGetter, genaiAttrsGetter]{}).
+		AddOperationListeners(ai.AIClientMetrics("genai.llm")).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.GENAI_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
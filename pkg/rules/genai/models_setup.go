@@ -0,0 +1,212 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"iter"
+	"reflect"
+	"strconv"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genai"
+)
+
+const (
+	geminiSystem   = "gemini"
+	vertexAISystem = "vertex_ai"
+)
+
+// clientInfo reads the backend and the base url from the unexported client
+// config shared by the services of a genai.Client.
+func clientInfo(m genai.Models) (system string, baseURL string) {
+	system = geminiSystem
+	apiClient := reflect.ValueOf(m).FieldByName("apiClient")
+	if !apiClient.IsValid() || apiClient.Kind() != reflect.Ptr || apiClient.IsNil() {
+		return
+	}
+	config := apiClient.Elem().FieldByName("clientConfig")
+	if config.IsValid() && config.Kind() == reflect.Ptr {
+		if config.IsNil() {
+			return
+		}
+		config = config.Elem()
+	}
+	if !config.IsValid() || config.Kind() != reflect.Struct {
+		return
+	}
+	if backend := config.FieldByName("Backend"); backend.IsValid() && backend.Int() == int64(genai.BackendVertexAI) {
+		system = vertexAISystem
+	}
+	if options := config.FieldByName("HTTPOptions"); options.IsValid() && options.Kind() == reflect.Struct {
+		if url := options.FieldByName("BaseURL"); url.IsValid() {
+			baseURL = url.String()
+		}
+	}
+	return
+}
+
+// float32Value keeps the shortest decimal form of the value, e.g. 0.7
+// instead of 0.699999988079071.
+func float32Value(f *float32) float64 {
+	if f == nil {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(*f), 'g', -1, 32), 64)
+	return v
+}
+
+func newGenerateRequest(m genai.Models, model string, config *genai.GenerateContentConfig) genaiRequest {
+	request := genaiRequest{
+		operationName: "chat",
+		modelName:     model,
+		startTime:     time.Now(),
+	}
+	request.system, request.serverAddress = clientInfo(m)
+	if config == nil {
+		return request
+	}
+	if config.HTTPOptions != nil && config.HTTPOptions.BaseURL != "" {
+		request.serverAddress = config.HTTPOptions.BaseURL
+	}
+	request.temperature = float32Value(config.Temperature)
+	request.topP = float32Value(config.TopP)
+	request.topK = float32Value(config.TopK)
+	request.frequencyPenalty = float32Value(config.FrequencyPenalty)
+	request.presencePenalty = float32Value(config.PresencePenalty)
+	request.maxTokens = int64(config.MaxOutputTokens)
+	request.stopSequences = config.StopSequences
+	if config.Seed != nil {
+		request.seed = int64(*config.Seed)
+	}
+	return request
+}
+
+func startGenerate(call api.CallContext, m genai.Models, ctx context.Context, model string,
+	contents []*genai.Content, config *genai.GenerateContentConfig) {
+	if !genaiEnabler.Enable() {
+		return
+	}
+	request := newGenerateRequest(m, model, config)
+	ctx = genaiInstrument.Start(ctx, request)
+	call.SetParam(1, ctx)
+	if ai.CaptureMessageContent() {
+		var system *genai.Content
+		if config != nil {
+			system = config.SystemInstruction
+		}
+		ai.RecordInputMessages(trace.SpanFromContext(ctx), request.system, toGenAIMessages(system, contents))
+	}
+	data := make(map[string]interface{}, 2)
+	data["ctx"] = ctx
+	data["request"] = request
+	call.SetData(data)
+}
+
+// fillResponse copies the metadata of a response, or of the last chunk of a
+// stream since usage and finish reasons are only complete at its end.
+func fillResponse(res *genai.GenerateContentResponse, request *genaiRequest, response *genaiResponse) {
+	if res.ResponseID != "" {
+		response.responseID = res.ResponseID
+	}
+	if res.ModelVersion != "" {
+		response.responseModel = res.ModelVersion
+	}
+	for _, candidate := range res.Candidates {
+		if candidate != nil && candidate.FinishReason != "" {
+			response.responseFinishReasons = append(response.responseFinishReasons, string(candidate.FinishReason))
+		}
+	}
+	if usage := res.UsageMetadata; usage != nil {
+		request.usageInputTokens = int64(usage.PromptTokenCount)
+		// thinking tokens are billed as output tokens
+		response.usageOutputTokens = int64(usage.CandidatesTokenCount) + int64(usage.ThoughtsTokenCount)
+	}
+}
+
+//go:linkname generateContentOnEnter google.golang.org/genai.generateContentOnEnter
+func generateContentOnEnter(call api.CallContext, m genai.Models, ctx context.Context, model string,
+	contents []*genai.Content, config *genai.GenerateContentConfig) {
+	startGenerate(call, m, ctx, model, contents, config)
+}
+
+//go:linkname generateContentOnExit google.golang.org/genai.generateContentOnExit
+func generateContentOnExit(call api.CallContext, res *genai.GenerateContentResponse, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx := data["ctx"].(context.Context)
+	request := data["request"].(genaiRequest)
+	response := genaiResponse{}
+	if res != nil {
+		fillResponse(res, &request, &response)
+		if ai.CaptureMessageContent() {
+			ai.RecordChoices(trace.SpanFromContext(ctx), request.system, toGenAIChoices(res))
+		}
+	}
+	genaiInstrument.End(ctx, request, response, err)
+}
+
+//go:linkname generateContentStreamOnEnter google.golang.org/genai.generateContentStreamOnEnter
+func generateContentStreamOnEnter(call api.CallContext, m genai.Models, ctx context.Context, model string,
+	contents []*genai.Content, config *genai.GenerateContentConfig) {
+	startGenerate(call, m, ctx, model, contents, config)
+}
+
+//go:linkname generateContentStreamOnExit google.golang.org/genai.generateContentStreamOnExit
+func generateContentStreamOnExit(call api.CallContext, seq iter.Seq2[*genai.GenerateContentResponse, error]) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	call.SetReturnVal(0, wrapContentStream(data["ctx"].(context.Context), data["request"].(genaiRequest), seq))
+}
+
+//go:linkname embedContentOnEnter google.golang.org/genai.embedContentOnEnter
+func embedContentOnEnter(call api.CallContext, m genai.Models, ctx context.Context, model string,
+	contents []*genai.Content, config *genai.EmbedContentConfig) {
+	if !genaiEnabler.Enable() {
+		return
+	}
+	request := genaiRequest{
+		operationName: "embeddings",
+		modelName:     model,
+		startTime:     time.Now(),
+	}
+	request.system, request.serverAddress = clientInfo(m)
+	if config != nil && config.HTTPOptions != nil && config.HTTPOptions.BaseURL != "" {
+		request.serverAddress = config.HTTPOptions.BaseURL
+	}
+	ctx = genaiInstrument.Start(ctx, request)
+	call.SetParam(1, ctx)
+	data := make(map[string]interface{}, 2)
+	data["ctx"] = ctx
+	data["request"] = request
+	call.SetData(data)
+}
+
+//go:linkname embedContentOnExit google.golang.org/genai.embedContentOnExit
+func embedContentOnExit(call api.CallContext, res *genai.EmbedContentResponse, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	genaiInstrument.End(data["ctx"].(context.Context), data["request"].(genaiRequest), genaiResponse{}, err)
+}
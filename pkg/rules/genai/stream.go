@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genai"
+)
+
+type candidateState struct {
+	finishReason string
+	text         strings.Builder
+	toolCalls    []ai.GenAIToolCall
+}
+
+// contentStream accumulates the chunks of a streamed generation.
+type contentStream struct {
+	ctx            context.Context
+	request        genaiRequest
+	response       genaiResponse
+	capture        bool
+	candidates     map[int32]*candidateState
+	indexes        []int32
+	firstTokenTime time.Time
+	once           sync.Once
+}
+
+func (s *contentStream) observe(res *genai.GenerateContentResponse) {
+	if res.ResponseID != "" {
+		s.response.responseID = res.ResponseID
+	}
+	if res.ModelVersion != "" {
+		s.response.responseModel = res.ModelVersion
+	}
+	if usage := res.UsageMetadata; usage != nil {
+		// the usage of every chunk is cumulative
+		s.request.usageInputTokens = int64(usage.PromptTokenCount)
+		s.response.usageOutputTokens = int64(usage.CandidatesTokenCount) + int64(usage.ThoughtsTokenCount)
+	}
+	for _, candidate := range res.Candidates {
+		if candidate == nil {
+			continue
+		}
+		state, ok := s.candidates[candidate.Index]
+		if !ok {
+			state = &candidateState{}
+			s.candidates[candidate.Index] = state
+			s.indexes = append(s.indexes, candidate.Index)
+		}
+		if candidate.FinishReason != "" {
+			state.finishReason = string(candidate.FinishReason)
+		}
+		if candidate.Content == nil || len(candidate.Content.Parts) == 0 {
+			continue
+		}
+		if s.firstTokenTime.IsZero() {
+			s.firstTokenTime = time.Now()
+		}
+		if s.capture {
+			msg, _ := toGenAIMessage(candidate.Content, ai.GenAIRoleAssistant)
+			state.text.WriteString(msg.Content)
+			state.toolCalls = append(state.toolCalls, msg.ToolCalls...)
+		}
+	}
+}
+
+func (s *contentStream) end(err error) {
+	s.once.Do(func() {
+		var choices []ai.GenAIChoice
+		for _, index := range s.indexes {
+			state := s.candidates[index]
+			if state.finishReason != "" {
+				s.response.responseFinishReasons = append(s.response.responseFinishReasons, state.finishReason)
+			}
+			choices = append(choices, ai.GenAIChoice{
+				Index:        int(index),
+				FinishReason: state.finishReason,
+				Message: ai.GenAIMessage{
+					Role:      ai.GenAIRoleAssistant,
+					Content:   state.text.String(),
+					ToolCalls: state.toolCalls,
+				},
+			})
+		}
+		if s.capture {
+			ai.RecordChoices(trace.SpanFromContext(s.ctx), s.request.system, choices)
+		}
+		ctx := s.ctx
+		if !s.firstTokenTime.IsZero() {
+			ctx = ai.ContextWithTimeToFirstToken(ctx, s.firstTokenTime.Sub(s.request.startTime))
+		}
+		genaiInstrument.End(ctx, s.request, s.response, err)
+	})
+}
+
+// wrapContentStream returns an iterator observing every chunk of seq. The
+// span ends when the range over it stops, whether the stream is drained,
+// fails or the caller breaks out of the loop.
+func wrapContentStream(ctx context.Context, request genaiRequest,
+	seq iter.Seq2[*genai.GenerateContentResponse, error]) iter.Seq2[*genai.GenerateContentResponse, error] {
+	if seq == nil {
+		genaiInstrument.End(ctx, request, genaiResponse{}, nil)
+		return seq
+	}
+	s := &contentStream{
+		ctx:        ctx,
+		request:    request,
+		capture:    ai.CaptureMessageContent(),
+		candidates: make(map[int32]*candidateState),
+	}
+	return func(yield func(*genai.GenerateContentResponse, error) bool) {
+		var streamErr error
+		defer func() {
+			s.end(streamErr)
+		}()
+		for res, err := range seq {
+			if err != nil {
+				streamErr = err
+			} else if res != nil {
+				s.observe(res)
+			}
+			if !yield(res, err) {
+				return
+			}
+		}
+	}
+}
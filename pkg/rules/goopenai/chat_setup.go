@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goopenai
+
+import (
+	"context"
+	"reflect"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/sashabaranov/go-openai"
+)
+
+func clientBaseURL(c *openai.Client) string {
+	if c == nil {
+		return ""
+	}
+	baseURL := reflect.ValueOf(c).Elem().FieldByName("config").FieldByName("BaseURL")
+	if baseURL.IsValid() {
+		return baseURL.String()
+	}
+	return ""
+}
+
+func newChatRequest(c *openai.Client, body openai.ChatCompletionRequest) goopenaiRequest {
+	request := goopenaiRequest{
+		operationName:    "chat",
+		modelName:        body.Model,
+		frequencyPenalty: float64(body.FrequencyPenalty),
+		presencePenalty:  float64(body.PresencePenalty),
+		maxTokens:        int64(body.MaxCompletionTokens),
+		stopSequences:    body.Stop,
+		temperature:      float64(body.Temperature),
+		topP:             float64(body.TopP),
+		serverAddress:    clientBaseURL(c),
+		startTime:        time.Now(),
+	}
+	if request.maxTokens == 0 {
+		request.maxTokens = int64(body.MaxTokens)
+	}
+	if body.Seed != nil {
+		request.seed = int64(*body.Seed)
+	}
+	return request
+}
+
+func startChat(call api.CallContext, c *openai.Client, ctx context.Context, body openai.ChatCompletionRequest) {
+	if !goopenaiEnabler.Enable() {
+		return
+	}
+	request := newChatRequest(c, body)
+	ctx = goopenaiInstrument.Start(ctx, request)
+	call.SetParam(1, ctx)
+	data := make(map[string]interface{}, 3)
+	data["ctx"] = ctx
+	data["request"] = request
+	data["body"] = body
+	call.SetData(data)
+}
+
+//go:linkname createChatCompletionOnEnter github.com/sashabaranov/go-openai.createChatCompletionOnEnter
+func createChatCompletionOnEnter(call api.CallContext, c *openai.Client, ctx context.Context, body openai.ChatCompletionRequest) {
+	startChat(call, c, ctx, body)
+}
+
+//go:linkname createChatCompletionOnExit github.com/sashabaranov/go-openai.createChatCompletionOnExit
+func createChatCompletionOnExit(call api.CallContext, res openai.ChatCompletionResponse, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx := data["ctx"].(context.Context)
+	request := data["request"].(goopenaiRequest)
+	response := goopenaiResponse{
+		responseID:    res.ID,
+		responseModel: res.Model,
+	}
+	for _, choice := range res.Choices {
+		response.responseFinishReasons = append(response.responseFinishReasons, string(choice.FinishReason))
+	}
+	request.usageInputTokens = int64(res.Usage.PromptTokens)
+	response.usageOutputTokens = int64(res.Usage.CompletionTokens)
+	goopenaiInstrument.End(ctx, request, response, err)
+}
+
+//go:linkname createChatCompletionStreamOnEnter github.com/sashabaranov/go-openai.createChatCompletionStreamOnEnter
+func createChatCompletionStreamOnEnter(call api.CallContext, c *openai.Client, ctx context.Context, body openai.ChatCompletionRequest) {
+	startChat(call, c, ctx, body)
+}
+
+//go:linkname createChatCompletionStreamOnExit github.com/sashabaranov/go-openai.createChatCompletionStreamOnExit
+func createChatCompletionStreamOnExit(call api.CallContext, stream *openai.ChatCompletionStream, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx := data["ctx"].(context.Context)
+	request := data["request"].(goopenaiRequest)
+	if err != nil || stream == nil {
+		goopenaiInstrument.End(ctx, request, goopenaiResponse{}, err)
+		return
+	}
+	wrapStream(ctx, request, data["body"].(openai.ChatCompletionRequest), stream)
+}
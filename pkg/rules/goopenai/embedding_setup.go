@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goopenai
+
+import (
+	"context"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/sashabaranov/go-openai"
+)
+
+//go:linkname createEmbeddingsOnEnter github.com/sashabaranov/go-openai.createEmbeddingsOnEnter
+func createEmbeddingsOnEnter(call api.CallContext, c *openai.Client, ctx context.Context, conv openai.EmbeddingRequestConverter) {
+	if !goopenaiEnabler.Enable() || conv == nil {
+		return
+	}
+	body := conv.Convert()
+	request := goopenaiRequest{
+		operationName: "embeddings",
+		modelName:     string(body.Model),
+		serverAddress: clientBaseURL(c),
+		startTime:     time.Now(),
+	}
+	if body.EncodingFormat != "" {
+		request.encodingFormats = []string{string(body.EncodingFormat)}
+	}
+	ctx = goopenaiInstrument.Start(ctx, request)
+	call.SetParam(1, ctx)
+	data := make(map[string]interface{}, 2)
+	data["ctx"] = ctx
+	data["request"] = request
+	call.SetData(data)
+}
+
+//go:linkname createEmbeddingsOnExit github.com/sashabaranov/go-openai.createEmbeddingsOnExit
+func createEmbeddingsOnExit(call api.CallContext, res openai.EmbeddingResponse, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx := data["ctx"].(context.Context)
+	request := data["request"].(goopenaiRequest)
+	request.usageInputTokens = int64(res.Usage.PromptTokens)
+	goopenaiInstrument.End(ctx, request, goopenaiResponse{responseModel: string(res.Model)}, err)
+}
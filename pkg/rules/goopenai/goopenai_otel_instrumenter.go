@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goopenai
+
+import (
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+type goopenaiInnerEnabler struct {
+	enabled bool
+}
+
+func (g goopenaiInnerEnabler) Enable() bool {
+	return g.enabled
+}
+
+var goopenaiEnabler = goopenaiInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_GOOPENAI_ENABLED") != "false"}
+
+var goopenaiInstrument = BuildGoOpenAIInstrumenter()
+
+type goopenaiAttrsGetter struct{}
+
+var _ ai.LLMAttrsGetter[goopenaiRequest, goopenaiResponse] = goopenaiAttrsGetter{}
+var _ ai.CommonAttrsGetter[goopenaiRequest, goopenaiResponse] = goopenaiAttrsGetter{}
+
+func (goopenaiAttrsGetter) GetAIOperationName(request goopenaiRequest) string {
+	return request.operationName
+}
+
+func (goopenaiAttrsGetter) GetAISystem(request goopenaiRequest) string {
+	return "openai"
+}
+
+func (goopenaiAttrsGetter) GetAIRequestModel(request goopenaiRequest) string {
+	return request.modelName
+}
+
+func (goopenaiAttrsGetter) GetAIRequestEncodingFormats(request goopenaiRequest) []string {
+	return request.encodingFormats
+}
+
+func (goopenaiAttrsGetter) GetAIRequestFrequencyPenalty(request goopenaiRequest) float64 {
+	return request.frequencyPenalty
+}
+
+func (goopenaiAttrsGetter) GetAIRequestPresencePenalty(request goopenaiRequest) float64 {
+	return request.presencePenalty
+}
+
+func (goopenaiAttrsGetter) GetAIResponseFinishReasons(request goopenaiRequest, response goopenaiResponse) []string {
+	return response.responseFinishReasons
+}
+
+func (goopenaiAttrsGetter) GetAIResponseModel(request goopenaiRequest, response goopenaiResponse) string {
+	return response.responseModel
+}
+
+func (goopenaiAttrsGetter) GetAIRequestMaxTokens(request goopenaiRequest) int64 {
+	return request.maxTokens
+}
+
+func (goopenaiAttrsGetter) GetAIUsageInputTokens(request goopenaiRequest) int64 {
+	return request.usageInputTokens
+}
+
+func (goopenaiAttrsGetter) GetAIUsageOutputTokens(request goopenaiRequest, response goopenaiResponse) int64 {
+	return response.usageOutputTokens
+}
+
+func (goopenaiAttrsGetter) GetAIRequestStopSequences(request goopenaiRequest) []string {
+	return request.stopSequences
+}
+
+func (goopenaiAttrsGetter) GetAIRequestTemperature(request goopenaiRequest) float64 {
+	return request.temperature
+}
+
+func (goopenaiAttrsGetter) GetAIRequestTopK(request goopenaiRequest) float64 {
+	return 0
+}
+
+func (goopenaiAttrsGetter) GetAIRequestTopP(request goopenaiRequest) float64 {
+	return request.topP
+}
+
+func (goopenaiAttrsGetter) GetAIResponseID(request goopenaiRequest, response goopenaiResponse) string {
+	return response.responseID
+}
+
+func (goopenaiAttrsGetter) GetAIServerAddress(request goopenaiRequest) string {
+	return request.serverAddress
+}
+
+func (goopenaiAttrsGetter) GetAIRequestSeed(request goopenaiRequest) int64 {
+	return request.seed
+}
+
+func BuildGoOpenAIInstrumenter() instrumenter.Instrumenter[goopenaiRequest, goopenaiResponse] {
+	builder := instrumenter.Builder[goopenaiRequest, goopenaiResponse]{}
+	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[goopenaiRequest, goopenaiResponse]{Getter: goopenaiAttrsGetter{}}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[goopenaiRequest]{}).
+		AddAttributesExtractor(&ai.AILLMAttrsExtractor[goopenaiRequest, goopenaiResponse, goopenaiAttrsGetter, goopenaiAttrsGetter]{}).
+		AddOperationListeners(ai.AIClientMetrics("goopenai.llm")).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.GOOPENAI_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goopenai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/sashabaranov/go-openai"
+)
+
+type unmarshaler interface {
+	Unmarshal(data []byte, v any) error
+}
+
+// streamRecorder is installed as the unmarshaler of a chat completion stream
+// so it sees every decoded chunk. The span ends once all choices finished
+// (and the usage chunk arrived when it was requested) or the stream is closed.
+type streamRecorder struct {
+	unmarshaler
+	ctx            context.Context
+	request        goopenaiRequest
+	response       goopenaiResponse
+	expected       int
+	includeUsage   bool
+	usageSeen      bool
+	finishReasons  []string
+	firstTokenTime time.Time
+	once           sync.Once
+	mu             sync.Mutex
+}
+
+func (s *streamRecorder) Unmarshal(data []byte, v any) error {
+	err := s.unmarshaler.Unmarshal(data, v)
+	chunk, ok := v.(*openai.ChatCompletionStreamResponse)
+	if err != nil || !ok {
+		return err
+	}
+	s.mu.Lock()
+	if s.firstTokenTime.IsZero() && len(chunk.Choices) > 0 {
+		s.firstTokenTime = time.Now()
+	}
+	if chunk.ID != "" {
+		s.response.responseID = chunk.ID
+	}
+	if chunk.Model != "" {
+		s.response.responseModel = chunk.Model
+	}
+	for _, choice := range chunk.Choices {
+		if choice.FinishReason == "" || choice.Index < 0 {
+			continue
+		}
+		for len(s.finishReasons) <= choice.Index {
+			s.finishReasons = append(s.finishReasons, "")
+		}
+		s.finishReasons[choice.Index] = string(choice.FinishReason)
+	}
+	if chunk.Usage != nil {
+		s.usageSeen = true
+		s.request.usageInputTokens = int64(chunk.Usage.PromptTokens)
+		s.response.usageOutputTokens = int64(chunk.Usage.CompletionTokens)
+	}
+	done := s.finished()
+	s.mu.Unlock()
+	if done {
+		s.end()
+	}
+	return nil
+}
+
+func (s *streamRecorder) finished() bool {
+	n := 0
+	for _, reason := range s.finishReasons {
+		if reason != "" {
+			n++
+		}
+	}
+	return n >= s.expected && (!s.includeUsage || s.usageSeen)
+}
+
+func (s *streamRecorder) end() {
+	s.once.Do(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.response.responseFinishReasons = s.finishReasons
+		ctx := s.ctx
+		if !s.firstTokenTime.IsZero() {
+			ctx = ai.ContextWithTimeToFirstToken(ctx, s.firstTokenTime.Sub(s.request.startTime))
+		}
+		goopenaiInstrument.End(ctx, s.request, s.response, nil)
+	})
+}
+
+// closeNotifier ends the span when the user closes the stream early.
+type closeNotifier struct {
+	io.ReadCloser
+	recorder *streamRecorder
+}
+
+func (c *closeNotifier) Close() error {
+	err := c.ReadCloser.Close()
+	c.recorder.end()
+	return err
+}
+
+func unexportedField(v reflect.Value, name string) reflect.Value {
+	f := v.FieldByName(name)
+	if !f.IsValid() {
+		return f
+	}
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+func wrapStream(ctx context.Context, request goopenaiRequest, body openai.ChatCompletionRequest, stream *openai.ChatCompletionStream) {
+	reader := reflect.ValueOf(stream).Elem().Field(0)
+	if reader.Kind() != reflect.Ptr || reader.IsNil() {
+		goopenaiInstrument.End(ctx, request, goopenaiResponse{}, nil)
+		return
+	}
+	reader = reader.Elem()
+	um := unexportedField(reader, "unmarshaler")
+	resp := unexportedField(reader, "response")
+	if !um.IsValid() || um.IsNil() || !resp.IsValid() || resp.IsNil() {
+		goopenaiInstrument.End(ctx, request, goopenaiResponse{}, nil)
+		return
+	}
+	inner, ok := um.Interface().(unmarshaler)
+	httpResp, ok2 := resp.Interface().(*http.Response)
+	if !ok || !ok2 || httpResp.Body == nil {
+		goopenaiInstrument.End(ctx, request, goopenaiResponse{}, nil)
+		return
+	}
+	recorder := &streamRecorder{
+		unmarshaler:  inner,
+		ctx:          ctx,
+		request:      request,
+		expected:     max(body.N, 1),
+		includeUsage: body.StreamOptions != nil && body.StreamOptions.IncludeUsage,
+	}
+	um.Set(reflect.ValueOf(recorder))
+	httpResp.Body = &closeNotifier{ReadCloser: httpResp.Body, recorder: recorder}
+}
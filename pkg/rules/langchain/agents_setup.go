@@ -16,6 +16,7 @@ package langchain
 
 import (
 	"context"
+	"strings"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
@@ -42,6 +43,18 @@ func doActionOnEnter(call api.CallContext,
 		},
 	}
 	langCtx := langChainCommonInstrument.Start(ctx, request)
+	call.SetParam(1, langCtx)
+	// tools.Tool is an interface, so the tool picked for this action is
+	// wrapped in a copy of nameToTool to trace its execution
+	key := strings.ToUpper(action.Tool)
+	if tool, ok := nameToTool[key]; ok {
+		wrapped := make(map[string]tools.Tool, len(nameToTool))
+		for name, t := range nameToTool {
+			wrapped[name] = t
+		}
+		wrapped[key] = &tracedTool{Tool: tool, callID: action.ToolID}
+		call.SetParam(3, wrapped)
+	}
 	data := make(map[string]interface{})
 	data["ctx"] = langCtx
 	call.SetData(data)
@@ -60,3 +73,23 @@ func doActionOnExit(call api.CallContext, steps []schema.AgentStep, err error) {
 	}
 	langChainCommonInstrument.End(ctx, request, nil, err)
 }
+
+// tracedTool creates an execute_tool span for every call of the wrapped tool.
+type tracedTool struct {
+	tools.Tool
+	callID string
+}
+
+func (t *tracedTool) Call(ctx context.Context, input string) (string, error) {
+	request := langChainRequest{
+		operationName:   MExecuteTool,
+		system:          "langchain",
+		toolName:        t.Name(),
+		toolCallID:      t.callID,
+		toolDescription: t.Description(),
+	}
+	ctx = langChainToolInstrument.Start(ctx, request)
+	output, err := t.Tool.Call(ctx, input)
+	langChainToolInstrument.End(ctx, request, nil, err)
+	return output, err
+}
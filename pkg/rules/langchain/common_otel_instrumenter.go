@@ -27,6 +27,18 @@ type LExperimentalAttributeExtractor struct {
 
 func (l LExperimentalAttributeExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request langChainRequest) ([]attribute.KeyValue, context.Context) {
 	attributes, parentContext = l.Base.OnStart(attributes, parentContext, request)
+	if request.toolName != "" {
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   "gen_ai.tool.name",
+			Value: attribute.StringValue(request.toolName),
+		}, attribute.KeyValue{
+			Key:   "gen_ai.tool.call.id",
+			Value: attribute.StringValue(request.toolCallID),
+		}, attribute.KeyValue{
+			Key:   "gen_ai.tool.description",
+			Value: attribute.StringValue(request.toolDescription),
+		})
+	}
 	if request.input != nil {
 		var val attribute.Value
 		for k, v := range request.input {
@@ -100,3 +112,15 @@ func BuildCommonLangchainOtelInstrumenter() instrumenter.Instrumenter[langChainR
 		}).
 		BuildInstrumenter()
 }
+
+func BuildLangchainToolOtelInstrumenter() instrumenter.Instrumenter[langChainRequest, any] {
+	builder := instrumenter.Builder[langChainRequest, any]{}
+	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[langChainRequest, any]{Getter: aiCommonRequest{}}).
+		SetSpanKindExtractor(&instrumenter.AlwaysInternalExtractor[langChainRequest]{}).
+		AddAttributesExtractor(&LExperimentalAttributeExtractor{}).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.LANGCHAIN_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
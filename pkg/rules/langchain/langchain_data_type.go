@@ -15,14 +15,18 @@
 package langchain
 
 type langChainRequest struct {
-	operationName string
-	system        string
-	input         map[string]any
-	output        map[string]any
+	operationName   string
+	system          string
+	toolName        string
+	toolCallID      string
+	toolDescription string
+	input           map[string]any
+	output          map[string]any
 }
 
 type langChainLLMRequest struct {
 	operationName    string
+	system           string
 	moduleName       string
 	encodingFormats  []string
 	frequencyPenalty float64
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package langchain
+
+import (
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/tmc/langchaingo/llms"
+)
+
+func genAIRole(role llms.ChatMessageType) string {
+	switch role {
+	case llms.ChatMessageTypeSystem:
+		return ai.GenAIRoleSystem
+	case llms.ChatMessageTypeAI:
+		return ai.GenAIRoleAssistant
+	case llms.ChatMessageTypeTool, llms.ChatMessageTypeFunction:
+		return ai.GenAIRoleTool
+	default:
+		return ai.GenAIRoleUser
+	}
+}
+
+func toGenAIToolCall(call llms.ToolCall) ai.GenAIToolCall {
+	res := ai.GenAIToolCall{ID: call.ID}
+	if call.FunctionCall != nil {
+		res.Name = call.FunctionCall.Name
+		res.Arguments = call.FunctionCall.Arguments
+	}
+	return res
+}
+
+func toGenAIMessages(messages []llms.MessageContent) []ai.GenAIMessage {
+	res := make([]ai.GenAIMessage, 0, len(messages))
+	for _, message := range messages {
+		msg := ai.GenAIMessage{Role: genAIRole(message.Role)}
+		var texts []string
+		for _, part := range message.Parts {
+			switch p := part.(type) {
+			case llms.TextContent:
+				texts = append(texts, p.Text)
+			case *llms.TextContent:
+				texts = append(texts, p.Text)
+			case llms.ToolCall:
+				msg.ToolCalls = append(msg.ToolCalls, toGenAIToolCall(p))
+			case llms.ToolCallResponse:
+				msg.ToolCallID = p.ToolCallID
+				texts = append(texts, p.Content)
+			}
+		}
+		msg.Content = strings.Join(texts, "\n")
+		res = append(res, msg)
+	}
+	return res
+}
+
+func toGenAIChoices(resp *llms.ContentResponse) []ai.GenAIChoice {
+	if resp == nil {
+		return nil
+	}
+	res := make([]ai.GenAIChoice, 0, len(resp.Choices))
+	for i, choice := range resp.Choices {
+		if choice == nil {
+			continue
+		}
+		// streamed chunks are already concatenated into Content by langchaingo
+		msg := ai.GenAIMessage{Role: ai.GenAIRoleAssistant, Content: choice.Content}
+		for _, call := range choice.ToolCalls {
+			msg.ToolCalls = append(msg.ToolCalls, toGenAIToolCall(call))
+		}
+		if len(msg.ToolCalls) == 0 && choice.FuncCall != nil {
+			msg.ToolCalls = append(msg.ToolCalls, ai.GenAIToolCall{
+				Name:      choice.FuncCall.Name,
+				Arguments: choice.FuncCall.Arguments,
+			})
+		}
+		res = append(res, ai.GenAIChoice{Index: i, FinishReason: choice.StopReason, Message: msg})
+	}
+	return res
+}
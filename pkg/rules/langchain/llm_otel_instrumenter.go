@@ -19,6 +19,9 @@ func (aiLLMRequest) GetAIOperationName(request langChainLLMRequest) string {
 	return request.operationName
 }
 func (aiLLMRequest) GetAISystem(request langChainLLMRequest) string {
+	if request.system != "" {
+		return request.system
+	}
 	if request.moduleName == "" {
 		return "langchain"
 	}
@@ -83,6 +86,7 @@ func BuildLangchainLLMOtelInstrumenter() instrumenter.Instrumenter[langChainLLMR
 	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[langChainLLMRequest, langChainLLMResponse]{Getter: aiLLMRequest{}}).
 		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[langChainLLMRequest]{}).
 		AddAttributesExtractor(&ai.AILLMAttrsExtractor[langChainLLMRequest, langChainLLMResponse, aiLLMRequest, aiLLMRequest]{}).
+		AddOperationListeners(ai.AIClientMetrics("langchain.llm")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.LANGCHAIN_SCOPE_NAME,
 			Version: version.Tag,
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package langchain
+
+import (
+	"context"
+	"reflect"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// The receivers of the following providers are taken as interface{}, so that
+// their SDKs are not pulled into the application by this package, and the
+// model and server address are read from their unexported fields.
+
+//go:linkname anthropicGenerateContentOnEnter github.com/tmc/langchaingo/llms/anthropic.anthropicGenerateContentOnEnter
+func anthropicGenerateContentOnEnter(call api.CallContext, llm interface{},
+	ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption,
+) {
+	request := &langChainLLMRequest{
+		moduleName:    fieldString(llm, "client", "Model"),
+		operationName: "chat",
+		system:        "anthropic",
+		serverAddress: fieldString(llm, "client", "baseURL"),
+	}
+	LLMBaseOnEnter(call, ctx, request, messages, options...)
+}
+
+//go:linkname anthropicGenerateContentOnExit github.com/tmc/langchaingo/llms/anthropic.anthropicGenerateContentOnExit
+func anthropicGenerateContentOnExit(call api.CallContext, resp *llms.ContentResponse, err error) {
+	LLMBaseOnExit(call, resp, err)
+}
+
+//go:linkname googleaiGenerateContentOnEnter github.com/tmc/langchaingo/llms/googleai.googleaiGenerateContentOnEnter
+func googleaiGenerateContentOnEnter(call api.CallContext, llm interface{},
+	ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption,
+) {
+	request := &langChainLLMRequest{
+		moduleName:    fieldString(llm, "opts", "DefaultModel"),
+		operationName: "chat",
+		system:        "gemini",
+	}
+	LLMBaseOnEnter(call, ctx, request, messages, options...)
+}
+
+//go:linkname googleaiGenerateContentOnExit github.com/tmc/langchaingo/llms/googleai.googleaiGenerateContentOnExit
+func googleaiGenerateContentOnExit(call api.CallContext, resp *llms.ContentResponse, err error) {
+	LLMBaseOnExit(call, resp, err)
+}
+
+//go:linkname bedrockGenerateContentOnEnter github.com/tmc/langchaingo/llms/bedrock.bedrockGenerateContentOnEnter
+func bedrockGenerateContentOnEnter(call api.CallContext, llm interface{},
+	ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption,
+) {
+	request := &langChainLLMRequest{
+		moduleName:    fieldString(llm, "modelID"),
+		operationName: "chat",
+		system:        "aws.bedrock",
+	}
+	LLMBaseOnEnter(call, ctx, request, messages, options...)
+}
+
+//go:linkname bedrockGenerateContentOnExit github.com/tmc/langchaingo/llms/bedrock.bedrockGenerateContentOnExit
+func bedrockGenerateContentOnExit(call api.CallContext, resp *llms.ContentResponse, err error) {
+	LLMBaseOnExit(call, resp, err)
+}
+
+//go:linkname mistralGenerateContentOnEnter github.com/tmc/langchaingo/llms/mistral.mistralGenerateContentOnEnter
+func mistralGenerateContentOnEnter(call api.CallContext, llm interface{},
+	ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption,
+) {
+	request := &langChainLLMRequest{
+		moduleName:    fieldString(llm, "clientOptions", "model"),
+		operationName: "chat",
+		system:        "mistral_ai",
+		serverAddress: fieldString(llm, "clientOptions", "endpoint"),
+	}
+	LLMBaseOnEnter(call, ctx, request, messages, options...)
+}
+
+//go:linkname mistralGenerateContentOnExit github.com/tmc/langchaingo/llms/mistral.mistralGenerateContentOnExit
+func mistralGenerateContentOnExit(call api.CallContext, resp *llms.ContentResponse, err error) {
+	LLMBaseOnExit(call, resp, err)
+}
+
+//go:linkname huggingfaceGenerateContentOnEnter github.com/tmc/langchaingo/llms/huggingface.huggingfaceGenerateContentOnEnter
+func huggingfaceGenerateContentOnEnter(call api.CallContext, llm interface{},
+	ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption,
+) {
+	request := &langChainLLMRequest{
+		moduleName:    fieldString(llm, "client", "Model"),
+		operationName: "chat",
+		system:        "huggingface",
+		serverAddress: fieldString(llm, "client", "url"),
+	}
+	LLMBaseOnEnter(call, ctx, request, messages, options...)
+}
+
+//go:linkname huggingfaceGenerateContentOnExit github.com/tmc/langchaingo/llms/huggingface.huggingfaceGenerateContentOnExit
+func huggingfaceGenerateContentOnExit(call api.CallContext, resp *llms.ContentResponse, err error) {
+	LLMBaseOnExit(call, resp, err)
+}
+
+// fieldString follows the named fields from v through pointers and
+// interfaces and returns the string found at the end, or "" if there is none.
+func fieldString(v interface{}, names ...string) string {
+	f := reflect.ValueOf(v)
+	for _, name := range names {
+		for f.Kind() == reflect.Pointer || f.Kind() == reflect.Interface {
+			if f.IsNil() {
+				return ""
+			}
+			f = f.Elem()
+		}
+		if f.Kind() != reflect.Struct {
+			return ""
+		}
+		f = f.FieldByName(name)
+		if !f.IsValid() {
+			return ""
+		}
+	}
+	if f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
@@ -17,12 +17,16 @@ package langchain
 import (
 	"context"
 	"reflect"
+	"sync"
+	"time"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/ollama"
 	"github.com/tmc/langchaingo/llms/openai"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:linkname openaiGenerateContentOnEnter github.com/tmc/langchaingo/llms/openai.openaiGenerateContentOnEnter
@@ -47,38 +51,7 @@ func openaiGenerateContentOnEnter(call api.CallContext, llm *openai.LLM,
 
 //go:linkname openaiGenerateContentOnExit github.com/tmc/langchaingo/llms/openai.openaiGenerateContentOnExit
 func openaiGenerateContentOnExit(call api.CallContext, resp *llms.ContentResponse, err error) {
-	data := call.GetData().(map[string]interface{})
-	request := langChainLLMRequest{}
-	response := langChainLLMResponse{}
-	ctx, ok := data["ctx"].(context.Context)
-	if !ok {
-		return
-	}
-	if err != nil {
-		langChainLLMInstrument.End(ctx, request, response, err)
-		return
-	}
-	request = data["request"].(langChainLLMRequest)
-
-	if len(resp.Choices) > 0 {
-		var finishReasons []string
-		for _, choice := range resp.Choices {
-			finishReasons = append(finishReasons, choice.StopReason)
-		}
-		response.responseFinishReasons = finishReasons
-		if totalTokensAny, ok1 := resp.Choices[0].GenerationInfo["TotalTokens"]; ok1 {
-			if totalTokens, ok2 := totalTokensAny.(int); ok2 {
-				response.usageOutputTokens = int64(totalTokens)
-			}
-		}
-		if reasoningTokensAny, ok1 := resp.Choices[0].GenerationInfo["ReasoningTokens"]; ok1 {
-			if totalTokens, ok2 := reasoningTokensAny.(int); ok2 {
-				request.usageInputTokens = int64(totalTokens)
-			}
-		}
-	}
-
-	langChainLLMInstrument.End(ctx, request, response, nil)
+	LLMBaseOnExit(call, resp, err)
 }
 
 //go:linkname ollamaGenerateContentOnEnter github.com/tmc/langchaingo/llms/ollama.ollamaGenerateContentOnEnter
@@ -100,25 +73,7 @@ func ollamaGenerateContentOnEnter(call api.CallContext, llm *ollama.LLM,
 
 //go:linkname ollamaGenerateContentOnExit github.com/tmc/langchaingo/llms/ollama.ollamaGenerateContentOnExit
 func ollamaGenerateContentOnExit(call api.CallContext, resp *llms.ContentResponse, err error) {
-	data := call.GetData().(map[string]interface{})
-	request := langChainLLMRequest{}
-	response := langChainLLMResponse{}
-	ctx, ok := data["ctx"].(context.Context)
-	if !ok {
-		return
-	}
-	if err != nil {
-		langChainLLMInstrument.End(ctx, request, response, err)
-		return
-	}
-	request = data["request"].(langChainLLMRequest)
-
-	if totalTokensAny, ok1 := resp.Choices[0].GenerationInfo["TotalTokens"]; ok1 {
-		if totalTokens, ok2 := totalTokensAny.(int); ok2 {
-			response.usageOutputTokens = int64(totalTokens)
-		}
-	}
-	langChainLLMInstrument.End(ctx, request, response, nil)
+	LLMBaseOnExit(call, resp, err)
 }
 
 func LLMBaseOnEnter(call api.CallContext,
@@ -142,8 +97,161 @@ func LLMBaseOnEnter(call api.CallContext,
 	req.seed = int64(llmsOpts.Seed)
 
 	langCtx := langChainLLMInstrument.Start(ctx, *req)
+	if ai.CaptureMessageContent() {
+		ai.RecordInputMessages(trace.SpanFromContext(langCtx), aiLLMRequest{}.GetAISystem(*req), toGenAIMessages(messages))
+	}
+	// the streaming callback receives this context, so chunks are handled
+	// within the llm span
+	call.SetParam(1, langCtx)
 	data := make(map[string]interface{})
 	data["ctx"] = langCtx
 	data["request"] = *req
+	if llmsOpts.StreamingFunc != nil {
+		stream := &streamState{start: time.Now()}
+		streamingFunc := llmsOpts.StreamingFunc
+		options = append(options, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			stream.chunkStart()
+			defer stream.chunkDone()
+			return streamingFunc(ctx, chunk)
+		}))
+		call.SetParam(3, options)
+		data["stream"] = stream
+	}
 	call.SetData(data)
 }
+
+func LLMBaseOnExit(call api.CallContext, resp *llms.ContentResponse, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, _ := data["request"].(langChainLLMRequest)
+	response := langChainLLMResponse{}
+	if err == nil && resp != nil {
+		for _, choice := range resp.Choices {
+			if choice != nil && choice.StopReason != "" {
+				response.responseFinishReasons = append(response.responseFinishReasons, choice.StopReason)
+			}
+		}
+		if len(resp.Choices) > 0 && resp.Choices[0] != nil {
+			request.usageInputTokens, response.usageOutputTokens = generationTokens(resp.Choices[0].GenerationInfo)
+		}
+		if ai.CaptureMessageContent() {
+			ai.RecordChoices(trace.SpanFromContext(ctx), aiLLMRequest{}.GetAISystem(request), toGenAIChoices(resp))
+		}
+	}
+	stream, ok := data["stream"].(*streamState)
+	if !ok {
+		langChainLLMInstrument.End(ctx, request, response, err)
+		return
+	}
+	stream.finish(func() {
+		if ttft := stream.timeToFirstToken(); ttft > 0 {
+			ctx = ai.ContextWithTimeToFirstToken(ctx, ttft)
+		}
+		langChainLLMInstrument.End(ctx, request, response, err)
+	})
+}
+
+// streamState follows the chunks delivered to a streaming callback. Some
+// providers deliver chunks from a reader goroutine, so the llm span is only
+// ended once the call has returned and no chunk is being handled anymore.
+type streamState struct {
+	mu       sync.Mutex
+	start    time.Time
+	ttft     time.Duration
+	inflight int
+	returned bool
+	end      func()
+}
+
+func (s *streamState) chunkStart() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ttft == 0 {
+		s.ttft = time.Since(s.start)
+	}
+	s.inflight++
+}
+
+func (s *streamState) chunkDone() {
+	s.mu.Lock()
+	s.inflight--
+	var end func()
+	if s.returned && s.inflight == 0 {
+		end, s.end = s.end, nil
+	}
+	s.mu.Unlock()
+	if end != nil {
+		end()
+	}
+}
+
+func (s *streamState) finish(end func()) {
+	s.mu.Lock()
+	s.returned = true
+	if s.inflight > 0 {
+		s.end = end
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	end()
+}
+
+func (s *streamState) timeToFirstToken() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ttft
+}
+
+// generationTokenKeys lists the keys under which the providers report the
+// input and output token counts in the choice generation info.
+var generationTokenKeys = [][2]string{
+	{"PromptTokens", "CompletionTokens"}, // openai, ollama, googleai
+	{"InputTokens", "OutputTokens"},      // anthropic
+	{"input_tokens", "output_tokens"},    // googleai, bedrock
+}
+
+// generationTokens reads the input and output token counts reported by the
+// providers in the choice generation info.
+func generationTokens(info map[string]any) (int64, int64) {
+	for _, keys := range generationTokenKeys {
+		input, hasInput := tokenCount(info[keys[0]])
+		output, hasOutput := tokenCount(info[keys[1]])
+		if hasInput || hasOutput {
+			return input, output
+		}
+	}
+	// mistral reports the usage of the response as it is
+	usage := reflect.Indirect(reflect.ValueOf(info["usage"]))
+	if usage.Kind() != reflect.Struct {
+		return 0, 0
+	}
+	var input, output int64
+	if f := usage.FieldByName("PromptTokens"); f.IsValid() && f.CanInterface() {
+		input, _ = tokenCount(f.Interface())
+	}
+	if f := usage.FieldByName("CompletionTokens"); f.IsValid() && f.CanInterface() {
+		output, _ = tokenCount(f.Interface())
+	}
+	return input, output
+}
+
+func tokenCount(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), true
+	}
+	return 0, false
+}
@@ -25,6 +25,7 @@ const (
 	MEmbedSingle       = "singleEmbed"
 	MEmbedBatch        = "batchedEmbed"
 	MRelevantDoc       = "relevantDocuments"
+	MExecuteTool       = "execute_tool"
 )
 
 type langChainInnerEnabler struct {
@@ -38,3 +39,5 @@ func (l langChainInnerEnabler) Enable() bool {
 var langChainEnabler = langChainInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_LANGCHAIN_ENABLED") != "false"}
 
 var langChainCommonInstrument = BuildCommonLangchainOtelInstrumenter()
+
+var langChainToolInstrument = BuildLangchainToolOtelInstrumenter()
@@ -12,16 +12,16 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package mcp
+package client
 
 import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	mcpsemconv "github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/mcp"
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -49,19 +49,22 @@ func clientOnEnter(call api.CallContext,
 	if method == string(mcp.MethodPing) {
 		return
 	}
-	request := mcpRequest{
-		operationName: "execute_other:" + string(method),
-		system:        "mcp",
-		methodType:    method,
-		input:         map[string]any{},
-		output:        map[string]any{},
+	request := mcpsemconv.Request{
+		OperationName: "execute_other:" + string(method),
+		System:        "mcp",
+		MethodType:    method,
+		Input:         map[string]any{},
+		Output:        map[string]any{},
 	}
 	//var subRequest *mcp.Request
 	if err := handleClientRequest(method, &request, params); err != nil {
-		fmt.Println("handleClientRequest", "未匹配")
 		return
 	}
-	Ctx := ClientInstrumenter.Start(ctx, request)
+	Ctx := mcpsemconv.ClientInstrumenter.Start(ctx, request)
+	recordToolCall(Ctx, method, request, params)
+	if injected, ok := mcpsemconv.InjectParams(Ctx, params); ok {
+		call.SetParam(3, injected)
+	}
 	data := make(map[string]interface{})
 	data["ctx"] = Ctx
 	data["mcp_client_request"] = request
@@ -86,14 +89,34 @@ func clientOnExit(call api.CallContext, j *json.RawMessage, err error) {
 	if !ok {
 		return
 	}
-	request, ok := data["mcp_client_request"].(mcpRequest)
+	request, ok := data["mcp_client_request"].(mcpsemconv.Request)
 	if !ok {
 		return
 	}
-	ClientInstrumenter.End(ctx, request, nil, err)
+	if request.MethodType == string(mcp.MethodToolsCall) {
+		mcpsemconv.RecordToolResult(ctx, j, err)
+	}
+	mcpsemconv.ClientInstrumenter.End(ctx, request, nil, err)
+}
+
+// recordToolCall records the arguments of a tools/call request
+func recordToolCall(ctx context.Context, method string,
+	request mcpsemconv.Request, params interface{}) {
+	if method != string(mcp.MethodToolsCall) {
+		return
+	}
+	if msg, ok := params.(struct {
+		Name      string                 `json:"name"`
+		Arguments map[string]interface{} `json:"arguments,omitempty"`
+		Meta      *struct {
+			ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+		} `json:"_meta,omitempty"`
+	}); ok {
+		mcpsemconv.RecordToolCall(ctx, request.CallId, msg.Name, msg.Arguments)
+	}
 }
 
-func handleClientRequest(method string, request *mcpRequest, message interface{}) error {
+func handleClientRequest(method string, request *mcpsemconv.Request, message interface{}) error {
 	switch method {
 	case string(mcp.MethodToolsCall):
 		if msg, ok := message.(struct {
@@ -104,8 +127,8 @@ func handleClientRequest(method string, request *mcpRequest, message interface{}
 			} `json:"_meta,omitempty"`
 		}); ok {
 			if request != nil {
-				request.operationName = "execute_tool"
-				request.methodName = msg.Name
+				request.OperationName = "execute_tool"
+				request.MethodName = msg.Name
 			}
 		}
 		return nil
@@ -117,7 +140,7 @@ func handleClientRequest(method string, request *mcpRequest, message interface{}
 			Arguments map[string]string `json:"arguments,omitempty"`
 		}); ok {
 			if request != nil {
-				request.input["prompt_name"] = msg.Name
+				request.Input["prompt_name"] = msg.Name
 			}
 		}
 		return nil
@@ -127,7 +150,7 @@ func handleClientRequest(method string, request *mcpRequest, message interface{}
 			Arguments map[string]interface{} `json:"arguments,omitempty"`
 		}); ok {
 			if request != nil {
-				request.input["resources_uri"] = msg.URI
+				request.Input["resources_uri"] = msg.URI
 			}
 		}
 		return nil
@@ -138,8 +161,8 @@ func handleClientRequest(method string, request *mcpRequest, message interface{}
 			ClientInfo      mcp.Implementation     `json:"clientInfo"`
 		}); ok {
 			if request != nil {
-				request.input["client_info_name"] = msg.ClientInfo.Name
-				request.input["client_info_version"] = msg.ClientInfo.Version
+				request.Input["client_info_name"] = msg.ClientInfo.Name
+				request.Input["client_info_version"] = msg.ClientInfo.Version
 			}
 		}
 		return nil
@@ -148,7 +171,7 @@ func handleClientRequest(method string, request *mcpRequest, message interface{}
 			Cursor mcp.Cursor `json:"cursor,omitempty"`
 		}); ok {
 			if request != nil {
-				request.input["cursor"] = msg.Cursor
+				request.Input["cursor"] = msg.Cursor
 			}
 		}
 		return nil
@@ -157,7 +180,7 @@ func handleClientRequest(method string, request *mcpRequest, message interface{}
 			Cursor mcp.Cursor `json:"cursor,omitempty"`
 		}); ok {
 			if request != nil {
-				request.input["cursor"] = msg.Cursor
+				request.Input["cursor"] = msg.Cursor
 			}
 		}
 		return nil
@@ -166,7 +189,7 @@ func handleClientRequest(method string, request *mcpRequest, message interface{}
 			Cursor mcp.Cursor `json:"cursor,omitempty"`
 		}); ok {
 			if request != nil {
-				request.input["cursor"] = msg.Cursor
+				request.Input["cursor"] = msg.Cursor
 			}
 		}
 		return nil
@@ -175,7 +198,7 @@ func handleClientRequest(method string, request *mcpRequest, message interface{}
 			Cursor mcp.Cursor `json:"cursor,omitempty"`
 		}); ok {
 			if request != nil {
-				request.input["cursor"] = msg.Cursor
+				request.Input["cursor"] = msg.Cursor
 			}
 		}
 		return nil
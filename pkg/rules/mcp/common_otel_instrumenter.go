@@ -124,14 +124,3 @@ func BuildServerCommonOtelInstrumenter() instrumenter.Instrumenter[mcpRequest, a
 		}).
 		BuildInstrumenter()
 }
-func BuildClientCommonOtelInstrumenter() instrumenter.Instrumenter[mcpRequest, any] {
-	builder := instrumenter.Builder[mcpRequest, any]{}
-	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[mcpRequest, any]{Getter: aiCommonRequest{}}).
-		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[mcpRequest]{}).
-		AddAttributesExtractor(&LExperimentalAttributeExtractor{}).
-		SetInstrumentationScope(instrumentation.Scope{
-			Name:    utils.MCP_SCOPE_NAME,
-			Version: version.Tag,
-		}).
-		BuildInstrumenter()
-}
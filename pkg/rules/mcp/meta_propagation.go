@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	mcpsemconv "github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// extractMeta returns ctx carrying the remote span context found in the
+// params._meta of message, or ctx itself when there is none.
+func extractMeta(ctx context.Context, message json.RawMessage) context.Context {
+	if !bytes.Contains(message, []byte(`"_meta"`)) {
+		return ctx
+	}
+	var request struct {
+		Params struct {
+			Meta map[string]any `json:"_meta"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil {
+		return ctx
+	}
+	return mcpsemconv.ExtractMeta(ctx, request.Params.Meta)
+}
+
+//go:linkname serverHandleMessageOnEnter github.com/mark3labs/mcp-go/server.serverHandleMessageOnEnter
+func serverHandleMessageOnEnter(call api.CallContext, s *server.MCPServer,
+	ctx context.Context, message json.RawMessage) {
+	if extracted := extractMeta(ctx, message); extracted != ctx {
+		call.SetParam(1, extracted)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	mcpsemconv "github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/mcp"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -44,6 +45,10 @@ func hookBeforeAnyOnEnter(call api.CallContext, c *server.Hooks,
 		return
 	}
 	Ctx := ServerInstrumenter.Start(ctx, request)
+	if msg, ok := message.(*mcp.CallToolRequest); ok {
+		mcpsemconv.RecordToolCall(Ctx, request.CallId, msg.Params.Name,
+			msg.Params.Arguments)
+	}
 	//subRequest.OtelRequest = request
 	subRequest.OtelContext = Ctx
 }
@@ -63,6 +68,9 @@ func hookOnSuccessOnEnter(call api.CallContext, c *server.Hooks,
 	if !ok {
 		return
 	}
+	if method == mcp.MethodToolsCall {
+		mcpsemconv.RecordToolResult(ctx, result, nil)
+	}
 	ServerInstrumenter.End(ctx, request, nil, nil)
 }
 
@@ -80,6 +88,9 @@ func hookOnErrorOnEnter(call api.CallContext, c *server.Hooks,
 	if !ok {
 		return
 	}
+	if method == mcp.MethodToolsCall {
+		mcpsemconv.RecordToolResult(ctx, nil, err)
+	}
 	request := mcpRequest{}
 	ServerInstrumenter.End(ctx, request, nil, err)
 }
@@ -15,4 +15,3 @@
 package mcp
 
 var ServerInstrumenter = BuildServerCommonOtelInstrumenter()
-var ClientInstrumenter = BuildClientCommonOtelInstrumenter()
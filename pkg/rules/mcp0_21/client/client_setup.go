@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	mcpsemconv "github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/mcp"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+//go:linkname clientSendRequestOnEnter github.com/mark3labs/mcp-go/client.clientSendRequestOnEnter
+func clientSendRequestOnEnter(call api.CallContext, c *client.Client,
+	ctx context.Context, method string, params any) {
+	if method == string(mcp.MethodPing) {
+		return
+	}
+	request := mcpsemconv.Request{
+		OperationName: "execute_other:" + method,
+		System:        "mcp",
+		MethodType:    method,
+		Input:         map[string]any{},
+		Output:        map[string]any{},
+	}
+	if !handleClientRequest(method, &request, params) {
+		return
+	}
+	ctx = mcpsemconv.ClientInstrumenter.Start(ctx, request)
+	if method == string(mcp.MethodToolsCall) {
+		mcpsemconv.RecordToolCall(ctx, request.CallId, request.MethodName,
+			toolArguments(params))
+	}
+	if injected, ok := mcpsemconv.InjectParams(ctx, params); ok {
+		call.SetParam(3, injected)
+	}
+	data := make(map[string]interface{})
+	data["ctx"] = ctx
+	data["mcp_client_request"] = request
+	call.SetData(data)
+}
+
+//go:linkname clientSendRequestOnExit github.com/mark3labs/mcp-go/client.clientSendRequestOnExit
+func clientSendRequestOnExit(call api.CallContext, j *json.RawMessage, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, ok := data["mcp_client_request"].(mcpsemconv.Request)
+	if !ok {
+		return
+	}
+	if request.MethodType == string(mcp.MethodToolsCall) {
+		mcpsemconv.RecordToolResult(ctx, j, err)
+	}
+	mcpsemconv.ClientInstrumenter.End(ctx, request, nil, err)
+}
+
+// toolArguments reads the arguments of a tools/call request back from the JSON
+// form of its params, see handleClientRequest
+func toolArguments(params any) json.RawMessage {
+	var fields struct {
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if raw, err := json.Marshal(params); err == nil {
+		_ = json.Unmarshal(raw, &fields)
+	}
+	return fields.Arguments
+}
+
+// handleClientRequest fills request from the params of method. The params
+// types differ between mcp-go releases, so they are read back from their
+// JSON form rather than through type assertions.
+func handleClientRequest(method string, request *mcpsemconv.Request, params any) bool {
+	var fields struct {
+		Name       string             `json:"name"`
+		URI        string             `json:"uri"`
+		Cursor     mcp.Cursor         `json:"cursor"`
+		ClientInfo mcp.Implementation `json:"clientInfo"`
+	}
+	if raw, err := json.Marshal(params); err == nil {
+		_ = json.Unmarshal(raw, &fields)
+	}
+	switch method {
+	case string(mcp.MethodToolsCall):
+		request.OperationName = "execute_tool"
+		request.MethodName = fields.Name
+	case string(mcp.MethodPromptsGet):
+		request.Input["prompt_name"] = fields.Name
+	case string(mcp.MethodResourcesRead):
+		request.Input["resources_uri"] = fields.URI
+	case string(mcp.MethodInitialize):
+		request.Input["client_info_name"] = fields.ClientInfo.Name
+		request.Input["client_info_version"] = fields.ClientInfo.Version
+	case string(mcp.MethodResourcesList), string(mcp.MethodResourcesTemplatesList),
+		string(mcp.MethodPromptsList), string(mcp.MethodToolsList):
+		request.Input["cursor"] = fields.Cursor
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcpsdk
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	mcpsemconv "github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/mcp"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newClientRequest(method string) mcpRequest {
+	return mcpRequest{
+		operationName: "execute_other:" + method,
+		system:        "mcp",
+		methodType:    method,
+		input:         map[string]any{},
+		output:        map[string]any{},
+	}
+}
+
+func startClientRequest(call api.CallContext, ctx context.Context, request mcpRequest) context.Context {
+	ctx = ClientInstrumenter.Start(ctx, request)
+	data := make(map[string]interface{})
+	data["ctx"] = ctx
+	data["mcp_client_request"] = request
+	call.SetData(data)
+	return ctx
+}
+
+func endClientRequest(call api.CallContext, res any, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, ok := data["mcp_client_request"].(mcpRequest)
+	if !ok {
+		return
+	}
+	if request.methodType == methodToolsCall {
+		mcpsemconv.RecordToolResult(ctx, res, err)
+	}
+	ClientInstrumenter.End(ctx, request, nil, err)
+}
+
+//go:linkname clientCallToolOnEnter github.com/modelcontextprotocol/go-sdk/mcp.clientCallToolOnEnter
+func clientCallToolOnEnter(call api.CallContext, cs *mcp.ClientSession,
+	ctx context.Context, params *mcp.CallToolParams) {
+	if params == nil {
+		return
+	}
+	request := newClientRequest(methodToolsCall)
+	request.operationName = "execute_tool"
+	request.methodName = params.Name
+	ctx = startClientRequest(call, ctx, request)
+	mcpsemconv.RecordToolCall(ctx, "", params.Name, params.Arguments)
+	if meta, ok := mcpsemconv.InjectMeta(ctx, params.Meta); ok {
+		injected := *params
+		injected.Meta = meta
+		call.SetParam(2, &injected)
+	}
+}
+
+//go:linkname clientCallToolOnExit github.com/modelcontextprotocol/go-sdk/mcp.clientCallToolOnExit
+func clientCallToolOnExit(call api.CallContext, res *mcp.CallToolResult, err error) {
+	endClientRequest(call, res, err)
+}
+
+//go:linkname clientReadResourceOnEnter github.com/modelcontextprotocol/go-sdk/mcp.clientReadResourceOnEnter
+func clientReadResourceOnEnter(call api.CallContext, cs *mcp.ClientSession,
+	ctx context.Context, params *mcp.ReadResourceParams) {
+	if params == nil {
+		return
+	}
+	request := newClientRequest(methodResourcesRead)
+	request.input["resources_uri"] = params.URI
+	ctx = startClientRequest(call, ctx, request)
+	if meta, ok := mcpsemconv.InjectMeta(ctx, params.Meta); ok {
+		injected := *params
+		injected.Meta = meta
+		call.SetParam(2, &injected)
+	}
+}
+
+//go:linkname clientReadResourceOnExit github.com/modelcontextprotocol/go-sdk/mcp.clientReadResourceOnExit
+func clientReadResourceOnExit(call api.CallContext, res *mcp.ReadResourceResult, err error) {
+	endClientRequest(call, res, err)
+}
+
+//go:linkname clientGetPromptOnEnter github.com/modelcontextprotocol/go-sdk/mcp.clientGetPromptOnEnter
+func clientGetPromptOnEnter(call api.CallContext, cs *mcp.ClientSession,
+	ctx context.Context, params *mcp.GetPromptParams) {
+	if params == nil {
+		return
+	}
+	request := newClientRequest(methodPromptsGet)
+	request.input["prompt_name"] = params.Name
+	ctx = startClientRequest(call, ctx, request)
+	if meta, ok := mcpsemconv.InjectMeta(ctx, params.Meta); ok {
+		injected := *params
+		injected.Meta = meta
+		call.SetParam(2, &injected)
+	}
+}
+
+//go:linkname clientGetPromptOnExit github.com/modelcontextprotocol/go-sdk/mcp.clientGetPromptOnExit
+func clientGetPromptOnExit(call api.CallContext, res *mcp.GetPromptResult, err error) {
+	endClientRequest(call, res, err)
+}
+
+//go:linkname clientListToolsOnEnter github.com/modelcontextprotocol/go-sdk/mcp.clientListToolsOnEnter
+func clientListToolsOnEnter(call api.CallContext, cs *mcp.ClientSession,
+	ctx context.Context, params *mcp.ListToolsParams) {
+	request := newClientRequest(methodToolsList)
+	injected := mcp.ListToolsParams{}
+	if params != nil {
+		request.input["cursor"] = params.Cursor
+		injected = *params
+	}
+	ctx = startClientRequest(call, ctx, request)
+	if meta, ok := mcpsemconv.InjectMeta(ctx, injected.Meta); ok {
+		injected.Meta = meta
+		call.SetParam(2, &injected)
+	}
+}
+
+//go:linkname clientListToolsOnExit github.com/modelcontextprotocol/go-sdk/mcp.clientListToolsOnExit
+func clientListToolsOnExit(call api.CallContext, res *mcp.ListToolsResult, err error) {
+	endClientRequest(call, res, err)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcpsdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+var ClientInstrumenter = BuildClientCommonOtelInstrumenter()
+var ServerInstrumenter = BuildServerCommonOtelInstrumenter()
+
+type aiCommonRequest struct {
+}
+
+func (aiCommonRequest) GetAIOperationName(request mcpRequest) string {
+	return request.operationName
+}
+func (aiCommonRequest) GetAISystem(request mcpRequest) string {
+	return request.system
+}
+
+type LExperimentalAttributeExtractor struct {
+	Base ai.AICommonAttrsExtractor[mcpRequest, any, aiCommonRequest]
+}
+
+func (l LExperimentalAttributeExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request mcpRequest) ([]attribute.KeyValue, context.Context) {
+	attributes, parentContext = l.Base.OnStart(attributes, parentContext, request)
+	if request.methodType == methodToolsCall {
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   "gen_ai.tool.name",
+			Value: attribute.StringValue(request.methodName),
+		}, attribute.KeyValue{
+			Key:   "gen_ai.tool.call.id",
+			Value: attribute.StringValue(request.CallId),
+		})
+	}
+	return appendOtherAttributes(attributes, "gen_ai.other_input.", request.input), parentContext
+}
+
+func (l LExperimentalAttributeExtractor) OnEnd(attributes []attribute.KeyValue, context context.Context, request mcpRequest, response any, err error) ([]attribute.KeyValue, context.Context) {
+	attributes, context = l.Base.OnEnd(attributes, context, request, response, err)
+	return appendOtherAttributes(attributes, "gen_ai.other_output.", request.output), context
+}
+
+func appendOtherAttributes(attributes []attribute.KeyValue, prefix string, values map[string]any) []attribute.KeyValue {
+	for k, v := range values {
+		var val attribute.Value
+		switch v := v.(type) {
+		case string:
+			val = attribute.StringValue(v)
+		case int:
+			val = attribute.IntValue(v)
+		case int64:
+			val = attribute.Int64Value(v)
+		case float64:
+			val = attribute.Float64Value(v)
+		case bool:
+			val = attribute.BoolValue(v)
+		default:
+			val = attribute.StringValue(fmt.Sprintf("%#v", v))
+		}
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   attribute.Key(prefix + k),
+			Value: val,
+		})
+	}
+	return attributes
+}
+
+func BuildClientCommonOtelInstrumenter() instrumenter.Instrumenter[mcpRequest, any] {
+	builder := instrumenter.Builder[mcpRequest, any]{}
+	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[mcpRequest, any]{Getter: aiCommonRequest{}}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[mcpRequest]{}).
+		AddAttributesExtractor(&LExperimentalAttributeExtractor{}).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.MCP_SDK_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
+
+func BuildServerCommonOtelInstrumenter() instrumenter.Instrumenter[mcpRequest, any] {
+	builder := instrumenter.Builder[mcpRequest, any]{}
+	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[mcpRequest, any]{Getter: aiCommonRequest{}}).
+		SetSpanKindExtractor(&instrumenter.AlwaysServerExtractor[mcpRequest]{}).
+		AddAttributesExtractor(&LExperimentalAttributeExtractor{}).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.MCP_SDK_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcpsdk
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	mcpsemconv "github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/mcp"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// startServerRequest starts the server span as the child of the client span
+// carried in meta, and hands its context to the registered handler.
+func startServerRequest(call api.CallContext, ctx context.Context, meta mcp.Meta, request mcpRequest) context.Context {
+	ctx = ServerInstrumenter.Start(mcpsemconv.ExtractMeta(ctx, meta), request)
+	call.SetParam(1, ctx)
+	data := make(map[string]interface{})
+	data["ctx"] = ctx
+	data["mcp_server_request"] = request
+	call.SetData(data)
+	return ctx
+}
+
+func endServerRequest(call api.CallContext, res any, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, ok := data["mcp_server_request"].(mcpRequest)
+	if !ok {
+		return
+	}
+	if request.methodType == methodToolsCall {
+		mcpsemconv.RecordToolResult(ctx, res, err)
+	}
+	ServerInstrumenter.End(ctx, request, nil, err)
+}
+
+//go:linkname serverCallToolOnEnter github.com/modelcontextprotocol/go-sdk/mcp.serverCallToolOnEnter
+func serverCallToolOnEnter(call api.CallContext, s *mcp.Server,
+	ctx context.Context, req *mcp.CallToolRequest) {
+	if req == nil || req.Params == nil {
+		return
+	}
+	request := mcpRequest{
+		operationName: "execute_tool",
+		system:        "mcp",
+		methodName:    req.Params.Name,
+		methodType:    methodToolsCall,
+		input:         map[string]any{},
+		output:        map[string]any{},
+	}
+	ctx = startServerRequest(call, ctx, req.Params.Meta, request)
+	mcpsemconv.RecordToolCall(ctx, "", req.Params.Name, req.Params.Arguments)
+}
+
+//go:linkname serverCallToolOnExit github.com/modelcontextprotocol/go-sdk/mcp.serverCallToolOnExit
+func serverCallToolOnExit(call api.CallContext, res *mcp.CallToolResult, err error) {
+	endServerRequest(call, res, err)
+}
+
+//go:linkname serverReadResourceOnEnter github.com/modelcontextprotocol/go-sdk/mcp.serverReadResourceOnEnter
+func serverReadResourceOnEnter(call api.CallContext, s *mcp.Server,
+	ctx context.Context, req *mcp.ReadResourceRequest) {
+	if req == nil || req.Params == nil {
+		return
+	}
+	request := mcpRequest{
+		operationName: "execute_other:" + methodResourcesRead,
+		system:        "mcp",
+		methodType:    methodResourcesRead,
+		input:         map[string]any{"resources_uri": req.Params.URI},
+		output:        map[string]any{},
+	}
+	startServerRequest(call, ctx, req.Params.Meta, request)
+}
+
+//go:linkname serverReadResourceOnExit github.com/modelcontextprotocol/go-sdk/mcp.serverReadResourceOnExit
+func serverReadResourceOnExit(call api.CallContext, res *mcp.ReadResourceResult, err error) {
+	endServerRequest(call, res, err)
+}
+
+//go:linkname serverGetPromptOnEnter github.com/modelcontextprotocol/go-sdk/mcp.serverGetPromptOnEnter
+func serverGetPromptOnEnter(call api.CallContext, s *mcp.Server,
+	ctx context.Context, req *mcp.GetPromptRequest) {
+	if req == nil || req.Params == nil {
+		return
+	}
+	request := mcpRequest{
+		operationName: "execute_other:" + methodPromptsGet,
+		system:        "mcp",
+		methodType:    methodPromptsGet,
+		input:         map[string]any{"prompt_name": req.Params.Name},
+		output:        map[string]any{},
+	}
+	startServerRequest(call, ctx, req.Params.Meta, request)
+}
+
+//go:linkname serverGetPromptOnExit github.com/modelcontextprotocol/go-sdk/mcp.serverGetPromptOnExit
+func serverGetPromptOnExit(call api.CallContext, res *mcp.GetPromptResult, err error) {
+	endServerRequest(call, res, err)
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvus
+
+import (
+	"context"
+	"os"
+	"reflect"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+var milvusInstrumenter = BuildMilvusOtelInstrumenter()
+
+type milvusInnerEnabler struct {
+	enabled bool
+}
+
+func (m milvusInnerEnabler) Enable() bool {
+	return m.enabled
+}
+
+var milvusEnabler = milvusInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_MILVUS_ENABLED") != "false"}
+
+//go:linkname milvusSearchOnEnter github.com/milvus-io/milvus-sdk-go/v2/client.milvusSearchOnEnter
+func milvusSearchOnEnter(call api.CallContext, c *client.GrpcClient, ctx context.Context, collName string,
+	partitions []string, expr string, outputFields []string, vectors []entity.Vector, vectorField string,
+	metricType entity.MetricType, topK int, sp entity.SearchParam, opts ...client.SearchQueryOptionFunc,
+) {
+	request := newMilvusRequest(c, "search", collName)
+	request.expr = expr
+	request.topK = topK
+	request.search = true
+	milvusStart(call, ctx, request)
+}
+
+//go:linkname milvusSearchOnExit github.com/milvus-io/milvus-sdk-go/v2/client.milvusSearchOnExit
+func milvusSearchOnExit(call api.CallContext, results []client.SearchResult, err error) {
+	milvusEnd(call, err, func(request *milvusRequest) {
+		for _, result := range results {
+			request.returned += result.ResultCount
+		}
+	})
+}
+
+//go:linkname milvusQueryOnEnter github.com/milvus-io/milvus-sdk-go/v2/client.milvusQueryOnEnter
+func milvusQueryOnEnter(call api.CallContext, c *client.GrpcClient, ctx context.Context, collectionName string,
+	partitionNames []string, expr string, outputFields []string, opts ...client.SearchQueryOptionFunc,
+) {
+	request := newMilvusRequest(c, "query", collectionName)
+	request.expr = expr
+	request.search = true
+	milvusStart(call, ctx, request)
+}
+
+//go:linkname milvusQueryOnExit github.com/milvus-io/milvus-sdk-go/v2/client.milvusQueryOnExit
+func milvusQueryOnExit(call api.CallContext, resultSet client.ResultSet, err error) {
+	milvusEnd(call, err, func(request *milvusRequest) {
+		if len(resultSet) > 0 && resultSet[0] != nil {
+			request.returned = resultSet[0].Len()
+		}
+	})
+}
+
+//go:linkname milvusInsertOnEnter github.com/milvus-io/milvus-sdk-go/v2/client.milvusInsertOnEnter
+func milvusInsertOnEnter(call api.CallContext, c *client.GrpcClient, ctx context.Context, collName string,
+	partitionName string, columns ...entity.Column,
+) {
+	request := newMilvusRequest(c, "insert", collName)
+	request.batchSize = columnsLen(columns)
+	milvusStart(call, ctx, request)
+}
+
+//go:linkname milvusInsertOnExit github.com/milvus-io/milvus-sdk-go/v2/client.milvusInsertOnExit
+func milvusInsertOnExit(call api.CallContext, ids entity.Column, err error) {
+	milvusEnd(call, err, nil)
+}
+
+//go:linkname milvusUpsertOnEnter github.com/milvus-io/milvus-sdk-go/v2/client.milvusUpsertOnEnter
+func milvusUpsertOnEnter(call api.CallContext, c *client.GrpcClient, ctx context.Context, collName string,
+	partitionName string, columns ...entity.Column,
+) {
+	request := newMilvusRequest(c, "upsert", collName)
+	request.batchSize = columnsLen(columns)
+	milvusStart(call, ctx, request)
+}
+
+//go:linkname milvusUpsertOnExit github.com/milvus-io/milvus-sdk-go/v2/client.milvusUpsertOnExit
+func milvusUpsertOnExit(call api.CallContext, ids entity.Column, err error) {
+	milvusEnd(call, err, nil)
+}
+
+//go:linkname milvusDeleteOnEnter github.com/milvus-io/milvus-sdk-go/v2/client.milvusDeleteOnEnter
+func milvusDeleteOnEnter(call api.CallContext, c *client.GrpcClient, ctx context.Context, collName string,
+	partitionName string, expr string,
+) {
+	request := newMilvusRequest(c, "delete", collName)
+	request.expr = expr
+	milvusStart(call, ctx, request)
+}
+
+//go:linkname milvusDeleteOnExit github.com/milvus-io/milvus-sdk-go/v2/client.milvusDeleteOnExit
+func milvusDeleteOnExit(call api.CallContext, err error) {
+	milvusEnd(call, err, nil)
+}
+
+func newMilvusRequest(c *client.GrpcClient, operation, collection string) *milvusRequest {
+	request := &milvusRequest{
+		operation:  operation,
+		collection: collection,
+	}
+	if c == nil {
+		return request
+	}
+	if c.Conn != nil {
+		request.address = c.Conn.Target()
+	}
+	// the database in use is only kept in the unexported client config
+	config := reflect.ValueOf(c).Elem().FieldByName("config")
+	if config.IsValid() && config.Kind() == reflect.Pointer && !config.IsNil() {
+		if dbName := config.Elem().FieldByName("DBName"); dbName.IsValid() {
+			request.dbName = dbName.String()
+		}
+	}
+	return request
+}
+
+func milvusStart(call api.CallContext, ctx context.Context, request *milvusRequest) {
+	if !milvusEnabler.Enable() {
+		return
+	}
+	newCtx := milvusInstrumenter.Start(ctx, request)
+	// the grpc calls issued by the client are made within the milvus span
+	call.SetParam(1, newCtx)
+	call.SetKeyData("ctx", newCtx)
+	call.SetKeyData("request", request)
+}
+
+func milvusEnd(call api.CallContext, err error, onResult func(request *milvusRequest)) {
+	if !milvusEnabler.Enable() {
+		return
+	}
+	newCtx, ok := call.GetKeyData("ctx").(context.Context)
+	if !ok {
+		return
+	}
+	request := call.GetKeyData("request").(*milvusRequest)
+	if err == nil && onResult != nil {
+		onResult(request)
+	}
+	milvusInstrumenter.End(newCtx, request, nil, err)
+}
+
+func columnsLen(columns []entity.Column) int {
+	if len(columns) == 0 || columns[0] == nil {
+		return 0
+	}
+	return columns[0].Len()
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvus
+
+import (
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+type milvusAttrsGetter struct {
+}
+
+func (m milvusAttrsGetter) GetSystem(request *milvusRequest) string {
+	return "milvus"
+}
+
+func (m milvusAttrsGetter) GetServerAddress(request *milvusRequest) string {
+	return request.address
+}
+
+func (m milvusAttrsGetter) GetStatement(request *milvusRequest) string {
+	return request.expr
+}
+
+func (m milvusAttrsGetter) GetOperation(request *milvusRequest) string {
+	return request.operation
+}
+
+func (m milvusAttrsGetter) GetCollection(request *milvusRequest) string {
+	return request.collection
+}
+
+func (m milvusAttrsGetter) GetParameters(request *milvusRequest) []any {
+	return nil
+}
+
+func (m milvusAttrsGetter) GetDbNamespace(request *milvusRequest) string {
+	return request.dbName
+}
+
+func (m milvusAttrsGetter) GetBatchSize(request *milvusRequest) int {
+	return request.batchSize
+}
+
+func (m milvusAttrsGetter) GetTopK(request *milvusRequest) int {
+	return request.topK
+}
+
+func (m milvusAttrsGetter) GetReturnedRows(request *milvusRequest) (int, bool) {
+	return request.returned, request.search
+}
+
+func BuildMilvusOtelInstrumenter() instrumenter.Instrumenter[*milvusRequest, any] {
+	builder := instrumenter.Builder[*milvusRequest, any]{}
+	getter := milvusAttrsGetter{}
+	return builder.Init().SetSpanNameExtractor(&db.DBSpanNameExtractor[*milvusRequest]{Getter: getter}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[*milvusRequest]{}).
+		AddOperationListeners(db.DbClientMetrics("vector.milvus")).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.MILVUS_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		AddAttributesExtractor(&db.DbClientAttrsExtractor[*milvusRequest, any, db.DbClientAttrsGetter[*milvusRequest]]{Base: db.DbClientCommonAttrsExtractor[*milvusRequest, any, db.DbClientAttrsGetter[*milvusRequest]]{Getter: getter}}).
+		AddAttributesExtractor(&db.DbVectorAttrsExtractor[*milvusRequest, any, db.DbVectorAttrsGetter[*milvusRequest]]{Getter: getter}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"strings"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// chanSubscriptionCheckInterval is how often the forwarding goroutine of a
+// channel subscription checks whether the subscription is still valid
+const chanSubscriptionCheckInterval = time.Second
+
+// shouldTrace filters out the messages that are not worth a span: JetStream
+// status and heartbeat messages, and plain replies to requests, which are
+// consumed on an inbox and carry no trace context
+func shouldTrace(msg *nats.Msg) bool {
+	if msg == nil {
+		return false
+	}
+	if len(msg.Data) == 0 && msg.Header.Get("Status") != "" {
+		return false
+	}
+	if strings.HasPrefix(msg.Subject, nats.InboxPrefix) {
+		_, traced := extractMsgLink(msg)
+		return traced
+	}
+	return true
+}
+
+// connSubscribeOnEnter instruments every subscription made on the connection.
+// The callback of asynchronous subscriptions (Subscribe, QueueSubscribe and
+// JetStream push subscriptions) is wrapped to record a process span around
+// it. The channel of channel subscriptions (ChanSubscribe, ChanQueueSubscribe)
+// is replaced by one that is forwarded to the application after a receive
+// span has been recorded. Synchronous subscriptions are recorded by NextMsg.
+//
+//go:linkname connSubscribeOnEnter github.com/nats-io/nats.go.connSubscribeOnEnter
+func connSubscribeOnEnter(call api.CallContext, nc *nats.Conn, subj, queue string, cb nats.MsgHandler,
+	ch chan *nats.Msg, errCh chan error, isSync bool, js interface{}) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	if cb != nil {
+		call.SetParam(3, wrapMsgHandler(cb, queue))
+		return
+	}
+	if ch != nil && !isSync {
+		forward := make(chan *nats.Msg, cap(ch))
+		subscription := make(chan *nats.Subscription, 1)
+		go forwardChanSubscription(forward, ch, subscription, queue)
+		call.SetParam(4, forward)
+		call.SetData(subscription)
+	}
+}
+
+//go:linkname connSubscribeOnExit github.com/nats-io/nats.go.connSubscribeOnExit
+func connSubscribeOnExit(call api.CallContext, sub *nats.Subscription, err error) {
+	subscription, ok := call.GetData().(chan *nats.Subscription)
+	if !ok {
+		return
+	}
+	if err != nil || sub == nil {
+		close(subscription)
+		return
+	}
+	subscription <- sub
+}
+
+func wrapMsgHandler(cb nats.MsgHandler, queue string) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		if !shouldTrace(msg) {
+			cb(msg)
+			return
+		}
+		request := natsReq{msg: msg, queue: queue}
+		ctx := processInstrumenter.Start(context.Background(), request)
+		defer processInstrumenter.End(ctx, request, nil, nil)
+		cb(msg)
+	}
+}
+
+// forwardChanSubscription records a receive span for every message delivered
+// to a channel subscription and hands it to the channel of the application.
+// nats.go never closes the channel of a channel subscription, so the
+// goroutine exits once the subscription is no longer valid.
+func forwardChanSubscription(forward <-chan *nats.Msg, ch chan<- *nats.Msg,
+	subscription <-chan *nats.Subscription, queue string) {
+	defer func() {
+		// The application may close its channel once it has unsubscribed
+		_ = recover()
+	}()
+	sub, ok := <-subscription
+	if !ok {
+		return
+	}
+	ticker := time.NewTicker(chanSubscriptionCheckInterval)
+	defer ticker.Stop()
+	deliver := func(msg *nats.Msg) {
+		if shouldTrace(msg) {
+			now := time.Now()
+			receiveInstrumenter.StartAndEnd(context.Background(), natsReq{msg: msg, queue: queue},
+				nil, nil, now, now)
+		}
+	}
+	// send hands the message to the application, it gives up once the
+	// subscription is closed, as the application may never read it then
+	send := func(msg *nats.Msg) bool {
+		for {
+			select {
+			case ch <- msg:
+				return true
+			case <-ticker.C:
+				if !sub.IsValid() {
+					return false
+				}
+			}
+		}
+	}
+	for {
+		select {
+		case msg := <-forward:
+			deliver(msg)
+			if !send(msg) {
+				return
+			}
+		case <-ticker.C:
+			if sub.IsValid() {
+				continue
+			}
+			// Hand out what was delivered before the subscription was closed
+			// without blocking on an application that stopped reading
+			for {
+				select {
+				case msg := <-forward:
+					deliver(msg)
+					select {
+					case ch <- msg:
+					default:
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+//go:linkname subscriptionNextMsgOnEnter github.com/nats-io/nats.go.subscriptionNextMsgOnEnter
+func subscriptionNextMsgOnEnter(call api.CallContext, sub *nats.Subscription, timeout time.Duration) {
+	nextMsgOnEnter(call, sub)
+}
+
+//go:linkname subscriptionNextMsgOnExit github.com/nats-io/nats.go.subscriptionNextMsgOnExit
+func subscriptionNextMsgOnExit(call api.CallContext, msg *nats.Msg, err error) {
+	nextMsgOnExit(call, msg, err)
+}
+
+//go:linkname subscriptionNextMsgWithContextOnEnter github.com/nats-io/nats.go.subscriptionNextMsgWithContextOnEnter
+func subscriptionNextMsgWithContextOnEnter(call api.CallContext, sub *nats.Subscription, ctx context.Context) {
+	nextMsgOnEnter(call, sub)
+}
+
+//go:linkname subscriptionNextMsgWithContextOnExit github.com/nats-io/nats.go.subscriptionNextMsgWithContextOnExit
+func subscriptionNextMsgWithContextOnExit(call api.CallContext, msg *nats.Msg, err error) {
+	nextMsgOnExit(call, msg, err)
+}
+
+func nextMsgOnEnter(call api.CallContext, sub *nats.Subscription) {
+	if !natsEnabler.Enable() || sub == nil {
+		return
+	}
+	call.SetData(map[string]interface{}{
+		"queue":          sub.Queue,
+		"startTimestamp": time.Now(),
+	})
+}
+
+// nextMsgOnExit records a receive span for a message pulled from a
+// synchronous subscription, timeouts without a message are not recorded
+func nextMsgOnExit(call api.CallContext, msg *nats.Msg, err error) {
+	if !natsEnabler.Enable() || err != nil || !shouldTrace(msg) {
+		return
+	}
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	queue, _ := data["queue"].(string)
+	startTimestamp, ok := data["startTimestamp"].(time.Time)
+	if !ok {
+		return
+	}
+	receiveInstrumenter.StartAndEnd(context.Background(), natsReq{msg: msg, queue: queue},
+		nil, nil, startTimestamp, time.Now())
+}
+
+//go:linkname subscriptionFetchOnEnter github.com/nats-io/nats.go.subscriptionFetchOnEnter
+func subscriptionFetchOnEnter(call api.CallContext, sub *nats.Subscription, batch int, opts ...nats.PullOpt) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	call.SetData(time.Now())
+}
+
+// subscriptionFetchOnExit records a single receive span for a JetStream pull,
+// the span links to the upstream context of every fetched message
+//
+//go:linkname subscriptionFetchOnExit github.com/nats-io/nats.go.subscriptionFetchOnExit
+func subscriptionFetchOnExit(call api.CallContext, msgs []*nats.Msg, err error) {
+	if !natsEnabler.Enable() || len(msgs) == 0 {
+		return
+	}
+	startTimestamp, ok := call.GetData().(time.Time)
+	if !ok {
+		return
+	}
+	request := natsFetchReq{msgs: msgs, subject: msgs[0].Subject}
+	links := make([]trace.Link, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Subject != request.subject {
+			request.subject = ""
+		}
+		if link, ok := extractMsgLink(msg); ok {
+			links = append(links, link)
+		}
+	}
+	fetchInstrumenter.StartAndEndWithOptions(context.Background(), request, nil, err,
+		startTimestamp, time.Now(), []trace.SpanStartOption{trace.WithLinks(links...)}, nil)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"github.com/nats-io/nats.go"
+)
+
+// natsReq describes a message published or consumed through a connection.
+// For publishing, msg is rebuilt from the encoded headers so that the trace
+// context can be injected.
+type natsReq struct {
+	msg   *nats.Msg
+	queue string
+}
+
+// natsFetchReq describes all messages returned by one Subscription.Fetch
+type natsFetchReq struct {
+	msgs    []*nats.Msg
+	subject string
+}
+
+// natsSettleReq describes the acknowledgement of a JetStream message
+type natsSettleReq struct {
+	header  nats.Header
+	subject string
+	outcome string
+}
@@ -0,0 +1,453 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const natsSystem = "nats"
+
+// Instrumentation enabler controller
+var natsEnabler = natsInnerEnabler{os.Getenv("OTEL_NATS_ENABLED") != "false"}
+
+// Cache Instrumenter instances to avoid repeated creation
+var (
+	publishInstrumenter = buildNatsPublishInstrumenter()
+	receiveInstrumenter = buildNatsReceiveInstrumenter()
+	processInstrumenter = buildNatsProcessInstrumenter()
+	fetchInstrumenter   = buildNatsFetchInstrumenter()
+	settleInstrumenter  = buildNatsSettleInstrumenter()
+)
+
+type natsInnerEnabler struct {
+	enabled bool
+}
+
+func (n natsInnerEnabler) Enable() bool {
+	return n.enabled
+}
+
+// natsHeaderCarrier implements OpenTelemetry propagator carrier interface on
+// top of the message headers. NATS headers are case-sensitive, the keys are
+// used exactly as the propagator hands them out.
+type natsHeaderCarrier struct {
+	msg *nats.Msg
+}
+
+func (carrier natsHeaderCarrier) Get(key string) string {
+	return carrier.msg.Header.Get(key)
+}
+
+func (carrier natsHeaderCarrier) Set(key, value string) {
+	if carrier.msg.Header == nil {
+		carrier.msg.Header = nats.Header{}
+	}
+	carrier.msg.Header.Set(key, value)
+}
+
+func (carrier natsHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier.msg.Header))
+	for key := range carrier.msg.Header {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// extractMsgLink builds a span link pointing to the upstream context that the
+// publisher injected into the message headers
+func extractMsgLink(msg *nats.Msg) (trace.Link, bool) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(),
+		natsHeaderCarrier{msg: msg})
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return trace.Link{}, false
+	}
+	return trace.Link{SpanContext: spanContext}, true
+}
+
+// natsStatusExtractor extracts operation status for all nats spans
+type natsStatusExtractor[REQUEST any] struct{}
+
+func (extractor *natsStatusExtractor[REQUEST]) Extract(span trace.Span, request REQUEST, response any, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+}
+
+// natsAttrsGetter retrieves attributes of a single published or consumed
+// message
+type natsAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[natsReq, any] = natsAttrsGetter{}
+
+func (getter natsAttrsGetter) GetSystem(request natsReq) string {
+	return natsSystem
+}
+
+func (getter natsAttrsGetter) GetDestination(request natsReq) string {
+	return request.msg.Subject
+}
+
+func (getter natsAttrsGetter) GetDestinationTemplate(request natsReq) string {
+	return ""
+}
+
+func (getter natsAttrsGetter) IsTemporaryDestination(request natsReq) bool {
+	return false
+}
+
+func (getter natsAttrsGetter) IsAnonymousDestination(request natsReq) bool {
+	return false
+}
+
+func (getter natsAttrsGetter) GetConversationId(request natsReq) string {
+	return ""
+}
+
+func (getter natsAttrsGetter) GetMessageBodySize(request natsReq) int64 {
+	return int64(len(request.msg.Data))
+}
+
+func (getter natsAttrsGetter) GetMessageEnvelopSize(request natsReq) int64 {
+	return 0
+}
+
+func (getter natsAttrsGetter) GetMessageId(request natsReq, response any) string {
+	return request.msg.Header.Get(nats.MsgIdHdr)
+}
+
+func (getter natsAttrsGetter) GetClientId(request natsReq) string {
+	return ""
+}
+
+func (getter natsAttrsGetter) GetBatchMessageCount(request natsReq, response any) int64 {
+	return 1
+}
+
+func (getter natsAttrsGetter) GetMessageHeader(request natsReq, name string) []string {
+	return request.msg.Header.Values(name)
+}
+
+func (getter natsAttrsGetter) GetDestinationPartitionId(request natsReq) string {
+	return ""
+}
+
+// natsFetchAttrsGetter retrieves attributes of a whole JetStream fetch
+type natsFetchAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[natsFetchReq, any] = natsFetchAttrsGetter{}
+
+func (getter natsFetchAttrsGetter) GetSystem(request natsFetchReq) string {
+	return natsSystem
+}
+
+func (getter natsFetchAttrsGetter) GetDestination(request natsFetchReq) string {
+	return request.subject
+}
+
+func (getter natsFetchAttrsGetter) GetDestinationTemplate(request natsFetchReq) string {
+	return ""
+}
+
+func (getter natsFetchAttrsGetter) IsTemporaryDestination(request natsFetchReq) bool {
+	return false
+}
+
+func (getter natsFetchAttrsGetter) IsAnonymousDestination(request natsFetchReq) bool {
+	return false
+}
+
+func (getter natsFetchAttrsGetter) GetConversationId(request natsFetchReq) string {
+	return ""
+}
+
+func (getter natsFetchAttrsGetter) GetMessageBodySize(request natsFetchReq) int64 {
+	var size int64
+	for _, msg := range request.msgs {
+		size += int64(len(msg.Data))
+	}
+	return size
+}
+
+func (getter natsFetchAttrsGetter) GetMessageEnvelopSize(request natsFetchReq) int64 {
+	return 0
+}
+
+func (getter natsFetchAttrsGetter) GetMessageId(request natsFetchReq, response any) string {
+	return ""
+}
+
+func (getter natsFetchAttrsGetter) GetClientId(request natsFetchReq) string {
+	return ""
+}
+
+func (getter natsFetchAttrsGetter) GetBatchMessageCount(request natsFetchReq, response any) int64 {
+	return int64(len(request.msgs))
+}
+
+func (getter natsFetchAttrsGetter) GetMessageHeader(request natsFetchReq, name string) []string {
+	return []string{}
+}
+
+func (getter natsFetchAttrsGetter) GetDestinationPartitionId(request natsFetchReq) string {
+	return ""
+}
+
+// natsSettleAttrsGetter retrieves attributes of a JetStream acknowledgement
+type natsSettleAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[natsSettleReq, any] = natsSettleAttrsGetter{}
+
+func (getter natsSettleAttrsGetter) GetSystem(request natsSettleReq) string {
+	return natsSystem
+}
+
+func (getter natsSettleAttrsGetter) GetDestination(request natsSettleReq) string {
+	return request.subject
+}
+
+func (getter natsSettleAttrsGetter) GetDestinationTemplate(request natsSettleReq) string {
+	return ""
+}
+
+func (getter natsSettleAttrsGetter) IsTemporaryDestination(request natsSettleReq) bool {
+	return false
+}
+
+func (getter natsSettleAttrsGetter) IsAnonymousDestination(request natsSettleReq) bool {
+	return false
+}
+
+func (getter natsSettleAttrsGetter) GetConversationId(request natsSettleReq) string {
+	return ""
+}
+
+func (getter natsSettleAttrsGetter) GetMessageBodySize(request natsSettleReq) int64 {
+	return 0
+}
+
+func (getter natsSettleAttrsGetter) GetMessageEnvelopSize(request natsSettleReq) int64 {
+	return 0
+}
+
+func (getter natsSettleAttrsGetter) GetMessageId(request natsSettleReq, response any) string {
+	return request.header.Get(nats.MsgIdHdr)
+}
+
+func (getter natsSettleAttrsGetter) GetClientId(request natsSettleReq) string {
+	return ""
+}
+
+func (getter natsSettleAttrsGetter) GetBatchMessageCount(request natsSettleReq, response any) int64 {
+	return 0
+}
+
+func (getter natsSettleAttrsGetter) GetMessageHeader(request natsSettleReq, name string) []string {
+	return request.header.Values(name)
+}
+
+func (getter natsSettleAttrsGetter) GetDestinationPartitionId(request natsSettleReq) string {
+	return ""
+}
+
+// natsConsumerAttrsExtractor extracts the nats specific consumer attributes
+type natsConsumerAttrsExtractor struct{}
+
+func (extractor *natsConsumerAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request natsReq) ([]attribute.KeyValue, context.Context) {
+	if request.queue != "" {
+		attributes = append(attributes, semconv.MessagingConsumerGroupName(request.queue))
+	}
+	return attributes, parentContext
+}
+
+func (extractor *natsConsumerAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request natsReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+// natsSettleAttrsExtractor records how a JetStream message was acknowledged
+type natsSettleAttrsExtractor struct{}
+
+func (extractor *natsSettleAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request natsSettleReq) ([]attribute.KeyValue, context.Context) {
+	return append(attributes,
+		semconv.MessagingOperationTypeSettle,
+		attribute.String("messaging.nats.message.ack_type", request.outcome),
+	), parentContext
+}
+
+func (extractor *natsSettleAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request natsSettleReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+// natsMsgContextCustomizer injects the span context into the headers of the
+// consumed message, so that the message handler and the acknowledgement of
+// the message can continue the trace from the message
+type natsMsgContextCustomizer struct{}
+
+func (customizer natsMsgContextCustomizer) OnStart(ctx context.Context, request natsReq, startAttributes []attribute.KeyValue) context.Context {
+	otel.GetTextMapPropagator().Inject(ctx, natsHeaderCarrier{msg: request.msg})
+	return ctx
+}
+
+// natsFetchContextCustomizer injects the receive span context into the
+// headers of every fetched message
+type natsFetchContextCustomizer struct{}
+
+func (customizer natsFetchContextCustomizer) OnStart(ctx context.Context, request natsFetchReq, startAttributes []attribute.KeyValue) context.Context {
+	for _, msg := range request.msgs {
+		otel.GetTextMapPropagator().Inject(ctx, natsHeaderCarrier{msg: msg})
+	}
+	return ctx
+}
+
+// Build nats publish instrumenter, one span per published message
+func buildNatsPublishInstrumenter() instrumenter.Instrumenter[natsReq, any] {
+	builder := instrumenter.Builder[natsReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.NATS_PRODUCER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[natsReq, any]{
+			Getter:        natsAttrsGetter{},
+			OperationName: message.PUBLISH,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[natsReq]{}).
+		SetSpanStatusExtractor(&natsStatusExtractor[natsReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[natsReq, any, natsAttrsGetter]{
+			Operation: message.PUBLISH,
+		}).
+		BuildPropagatingToDownstreamInstrumenter(
+			func(request natsReq) propagation.TextMapCarrier {
+				return natsHeaderCarrier{msg: request.msg}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build nats receive instrumenter, one span per message pulled from a
+// synchronous or channel subscription
+func buildNatsReceiveInstrumenter() instrumenter.Instrumenter[natsReq, any] {
+	builder := instrumenter.Builder[natsReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.NATS_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[natsReq, any]{
+			Getter:        natsAttrsGetter{},
+			OperationName: message.RECEIVE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[natsReq]{}).
+		SetSpanStatusExtractor(&natsStatusExtractor[natsReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[natsReq, any, natsAttrsGetter]{
+			Operation: message.RECEIVE,
+		}).
+		AddAttributesExtractor(&natsConsumerAttrsExtractor{}).
+		AddContextCustomizers(natsMsgContextCustomizer{}).
+		BuildPropagatingFromUpstreamInstrumenter(
+			func(request natsReq) propagation.TextMapCarrier {
+				return natsHeaderCarrier{msg: request.msg}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build nats process instrumenter, one span per message handed to a
+// subscription callback
+func buildNatsProcessInstrumenter() instrumenter.Instrumenter[natsReq, any] {
+	builder := instrumenter.Builder[natsReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.NATS_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[natsReq, any]{
+			Getter:        natsAttrsGetter{},
+			OperationName: message.PROCESS,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[natsReq]{}).
+		SetSpanStatusExtractor(&natsStatusExtractor[natsReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[natsReq, any, natsAttrsGetter]{
+			Operation: message.PROCESS,
+		}).
+		AddAttributesExtractor(&natsConsumerAttrsExtractor{}).
+		AddContextCustomizers(natsMsgContextCustomizer{}).
+		BuildPropagatingFromUpstreamInstrumenter(
+			func(request natsReq) propagation.TextMapCarrier {
+				return natsHeaderCarrier{msg: request.msg}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build nats fetch instrumenter, one span per JetStream pull which links to
+// the upstream context of every fetched message
+func buildNatsFetchInstrumenter() instrumenter.Instrumenter[natsFetchReq, any] {
+	builder := instrumenter.Builder[natsFetchReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.NATS_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[natsFetchReq, any]{
+			Getter:        natsFetchAttrsGetter{},
+			OperationName: message.RECEIVE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[natsFetchReq]{}).
+		SetSpanStatusExtractor(&natsStatusExtractor[natsFetchReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[natsFetchReq, any, natsFetchAttrsGetter]{
+			Operation: message.RECEIVE,
+		}).
+		AddContextCustomizers(natsFetchContextCustomizer{}).
+		BuildInstrumenter()
+}
+
+// Build nats settle instrumenter, one span per JetStream acknowledgement
+func buildNatsSettleInstrumenter() instrumenter.Instrumenter[natsSettleReq, any] {
+	builder := instrumenter.Builder[natsSettleReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.NATS_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[natsSettleReq, any]{
+			Getter:        natsSettleAttrsGetter{},
+			OperationName: message.SETTLE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[natsSettleReq]{}).
+		SetSpanStatusExtractor(&natsStatusExtractor[natsSettleReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[natsSettleReq, any, natsSettleAttrsGetter]{
+			Operation: message.SETTLE,
+		}).
+		AddAttributesExtractor(&natsSettleAttrsExtractor{}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/nats-io/nats.go"
+)
+
+// jsApiPrefix is the subject prefix of JetStream API requests, acks and flow
+// control replies, they are protocol traffic rather than application messages
+const jsApiPrefix = "$JS."
+
+// connPublishOnEnter starts a publish span for every message leaving the
+// connection. All of Publish, PublishMsg, PublishRequest, Request and the
+// JetStream publish calls end up in Conn.publish with the encoded headers,
+// the headers are rebuilt here to inject the trace context.
+//
+//go:linkname connPublishOnEnter github.com/nats-io/nats.go.connPublishOnEnter
+func connPublishOnEnter(call api.CallContext, nc *nats.Conn, subj, reply string, hdr, data []byte) {
+	if !natsEnabler.Enable() || subj == "" || strings.HasPrefix(subj, jsApiPrefix) {
+		return
+	}
+	msg := &nats.Msg{Subject: subj, Reply: reply, Data: data}
+	injectable := len(hdr) > 0 || (nc != nil && nc.HeadersSupported())
+	if len(hdr) > 0 {
+		header, err := nats.DecodeHeadersMsg(hdr)
+		if err != nil {
+			// Never drop the headers of the application
+			injectable = false
+		} else {
+			msg.Header = header
+		}
+	}
+	request := natsReq{msg: msg}
+	ctx := publishInstrumenter.Start(context.Background(), request)
+	if injectable {
+		if encoded, err := encodeHeader(msg.Header); err == nil {
+			call.SetParam(3, encoded)
+		}
+	}
+	call.SetData(map[string]interface{}{
+		"ctx":     ctx,
+		"request": request,
+	})
+}
+
+//go:linkname connPublishOnExit github.com/nats-io/nats.go.connPublishOnExit
+func connPublishOnExit(call api.CallContext, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, ok := data["request"].(natsReq)
+	if !ok {
+		return
+	}
+	publishInstrumenter.End(ctx, request, nil, err)
+}
+
+// encodeHeader encodes the headers the same way as nats.Msg does before
+// sending them to the server
+func encodeHeader(header nats.Header) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("NATS/1.0\r\n")
+	if err := http.Header(header).Write(&b); err != nil {
+		return nil, err
+	}
+	b.WriteString("\r\n")
+	return b.Bytes(), nil
+}
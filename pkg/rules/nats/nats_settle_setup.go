@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"bytes"
+	"context"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+)
+
+// ackOutcome maps the JetStream acknowledgement protocol to the recorded
+// outcome, progress notifications do not settle the message
+func ackOutcome(ackType []byte) string {
+	switch {
+	case bytes.HasPrefix(ackType, []byte("+ACK")), bytes.HasPrefix(ackType, []byte("+NXT")):
+		return "ack"
+	case bytes.HasPrefix(ackType, []byte("-NAK")):
+		return "nak"
+	case bytes.HasPrefix(ackType, []byte("+TERM")):
+		return "term"
+	}
+	return ""
+}
+
+// msgAckReplyOnEnter covers Ack, AckSync, Nak, NakWithDelay and Term of the
+// JetStream messages consumed through nats.JetStreamContext
+//
+//go:linkname msgAckReplyOnEnter github.com/nats-io/nats.go.msgAckReplyOnEnter
+func msgAckReplyOnEnter(call api.CallContext, msg *nats.Msg, ackType []byte, sync bool, opts ...nats.AckOpt) {
+	if msg == nil {
+		return
+	}
+	settleOnEnter(call, msg.Header, msg.Subject, ackOutcome(ackType))
+}
+
+//go:linkname msgAckReplyOnExit github.com/nats-io/nats.go.msgAckReplyOnExit
+func msgAckReplyOnExit(call api.CallContext, err error) {
+	settleOnExit(call, err)
+}
+
+//go:linkname jetStreamMsgAckOnEnter github.com/nats-io/nats.go/jetstream.jetStreamMsgAckOnEnter
+func jetStreamMsgAckOnEnter(call api.CallContext, msg interface{}) {
+	jetStreamSettleOnEnter(call, msg, "ack")
+}
+
+//go:linkname jetStreamMsgDoubleAckOnEnter github.com/nats-io/nats.go/jetstream.jetStreamMsgDoubleAckOnEnter
+func jetStreamMsgDoubleAckOnEnter(call api.CallContext, msg interface{}, ctx context.Context) {
+	jetStreamSettleOnEnter(call, msg, "ack")
+}
+
+//go:linkname jetStreamMsgNakOnEnter github.com/nats-io/nats.go/jetstream.jetStreamMsgNakOnEnter
+func jetStreamMsgNakOnEnter(call api.CallContext, msg interface{}) {
+	jetStreamSettleOnEnter(call, msg, "nak")
+}
+
+//go:linkname jetStreamMsgNakWithDelayOnEnter github.com/nats-io/nats.go/jetstream.jetStreamMsgNakWithDelayOnEnter
+func jetStreamMsgNakWithDelayOnEnter(call api.CallContext, msg interface{}, delay time.Duration) {
+	jetStreamSettleOnEnter(call, msg, "nak")
+}
+
+//go:linkname jetStreamMsgTermOnEnter github.com/nats-io/nats.go/jetstream.jetStreamMsgTermOnEnter
+func jetStreamMsgTermOnEnter(call api.CallContext, msg interface{}) {
+	jetStreamSettleOnEnter(call, msg, "term")
+}
+
+//go:linkname jetStreamMsgTermWithReasonOnEnter github.com/nats-io/nats.go/jetstream.jetStreamMsgTermWithReasonOnEnter
+func jetStreamMsgTermWithReasonOnEnter(call api.CallContext, msg interface{}, reason string) {
+	jetStreamSettleOnEnter(call, msg, "term")
+}
+
+//go:linkname jetStreamMsgSettleOnExit github.com/nats-io/nats.go/jetstream.jetStreamMsgSettleOnExit
+func jetStreamMsgSettleOnExit(call api.CallContext, err error) {
+	settleOnExit(call, err)
+}
+
+func jetStreamSettleOnEnter(call api.CallContext, msg interface{}, outcome string) {
+	jsMsg, ok := msg.(jetstream.Msg)
+	if !ok || jsMsg == nil {
+		return
+	}
+	settleOnEnter(call, jsMsg.Headers(), jsMsg.Subject(), outcome)
+}
+
+// settleOnEnter starts a settle span as a child of the context carried by the
+// message headers, which is the receive or process span of the message when
+// it was consumed with the instrumentation enabled
+func settleOnEnter(call api.CallContext, header nats.Header, subject, outcome string) {
+	if !natsEnabler.Enable() || outcome == "" {
+		return
+	}
+	parentContext := otel.GetTextMapPropagator().Extract(context.Background(),
+		natsHeaderCarrier{msg: &nats.Msg{Header: header}})
+	request := natsSettleReq{header: header, subject: subject, outcome: outcome}
+	ctx := settleInstrumenter.Start(parentContext, request)
+	call.SetData(map[string]interface{}{
+		"ctx":     ctx,
+		"request": request,
+	})
+}
+
+func settleOnExit(call api.CallContext, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, ok := data["request"].(natsSettleReq)
+	if !ok {
+		return
+	}
+	settleInstrumenter.End(ctx, request, nil, err)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/packages/ssestream"
+)
+
+func newChatRequest(body openai.ChatCompletionNewParams) openaiRequest {
+	request := openaiRequest{
+		operationName:    "chat",
+		modelName:        body.Model,
+		frequencyPenalty: body.FrequencyPenalty.Value,
+		presencePenalty:  body.PresencePenalty.Value,
+		maxTokens:        body.MaxCompletionTokens.Value,
+		temperature:      body.Temperature.Value,
+		topP:             body.TopP.Value,
+		seed:             body.Seed.Value,
+		startTime:        time.Now(),
+	}
+	if request.maxTokens == 0 {
+		request.maxTokens = body.MaxTokens.Value
+	}
+	if body.Stop.OfString.Value != "" {
+		request.stopSequences = []string{body.Stop.OfString.Value}
+	} else {
+		request.stopSequences = body.Stop.OfStringArray
+	}
+	return request
+}
+
+func startChat(call api.CallContext, ctx context.Context, body openai.ChatCompletionNewParams) {
+	if !openaiEnabler.Enable() {
+		return
+	}
+	request := newChatRequest(body)
+	ctx = openaiInstrument.Start(ctx, request)
+	call.SetParam(1, ctx)
+	data := make(map[string]interface{}, 2)
+	data["ctx"] = ctx
+	data["request"] = request
+	call.SetData(data)
+}
+
+//go:linkname chatNewOnEnter github.com/openai/openai-go.chatNewOnEnter
+func chatNewOnEnter(call api.CallContext, r *openai.ChatCompletionService, ctx context.Context,
+	body openai.ChatCompletionNewParams, opts ...option.RequestOption) {
+	startChat(call, ctx, body)
+}
+
+//go:linkname chatNewOnExit github.com/openai/openai-go.chatNewOnExit
+func chatNewOnExit(call api.CallContext, res *openai.ChatCompletion, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx := data["ctx"].(context.Context)
+	request := data["request"].(openaiRequest)
+	response := openaiResponse{}
+	if res != nil {
+		response.responseID = res.ID
+		response.responseModel = res.Model
+		for _, choice := range res.Choices {
+			response.responseFinishReasons = append(response.responseFinishReasons, choice.FinishReason)
+		}
+		request.usageInputTokens = res.Usage.PromptTokens
+		response.usageOutputTokens = res.Usage.CompletionTokens
+	}
+	openaiInstrument.End(ctx, request, response, err)
+}
+
+//go:linkname chatNewStreamingOnEnter github.com/openai/openai-go.chatNewStreamingOnEnter
+func chatNewStreamingOnEnter(call api.CallContext, r *openai.ChatCompletionService, ctx context.Context,
+	body openai.ChatCompletionNewParams, opts ...option.RequestOption) {
+	startChat(call, ctx, body)
+}
+
+//go:linkname chatNewStreamingOnExit github.com/openai/openai-go.chatNewStreamingOnExit
+func chatNewStreamingOnExit(call api.CallContext, stream *ssestream.Stream[openai.ChatCompletionChunk]) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	wrapStream(data["ctx"].(context.Context), data["request"].(openaiRequest), stream, &chatStreamObserver{})
+}
+
+type chatStreamObserver struct {
+	response      openaiResponse
+	finishReasons []string
+	usage         openai.CompletionUsage
+}
+
+// streamError returns the error carried by a stream event, if any.
+func streamError(event ssestream.Event) error {
+	var payload struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(event.Data, &payload) == nil && len(payload.Error) > 0 && string(payload.Error) != "null" {
+		return errors.New(string(payload.Error))
+	}
+	return nil
+}
+
+func (o *chatStreamObserver) observe(event ssestream.Event) (bool, error) {
+	if err := streamError(event); err != nil {
+		return false, err
+	}
+	var chunk openai.ChatCompletionChunk
+	if err := json.Unmarshal(event.Data, &chunk); err != nil {
+		return false, nil
+	}
+	if chunk.ID != "" {
+		o.response.responseID = chunk.ID
+	}
+	if chunk.Model != "" {
+		o.response.responseModel = chunk.Model
+	}
+	for _, choice := range chunk.Choices {
+		if choice.FinishReason == "" || choice.Index < 0 {
+			continue
+		}
+		for int64(len(o.finishReasons)) <= choice.Index {
+			o.finishReasons = append(o.finishReasons, "")
+		}
+		o.finishReasons[choice.Index] = choice.FinishReason
+	}
+	// usage comes in a trailing chunk when stream_options.include_usage is set
+	if chunk.Usage.TotalTokens > 0 {
+		o.usage = chunk.Usage
+	}
+	return len(chunk.Choices) > 0, nil
+}
+
+func (o *chatStreamObserver) finish(request *openaiRequest) openaiResponse {
+	o.response.responseFinishReasons = o.finishReasons
+	request.usageInputTokens = o.usage.PromptTokens
+	o.response.usageOutputTokens = o.usage.CompletionTokens
+	return o.response
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"context"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+)
+
+//go:linkname embeddingNewOnEnter github.com/openai/openai-go.embeddingNewOnEnter
+func embeddingNewOnEnter(call api.CallContext, r *openai.EmbeddingService, ctx context.Context,
+	body openai.EmbeddingNewParams, opts ...option.RequestOption) {
+	if !openaiEnabler.Enable() {
+		return
+	}
+	request := openaiRequest{
+		operationName: "embeddings",
+		modelName:     body.Model,
+		startTime:     time.Now(),
+	}
+	if body.EncodingFormat != "" {
+		request.encodingFormats = []string{string(body.EncodingFormat)}
+	}
+	ctx = openaiInstrument.Start(ctx, request)
+	call.SetParam(1, ctx)
+	data := make(map[string]interface{}, 2)
+	data["ctx"] = ctx
+	data["request"] = request
+	call.SetData(data)
+}
+
+//go:linkname embeddingNewOnExit github.com/openai/openai-go.embeddingNewOnExit
+func embeddingNewOnExit(call api.CallContext, res *openai.CreateEmbeddingResponse, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx := data["ctx"].(context.Context)
+	request := data["request"].(openaiRequest)
+	response := openaiResponse{}
+	if res != nil {
+		response.responseModel = res.Model
+		request.usageInputTokens = res.Usage.PromptTokens
+	}
+	openaiInstrument.End(ctx, request, response, err)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+type openaiInnerEnabler struct {
+	enabled bool
+}
+
+func (o openaiInnerEnabler) Enable() bool {
+	return o.enabled
+}
+
+var openaiEnabler = openaiInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_OPENAI_ENABLED") != "false"}
+
+var openaiInstrument = BuildOpenAIInstrumenter()
+
+type openaiAttrsGetter struct{}
+
+var _ ai.LLMAttrsGetter[openaiRequest, openaiResponse] = openaiAttrsGetter{}
+var _ ai.CommonAttrsGetter[openaiRequest, openaiResponse] = openaiAttrsGetter{}
+
+func (openaiAttrsGetter) GetAIOperationName(request openaiRequest) string {
+	return request.operationName
+}
+
+func (openaiAttrsGetter) GetAISystem(request openaiRequest) string {
+	return "openai"
+}
+
+func (openaiAttrsGetter) GetAIRequestModel(request openaiRequest) string {
+	return request.modelName
+}
+
+func (openaiAttrsGetter) GetAIRequestEncodingFormats(request openaiRequest) []string {
+	return request.encodingFormats
+}
+
+func (openaiAttrsGetter) GetAIRequestFrequencyPenalty(request openaiRequest) float64 {
+	return request.frequencyPenalty
+}
+
+func (openaiAttrsGetter) GetAIRequestPresencePenalty(request openaiRequest) float64 {
+	return request.presencePenalty
+}
+
+func (openaiAttrsGetter) GetAIResponseFinishReasons(request openaiRequest, response openaiResponse) []string {
+	return response.responseFinishReasons
+}
+
+func (openaiAttrsGetter) GetAIResponseModel(request openaiRequest, response openaiResponse) string {
+	return response.responseModel
+}
+
+func (openaiAttrsGetter) GetAIRequestMaxTokens(request openaiRequest) int64 {
+	return request.maxTokens
+}
+
+func (openaiAttrsGetter) GetAIUsageInputTokens(request openaiRequest) int64 {
+	return request.usageInputTokens
+}
+
+func (openaiAttrsGetter) GetAIUsageOutputTokens(request openaiRequest, response openaiResponse) int64 {
+	return response.usageOutputTokens
+}
+
+func (openaiAttrsGetter) GetAIRequestStopSequences(request openaiRequest) []string {
+	return request.stopSequences
+}
+
+func (openaiAttrsGetter) GetAIRequestTemperature(request openaiRequest) float64 {
+	return request.temperature
+}
+
+func (openaiAttrsGetter) GetAIRequestTopK(request openaiRequest) float64 {
+	return 0
+}
+
+func (openaiAttrsGetter) GetAIRequestTopP(request openaiRequest) float64 {
+	return request.topP
+}
+
+func (openaiAttrsGetter) GetAIResponseID(request openaiRequest, response openaiResponse) string {
+	return response.responseID
+}
+
+func (openaiAttrsGetter) GetAIServerAddress(request openaiRequest) string {
+	// the base url lives in the unexported request config of the client
+	return ""
+}
+
+func (openaiAttrsGetter) GetAIRequestSeed(request openaiRequest) int64 {
+	return request.seed
+}
+
+func BuildOpenAIInstrumenter() instrumenter.Instrumenter[openaiRequest, openaiResponse] {
+	builder := instrumenter.Builder[openaiRequest, openaiResponse]{}
+	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[openaiRequest, openaiResponse]{Getter: openaiAttrsGetter{}}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[openaiRequest]{}).
+		AddAttributesExtractor(&ai.AILLMAttrsExtractor[openaiRequest, openaiResponse, openaiAttrsGetter, openaiAttrsGetter]{}).
+		AddOperationListeners(ai.AIClientMetrics("openai.llm")).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.OPENAI_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/packages/ssestream"
+	"github.com/openai/openai-go/responses"
+)
+
+func startResponse(call api.CallContext, ctx context.Context, body responses.ResponseNewParams) {
+	if !openaiEnabler.Enable() {
+		return
+	}
+	request := openaiRequest{
+		operationName: "chat",
+		modelName:     body.Model,
+		maxTokens:     body.MaxOutputTokens.Value,
+		temperature:   body.Temperature.Value,
+		topP:          body.TopP.Value,
+		startTime:     time.Now(),
+	}
+	ctx = openaiInstrument.Start(ctx, request)
+	call.SetParam(1, ctx)
+	data := make(map[string]interface{}, 2)
+	data["ctx"] = ctx
+	data["request"] = request
+	call.SetData(data)
+}
+
+// fillResponse copies the response metadata, the status stands in for the
+// finish reason since the Responses API has no per choice reason.
+func fillResponse(res *responses.Response, request *openaiRequest, response *openaiResponse) {
+	response.responseID = res.ID
+	response.responseModel = res.Model
+	if res.IncompleteDetails.Reason != "" {
+		response.responseFinishReasons = []string{res.IncompleteDetails.Reason}
+	} else if res.Status != "" {
+		response.responseFinishReasons = []string{string(res.Status)}
+	}
+	request.usageInputTokens = res.Usage.InputTokens
+	response.usageOutputTokens = res.Usage.OutputTokens
+}
+
+//go:linkname responseNewOnEnter github.com/openai/openai-go/responses.responseNewOnEnter
+func responseNewOnEnter(call api.CallContext, r *responses.ResponseService, ctx context.Context,
+	body responses.ResponseNewParams, opts ...option.RequestOption) {
+	startResponse(call, ctx, body)
+}
+
+//go:linkname responseNewOnExit github.com/openai/openai-go/responses.responseNewOnExit
+func responseNewOnExit(call api.CallContext, res *responses.Response, err error) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx := data["ctx"].(context.Context)
+	request := data["request"].(openaiRequest)
+	response := openaiResponse{}
+	if res != nil {
+		fillResponse(res, &request, &response)
+	}
+	openaiInstrument.End(ctx, request, response, err)
+}
+
+//go:linkname responseNewStreamingOnEnter github.com/openai/openai-go/responses.responseNewStreamingOnEnter
+func responseNewStreamingOnEnter(call api.CallContext, r *responses.ResponseService, ctx context.Context,
+	body responses.ResponseNewParams, opts ...option.RequestOption) {
+	startResponse(call, ctx, body)
+}
+
+//go:linkname responseNewStreamingOnExit github.com/openai/openai-go/responses.responseNewStreamingOnExit
+func responseNewStreamingOnExit(call api.CallContext, stream *ssestream.Stream[responses.ResponseStreamEventUnion]) {
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	wrapStream(data["ctx"].(context.Context), data["request"].(openaiRequest), stream, &responseStreamObserver{})
+}
+
+type responseStreamObserver struct {
+	res *responses.Response
+}
+
+func (o *responseStreamObserver) observe(event ssestream.Event) (bool, error) {
+	var e responses.ResponseStreamEventUnion
+	if err := json.Unmarshal(event.Data, &e); err != nil {
+		return false, nil
+	}
+	switch e.Type {
+	case "response.created", "response.in_progress", "response.completed", "response.incomplete":
+		res := e.Response
+		o.res = &res
+	case "response.failed":
+		res := e.Response
+		o.res = &res
+		return false, errors.New(res.Error.Message)
+	case "error":
+		return false, errors.New(e.Message)
+	}
+	return strings.HasSuffix(e.Type, ".delta"), nil
+}
+
+func (o *responseStreamObserver) finish(request *openaiRequest) openaiResponse {
+	response := openaiResponse{}
+	if o.res != nil {
+		fillResponse(o.res, request, &response)
+	}
+	return response
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
+	"github.com/openai/openai-go/packages/ssestream"
+)
+
+// streamObserver accumulates the events of one streamed call and reports
+// whether the event carries the first generated token.
+type streamObserver interface {
+	observe(event ssestream.Event) (firstToken bool, err error)
+	finish(request *openaiRequest) openaiResponse
+}
+
+// streamDecoder sits between ssestream.Stream and the real decoder so that
+// the span covers the whole stream and ends once it is drained or closed.
+type streamDecoder struct {
+	ssestream.Decoder
+	ctx            context.Context
+	request        openaiRequest
+	observer       streamObserver
+	firstTokenTime time.Time
+	err            error
+	once           sync.Once
+}
+
+func (d *streamDecoder) Next() bool {
+	if d.Decoder.Next() {
+		event := d.Decoder.Event()
+		if !bytes.HasPrefix(event.Data, []byte("[DONE]")) {
+			firstToken, err := d.observer.observe(event)
+			if firstToken && d.firstTokenTime.IsZero() {
+				d.firstTokenTime = time.Now()
+			}
+			if err != nil && d.err == nil {
+				d.err = err
+			}
+		}
+		return true
+	}
+	d.end(d.Decoder.Err())
+	return false
+}
+
+func (d *streamDecoder) Close() error {
+	err := d.Decoder.Close()
+	d.end(nil)
+	return err
+}
+
+func (d *streamDecoder) end(err error) {
+	d.once.Do(func() {
+		if err == nil {
+			err = d.err
+		}
+		response := d.observer.finish(&d.request)
+		ctx := d.ctx
+		if !d.firstTokenTime.IsZero() {
+			ctx = ai.ContextWithTimeToFirstToken(ctx, d.firstTokenTime.Sub(d.request.startTime))
+		}
+		openaiInstrument.End(ctx, d.request, response, err)
+	})
+}
+
+// wrapStream replaces the decoder of an *ssestream.Stream[T]. The stream is
+// passed as any because the hooks see different instantiations of it.
+func wrapStream(ctx context.Context, request openaiRequest, stream any, observer streamObserver) {
+	v := reflect.ValueOf(stream)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		openaiInstrument.End(ctx, request, openaiResponse{}, errors.New("nil stream"))
+		return
+	}
+	decoder := v.Elem().FieldByName("decoder")
+	if !decoder.IsValid() {
+		openaiInstrument.End(ctx, request, openaiResponse{}, nil)
+		return
+	}
+	decoder = reflect.NewAt(decoder.Type(), unsafe.Pointer(decoder.UnsafeAddr())).Elem()
+	if decoder.IsNil() {
+		// the request failed, the stream only carries the error
+		var err error
+		if f := v.Elem().FieldByName("err"); f.IsValid() {
+			err, _ = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem().Interface().(error)
+		}
+		openaiInstrument.End(ctx, request, openaiResponse{}, err)
+		return
+	}
+	decoder.Set(reflect.ValueOf(&streamDecoder{
+		Decoder:  decoder.Interface().(ssestream.Decoder),
+		ctx:      ctx,
+		request:  request,
+		observer: observer,
+	}))
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"context"
+	"sync"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+// chanForwards holds the forwarding channel handed out by Chan for every
+// consumer, so that calling Chan repeatedly returns the same channel
+var chanForwards sync.Map
+
+// chanForward is the channel handed to the application in place of the
+// message channel of a consumer
+type chanForward struct {
+	ch   chan pulsar.ConsumerMessage
+	stop chan struct{}
+}
+
+// subscriptionOf returns the subscription of the unexported consumer
+// implementations (single topic, multiple topics and topics pattern)
+func subscriptionOf(c interface{}) string {
+	if consumer, ok := c.(pulsar.Consumer); ok {
+		return consumer.Subscription()
+	}
+	return ""
+}
+
+func receiveOnEnter(call api.CallContext, c interface{}) {
+	if !pulsarEnabler.Enable() {
+		return
+	}
+	call.SetData(map[string]interface{}{
+		"subscription":   subscriptionOf(c),
+		"startTimestamp": time.Now(),
+	})
+}
+
+// receiveOnExit records a receive span for a message returned by Receive,
+// calls ending without a message are not recorded
+func receiveOnExit(call api.CallContext, msg pulsar.Message, err error) {
+	if !pulsarEnabler.Enable() || err != nil || msg == nil {
+		return
+	}
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	subscription, _ := data["subscription"].(string)
+	startTimestamp, ok := data["startTimestamp"].(time.Time)
+	if !ok {
+		return
+	}
+	receiveInstrumenter.StartAndEnd(context.Background(), pulsarConsumerReq{msg: msg, subscription: subscription},
+		nil, nil, startTimestamp, time.Now())
+}
+
+func chanOnEnter(call api.CallContext, c interface{}) {
+	if !pulsarEnabler.Enable() {
+		return
+	}
+	call.SetData(c)
+}
+
+// chanOnExit replaces the message channel of the consumer by one that is
+// forwarded to the application after a receive span has been recorded. The
+// forwarding goroutine is started by the first call and exits when the
+// consumer is closed.
+func chanOnExit(call api.CallContext, ch <-chan pulsar.ConsumerMessage) {
+	c := call.GetData()
+	if !pulsarEnabler.Enable() || c == nil || ch == nil {
+		return
+	}
+	forward, ok := chanForwards.Load(c)
+	if !ok {
+		var loaded bool
+		forward, loaded = chanForwards.LoadOrStore(c, &chanForward{
+			ch:   make(chan pulsar.ConsumerMessage, cap(ch)),
+			stop: make(chan struct{}),
+		})
+		if !loaded {
+			go forwardConsumerChan(forward.(*chanForward), ch, subscriptionOf(c))
+		}
+	}
+	call.SetReturnVal(0, (<-chan pulsar.ConsumerMessage)(forward.(*chanForward).ch))
+}
+
+func forwardConsumerChan(forward *chanForward, ch <-chan pulsar.ConsumerMessage, subscription string) {
+	for {
+		select {
+		case cm, ok := <-ch:
+			if !ok {
+				close(forward.ch)
+				return
+			}
+			if cm.Message != nil {
+				now := time.Now()
+				receiveInstrumenter.StartAndEnd(context.Background(),
+					pulsarConsumerReq{msg: cm.Message, subscription: subscription}, nil, nil, now, now)
+			}
+			select {
+			case forward.ch <- cm:
+			case <-forward.stop:
+				return
+			}
+		case <-forward.stop:
+			return
+		}
+	}
+}
+
+// closeOnEnter stops the forwarding goroutine of the consumer
+func closeOnEnter(call api.CallContext, c interface{}) {
+	if forward, ok := chanForwards.LoadAndDelete(c); ok {
+		close(forward.(*chanForward).stop)
+	}
+}
+
+//go:linkname consumerReceiveOnEnter github.com/apache/pulsar-client-go/pulsar.consumerReceiveOnEnter
+func consumerReceiveOnEnter(call api.CallContext, c interface{}, ctx context.Context) {
+	receiveOnEnter(call, c)
+}
+
+//go:linkname consumerReceiveOnExit github.com/apache/pulsar-client-go/pulsar.consumerReceiveOnExit
+func consumerReceiveOnExit(call api.CallContext, msg pulsar.Message, err error) {
+	receiveOnExit(call, msg, err)
+}
+
+//go:linkname consumerChanOnEnter github.com/apache/pulsar-client-go/pulsar.consumerChanOnEnter
+func consumerChanOnEnter(call api.CallContext, c interface{}) {
+	chanOnEnter(call, c)
+}
+
+//go:linkname consumerChanOnExit github.com/apache/pulsar-client-go/pulsar.consumerChanOnExit
+func consumerChanOnExit(call api.CallContext, ch <-chan pulsar.ConsumerMessage) {
+	chanOnExit(call, ch)
+}
+
+//go:linkname consumerCloseOnEnter github.com/apache/pulsar-client-go/pulsar.consumerCloseOnEnter
+func consumerCloseOnEnter(call api.CallContext, c interface{}) {
+	closeOnEnter(call, c)
+}
+
+//go:linkname multiTopicConsumerReceiveOnEnter github.com/apache/pulsar-client-go/pulsar.multiTopicConsumerReceiveOnEnter
+func multiTopicConsumerReceiveOnEnter(call api.CallContext, c interface{}, ctx context.Context) {
+	receiveOnEnter(call, c)
+}
+
+//go:linkname multiTopicConsumerReceiveOnExit github.com/apache/pulsar-client-go/pulsar.multiTopicConsumerReceiveOnExit
+func multiTopicConsumerReceiveOnExit(call api.CallContext, msg pulsar.Message, err error) {
+	receiveOnExit(call, msg, err)
+}
+
+//go:linkname multiTopicConsumerChanOnEnter github.com/apache/pulsar-client-go/pulsar.multiTopicConsumerChanOnEnter
+func multiTopicConsumerChanOnEnter(call api.CallContext, c interface{}) {
+	chanOnEnter(call, c)
+}
+
+//go:linkname multiTopicConsumerChanOnExit github.com/apache/pulsar-client-go/pulsar.multiTopicConsumerChanOnExit
+func multiTopicConsumerChanOnExit(call api.CallContext, ch <-chan pulsar.ConsumerMessage) {
+	chanOnExit(call, ch)
+}
+
+//go:linkname multiTopicConsumerCloseOnEnter github.com/apache/pulsar-client-go/pulsar.multiTopicConsumerCloseOnEnter
+func multiTopicConsumerCloseOnEnter(call api.CallContext, c interface{}) {
+	closeOnEnter(call, c)
+}
+
+//go:linkname regexConsumerReceiveOnEnter github.com/apache/pulsar-client-go/pulsar.regexConsumerReceiveOnEnter
+func regexConsumerReceiveOnEnter(call api.CallContext, c interface{}, ctx context.Context) {
+	receiveOnEnter(call, c)
+}
+
+//go:linkname regexConsumerReceiveOnExit github.com/apache/pulsar-client-go/pulsar.regexConsumerReceiveOnExit
+func regexConsumerReceiveOnExit(call api.CallContext, msg pulsar.Message, err error) {
+	receiveOnExit(call, msg, err)
+}
+
+//go:linkname regexConsumerChanOnEnter github.com/apache/pulsar-client-go/pulsar.regexConsumerChanOnEnter
+func regexConsumerChanOnEnter(call api.CallContext, c interface{}) {
+	chanOnEnter(call, c)
+}
+
+//go:linkname regexConsumerChanOnExit github.com/apache/pulsar-client-go/pulsar.regexConsumerChanOnExit
+func regexConsumerChanOnExit(call api.CallContext, ch <-chan pulsar.ConsumerMessage) {
+	chanOnExit(call, ch)
+}
+
+//go:linkname regexConsumerCloseOnEnter github.com/apache/pulsar-client-go/pulsar.regexConsumerCloseOnEnter
+func regexConsumerCloseOnEnter(call api.CallContext, c interface{}) {
+	closeOnEnter(call, c)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+// pulsarProducerReq describes a message sent by a producer
+type pulsarProducerReq struct {
+	msg          *pulsar.ProducerMessage
+	topic        string
+	producerName string
+}
+
+// pulsarConsumerReq describes a message received by a consumer
+type pulsarConsumerReq struct {
+	msg          pulsar.Message
+	subscription string
+}
+
+// pulsarSettleReq describes the acknowledgement of a message
+type pulsarSettleReq struct {
+	msg          pulsar.Message
+	subscription string
+	outcome      string
+}
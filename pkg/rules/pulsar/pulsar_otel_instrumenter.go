@@ -0,0 +1,414 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"context"
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"github.com/apache/pulsar-client-go/pulsar"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const pulsarSystem = "pulsar"
+
+// Instrumentation enabler controller
+var pulsarEnabler = pulsarInnerEnabler{os.Getenv("OTEL_PULSAR_ENABLED") != "false"}
+
+// Cache Instrumenter instances to avoid repeated creation
+var (
+	publishInstrumenter = buildPulsarPublishInstrumenter()
+	receiveInstrumenter = buildPulsarReceiveInstrumenter()
+	settleInstrumenter  = buildPulsarSettleInstrumenter()
+)
+
+type pulsarInnerEnabler struct {
+	enabled bool
+}
+
+func (p pulsarInnerEnabler) Enable() bool {
+	return p.enabled
+}
+
+// pulsarProducerCarrier implements OpenTelemetry propagator carrier interface
+// on top of the properties of a message to be sent
+type pulsarProducerCarrier struct {
+	msg *pulsar.ProducerMessage
+}
+
+func (carrier pulsarProducerCarrier) Get(key string) string {
+	return carrier.msg.Properties[key]
+}
+
+func (carrier pulsarProducerCarrier) Set(key, value string) {
+	if carrier.msg.Properties == nil {
+		carrier.msg.Properties = make(map[string]string)
+	}
+	carrier.msg.Properties[key] = value
+}
+
+func (carrier pulsarProducerCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier.msg.Properties))
+	for key := range carrier.msg.Properties {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// pulsarConsumerCarrier implements OpenTelemetry propagator carrier interface
+// on top of the properties of a received message. The client hands out the
+// properties map of the message itself, so the context injected into it
+// travels with the message to its acknowledgement. Messages sent without
+// properties can not carry a context.
+type pulsarConsumerCarrier struct {
+	msg pulsar.Message
+}
+
+func (carrier pulsarConsumerCarrier) Get(key string) string {
+	return carrier.msg.Properties()[key]
+}
+
+func (carrier pulsarConsumerCarrier) Set(key, value string) {
+	if properties := carrier.msg.Properties(); properties != nil {
+		properties[key] = value
+	}
+}
+
+func (carrier pulsarConsumerCarrier) Keys() []string {
+	properties := carrier.msg.Properties()
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// pulsarStatusExtractor extracts operation status for all pulsar spans
+type pulsarStatusExtractor[REQUEST any, RESPONSE any] struct{}
+
+func (extractor *pulsarStatusExtractor[REQUEST, RESPONSE]) Extract(span trace.Span, request REQUEST, response RESPONSE, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+}
+
+// pulsarProducerAttrsGetter retrieves attributes of a sent message
+type pulsarProducerAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[pulsarProducerReq, pulsar.MessageID] = pulsarProducerAttrsGetter{}
+
+func (getter pulsarProducerAttrsGetter) GetSystem(request pulsarProducerReq) string {
+	return pulsarSystem
+}
+
+func (getter pulsarProducerAttrsGetter) GetDestination(request pulsarProducerReq) string {
+	return request.topic
+}
+
+func (getter pulsarProducerAttrsGetter) GetDestinationTemplate(request pulsarProducerReq) string {
+	return ""
+}
+
+func (getter pulsarProducerAttrsGetter) IsTemporaryDestination(request pulsarProducerReq) bool {
+	return false
+}
+
+func (getter pulsarProducerAttrsGetter) IsAnonymousDestination(request pulsarProducerReq) bool {
+	return false
+}
+
+func (getter pulsarProducerAttrsGetter) GetConversationId(request pulsarProducerReq) string {
+	return ""
+}
+
+func (getter pulsarProducerAttrsGetter) GetMessageBodySize(request pulsarProducerReq) int64 {
+	return int64(len(request.msg.Payload))
+}
+
+func (getter pulsarProducerAttrsGetter) GetMessageEnvelopSize(request pulsarProducerReq) int64 {
+	return 0
+}
+
+func (getter pulsarProducerAttrsGetter) GetMessageId(request pulsarProducerReq, response pulsar.MessageID) string {
+	if response == nil {
+		return ""
+	}
+	return response.String()
+}
+
+func (getter pulsarProducerAttrsGetter) GetClientId(request pulsarProducerReq) string {
+	return request.producerName
+}
+
+func (getter pulsarProducerAttrsGetter) GetBatchMessageCount(request pulsarProducerReq, response pulsar.MessageID) int64 {
+	return 1
+}
+
+func (getter pulsarProducerAttrsGetter) GetMessageHeader(request pulsarProducerReq, name string) []string {
+	return []string{request.msg.Properties[name]}
+}
+
+func (getter pulsarProducerAttrsGetter) GetDestinationPartitionId(request pulsarProducerReq) string {
+	return ""
+}
+
+// pulsarConsumerAttrsGetter retrieves attributes of a received message
+type pulsarConsumerAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[pulsarConsumerReq, any] = pulsarConsumerAttrsGetter{}
+
+func (getter pulsarConsumerAttrsGetter) GetSystem(request pulsarConsumerReq) string {
+	return pulsarSystem
+}
+
+func (getter pulsarConsumerAttrsGetter) GetDestination(request pulsarConsumerReq) string {
+	return request.msg.Topic()
+}
+
+func (getter pulsarConsumerAttrsGetter) GetDestinationTemplate(request pulsarConsumerReq) string {
+	return ""
+}
+
+func (getter pulsarConsumerAttrsGetter) IsTemporaryDestination(request pulsarConsumerReq) bool {
+	return false
+}
+
+func (getter pulsarConsumerAttrsGetter) IsAnonymousDestination(request pulsarConsumerReq) bool {
+	return false
+}
+
+func (getter pulsarConsumerAttrsGetter) GetConversationId(request pulsarConsumerReq) string {
+	return ""
+}
+
+func (getter pulsarConsumerAttrsGetter) GetMessageBodySize(request pulsarConsumerReq) int64 {
+	return int64(len(request.msg.Payload()))
+}
+
+func (getter pulsarConsumerAttrsGetter) GetMessageEnvelopSize(request pulsarConsumerReq) int64 {
+	return 0
+}
+
+func (getter pulsarConsumerAttrsGetter) GetMessageId(request pulsarConsumerReq, response any) string {
+	if id := request.msg.ID(); id != nil {
+		return id.String()
+	}
+	return ""
+}
+
+func (getter pulsarConsumerAttrsGetter) GetClientId(request pulsarConsumerReq) string {
+	return ""
+}
+
+func (getter pulsarConsumerAttrsGetter) GetBatchMessageCount(request pulsarConsumerReq, response any) int64 {
+	return 1
+}
+
+func (getter pulsarConsumerAttrsGetter) GetMessageHeader(request pulsarConsumerReq, name string) []string {
+	return []string{request.msg.Properties()[name]}
+}
+
+func (getter pulsarConsumerAttrsGetter) GetDestinationPartitionId(request pulsarConsumerReq) string {
+	return ""
+}
+
+// pulsarSettleAttrsGetter retrieves attributes of an acknowledgement
+type pulsarSettleAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[pulsarSettleReq, any] = pulsarSettleAttrsGetter{}
+
+func (getter pulsarSettleAttrsGetter) GetSystem(request pulsarSettleReq) string {
+	return pulsarSystem
+}
+
+func (getter pulsarSettleAttrsGetter) GetDestination(request pulsarSettleReq) string {
+	return request.msg.Topic()
+}
+
+func (getter pulsarSettleAttrsGetter) GetDestinationTemplate(request pulsarSettleReq) string {
+	return ""
+}
+
+func (getter pulsarSettleAttrsGetter) IsTemporaryDestination(request pulsarSettleReq) bool {
+	return false
+}
+
+func (getter pulsarSettleAttrsGetter) IsAnonymousDestination(request pulsarSettleReq) bool {
+	return false
+}
+
+func (getter pulsarSettleAttrsGetter) GetConversationId(request pulsarSettleReq) string {
+	return ""
+}
+
+func (getter pulsarSettleAttrsGetter) GetMessageBodySize(request pulsarSettleReq) int64 {
+	return 0
+}
+
+func (getter pulsarSettleAttrsGetter) GetMessageEnvelopSize(request pulsarSettleReq) int64 {
+	return 0
+}
+
+func (getter pulsarSettleAttrsGetter) GetMessageId(request pulsarSettleReq, response any) string {
+	if id := request.msg.ID(); id != nil {
+		return id.String()
+	}
+	return ""
+}
+
+func (getter pulsarSettleAttrsGetter) GetClientId(request pulsarSettleReq) string {
+	return ""
+}
+
+func (getter pulsarSettleAttrsGetter) GetBatchMessageCount(request pulsarSettleReq, response any) int64 {
+	return 0
+}
+
+func (getter pulsarSettleAttrsGetter) GetMessageHeader(request pulsarSettleReq, name string) []string {
+	return []string{request.msg.Properties()[name]}
+}
+
+func (getter pulsarSettleAttrsGetter) GetDestinationPartitionId(request pulsarSettleReq) string {
+	return ""
+}
+
+// pulsarConsumerAttrsExtractor records the subscription the message was
+// received from
+type pulsarConsumerAttrsExtractor struct{}
+
+func (extractor *pulsarConsumerAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request pulsarConsumerReq) ([]attribute.KeyValue, context.Context) {
+	if request.subscription != "" {
+		attributes = append(attributes, semconv.MessagingDestinationSubscriptionName(request.subscription))
+	}
+	return attributes, parentContext
+}
+
+func (extractor *pulsarConsumerAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request pulsarConsumerReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+// pulsarSettleAttrsExtractor records how a message was acknowledged
+type pulsarSettleAttrsExtractor struct{}
+
+func (extractor *pulsarSettleAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request pulsarSettleReq) ([]attribute.KeyValue, context.Context) {
+	attributes = append(attributes,
+		semconv.MessagingOperationTypeSettle,
+		attribute.String("messaging.pulsar.message.ack_type", request.outcome),
+	)
+	if request.subscription != "" {
+		attributes = append(attributes, semconv.MessagingDestinationSubscriptionName(request.subscription))
+	}
+	return attributes, parentContext
+}
+
+func (extractor *pulsarSettleAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request pulsarSettleReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+// pulsarMsgContextCustomizer injects the receive span context into the
+// properties of the received message, so that the processing and the
+// acknowledgement of the message can continue the trace from it
+type pulsarMsgContextCustomizer struct{}
+
+func (customizer pulsarMsgContextCustomizer) OnStart(ctx context.Context, request pulsarConsumerReq, startAttributes []attribute.KeyValue) context.Context {
+	otel.GetTextMapPropagator().Inject(ctx, pulsarConsumerCarrier{msg: request.msg})
+	return ctx
+}
+
+// Build pulsar publish instrumenter, one span per sent message
+func buildPulsarPublishInstrumenter() instrumenter.Instrumenter[pulsarProducerReq, pulsar.MessageID] {
+	builder := instrumenter.Builder[pulsarProducerReq, pulsar.MessageID]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.PULSAR_PRODUCER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[pulsarProducerReq, pulsar.MessageID]{
+			Getter:        pulsarProducerAttrsGetter{},
+			OperationName: message.PUBLISH,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[pulsarProducerReq]{}).
+		SetSpanStatusExtractor(&pulsarStatusExtractor[pulsarProducerReq, pulsar.MessageID]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[pulsarProducerReq, pulsar.MessageID, pulsarProducerAttrsGetter]{
+			Operation: message.PUBLISH,
+		}).
+		BuildPropagatingToDownstreamInstrumenter(
+			func(request pulsarProducerReq) propagation.TextMapCarrier {
+				return pulsarProducerCarrier{msg: request.msg}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build pulsar receive instrumenter, one span per message handed out by
+// Receive or through Chan
+func buildPulsarReceiveInstrumenter() instrumenter.Instrumenter[pulsarConsumerReq, any] {
+	builder := instrumenter.Builder[pulsarConsumerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.PULSAR_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[pulsarConsumerReq, any]{
+			Getter:        pulsarConsumerAttrsGetter{},
+			OperationName: message.RECEIVE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[pulsarConsumerReq]{}).
+		SetSpanStatusExtractor(&pulsarStatusExtractor[pulsarConsumerReq, any]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[pulsarConsumerReq, any, pulsarConsumerAttrsGetter]{
+			Operation: message.RECEIVE,
+		}).
+		AddAttributesExtractor(&pulsarConsumerAttrsExtractor{}).
+		AddContextCustomizers(pulsarMsgContextCustomizer{}).
+		BuildPropagatingFromUpstreamInstrumenter(
+			func(request pulsarConsumerReq) propagation.TextMapCarrier {
+				return pulsarConsumerCarrier{msg: request.msg}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build pulsar settle instrumenter, one span per Ack or Nack of a message
+func buildPulsarSettleInstrumenter() instrumenter.Instrumenter[pulsarSettleReq, any] {
+	builder := instrumenter.Builder[pulsarSettleReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.PULSAR_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[pulsarSettleReq, any]{
+			Getter:        pulsarSettleAttrsGetter{},
+			OperationName: message.SETTLE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[pulsarSettleReq]{}).
+		SetSpanStatusExtractor(&pulsarStatusExtractor[pulsarSettleReq, any]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[pulsarSettleReq, any, pulsarSettleAttrsGetter]{
+			Operation: message.SETTLE,
+		}).
+		AddAttributesExtractor(&pulsarSettleAttrsExtractor{}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+// newProducerReq describes a message about to be sent by the unexported
+// producer implementation, which also serves partitioned topics
+func newProducerReq(p interface{}, msg *pulsar.ProducerMessage) pulsarProducerReq {
+	request := pulsarProducerReq{msg: msg}
+	if producer, ok := p.(pulsar.Producer); ok {
+		request.topic = producer.Topic()
+		request.producerName = producer.Name()
+	}
+	return request
+}
+
+//go:linkname producerSendOnEnter github.com/apache/pulsar-client-go/pulsar.producerSendOnEnter
+func producerSendOnEnter(call api.CallContext, p interface{}, ctx context.Context, msg *pulsar.ProducerMessage) {
+	if !pulsarEnabler.Enable() || msg == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	request := newProducerReq(p, msg)
+	ctx = publishInstrumenter.Start(ctx, request)
+	call.SetData(map[string]interface{}{
+		"ctx":     ctx,
+		"request": request,
+	})
+}
+
+//go:linkname producerSendOnExit github.com/apache/pulsar-client-go/pulsar.producerSendOnExit
+func producerSendOnExit(call api.CallContext, id pulsar.MessageID, err error) {
+	if !pulsarEnabler.Enable() {
+		return
+	}
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, ok := data["request"].(pulsarProducerReq)
+	if !ok {
+		return
+	}
+	publishInstrumenter.End(ctx, request, id, err)
+}
+
+// producerSendAsyncOnEnter starts a publish span which is ended once the
+// callback reports the outcome of the send
+//
+//go:linkname producerSendAsyncOnEnter github.com/apache/pulsar-client-go/pulsar.producerSendAsyncOnEnter
+func producerSendAsyncOnEnter(call api.CallContext, p interface{}, ctx context.Context, msg *pulsar.ProducerMessage,
+	callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+	if !pulsarEnabler.Enable() || msg == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	request := newProducerReq(p, msg)
+	ctx = publishInstrumenter.Start(ctx, request)
+	call.SetParam(3, func(id pulsar.MessageID, m *pulsar.ProducerMessage, err error) {
+		publishInstrumenter.End(ctx, request, id, err)
+		if callback != nil {
+			callback(id, m, err)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/apache/pulsar-client-go/pulsar"
+	"go.opentelemetry.io/otel"
+)
+
+// startSettle starts a settle span as a child of the receive span of the
+// message, whose context was injected into the message properties
+func startSettle(call api.CallContext, c interface{}, msg pulsar.Message, outcome string) {
+	if !pulsarEnabler.Enable() || msg == nil {
+		return
+	}
+	request := pulsarSettleReq{msg: msg, subscription: subscriptionOf(c), outcome: outcome}
+	parentCtx := otel.GetTextMapPropagator().Extract(context.Background(), pulsarConsumerCarrier{msg: msg})
+	ctx := settleInstrumenter.Start(parentCtx, request)
+	call.SetData(map[string]interface{}{
+		"ctx":     ctx,
+		"request": request,
+	})
+}
+
+func endSettle(call api.CallContext, err error) {
+	if !pulsarEnabler.Enable() {
+		return
+	}
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, ok := data["request"].(pulsarSettleReq)
+	if !ok {
+		return
+	}
+	settleInstrumenter.End(ctx, request, nil, err)
+}
+
+//go:linkname consumerAckOnEnter github.com/apache/pulsar-client-go/pulsar.consumerAckOnEnter
+func consumerAckOnEnter(call api.CallContext, c interface{}, msg pulsar.Message) {
+	startSettle(call, c, msg, "ack")
+}
+
+//go:linkname consumerAckOnExit github.com/apache/pulsar-client-go/pulsar.consumerAckOnExit
+func consumerAckOnExit(call api.CallContext, err error) {
+	endSettle(call, err)
+}
+
+//go:linkname consumerNackOnEnter github.com/apache/pulsar-client-go/pulsar.consumerNackOnEnter
+func consumerNackOnEnter(call api.CallContext, c interface{}, msg pulsar.Message) {
+	startSettle(call, c, msg, "nack")
+}
+
+//go:linkname consumerNackOnExit github.com/apache/pulsar-client-go/pulsar.consumerNackOnExit
+func consumerNackOnExit(call api.CallContext) {
+	endSettle(call, nil)
+}
+
+//go:linkname multiTopicConsumerAckOnEnter github.com/apache/pulsar-client-go/pulsar.multiTopicConsumerAckOnEnter
+func multiTopicConsumerAckOnEnter(call api.CallContext, c interface{}, msg pulsar.Message) {
+	startSettle(call, c, msg, "ack")
+}
+
+//go:linkname multiTopicConsumerAckOnExit github.com/apache/pulsar-client-go/pulsar.multiTopicConsumerAckOnExit
+func multiTopicConsumerAckOnExit(call api.CallContext, err error) {
+	endSettle(call, err)
+}
+
+//go:linkname multiTopicConsumerNackOnEnter github.com/apache/pulsar-client-go/pulsar.multiTopicConsumerNackOnEnter
+func multiTopicConsumerNackOnEnter(call api.CallContext, c interface{}, msg pulsar.Message) {
+	startSettle(call, c, msg, "nack")
+}
+
+//go:linkname multiTopicConsumerNackOnExit github.com/apache/pulsar-client-go/pulsar.multiTopicConsumerNackOnExit
+func multiTopicConsumerNackOnExit(call api.CallContext) {
+	endSettle(call, nil)
+}
+
+//go:linkname regexConsumerAckOnEnter github.com/apache/pulsar-client-go/pulsar.regexConsumerAckOnEnter
+func regexConsumerAckOnEnter(call api.CallContext, c interface{}, msg pulsar.Message) {
+	startSettle(call, c, msg, "ack")
+}
+
+//go:linkname regexConsumerAckOnExit github.com/apache/pulsar-client-go/pulsar.regexConsumerAckOnExit
+func regexConsumerAckOnExit(call api.CallContext, err error) {
+	endSettle(call, err)
+}
+
+//go:linkname regexConsumerNackOnEnter github.com/apache/pulsar-client-go/pulsar.regexConsumerNackOnEnter
+func regexConsumerNackOnEnter(call api.CallContext, c interface{}, msg pulsar.Message) {
+	startSettle(call, c, msg, "nack")
+}
+
+//go:linkname regexConsumerNackOnExit github.com/apache/pulsar-client-go/pulsar.regexConsumerNackOnExit
+func regexConsumerNackOnExit(call api.CallContext) {
+	endSettle(call, nil)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qdrant
+
+import (
+	"context"
+	"os"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/qdrant/go-client/qdrant"
+)
+
+var qdrantInstrumenter = BuildQdrantOtelInstrumenter()
+
+type qdrantInnerEnabler struct {
+	enabled bool
+}
+
+func (q qdrantInnerEnabler) Enable() bool {
+	return q.enabled
+}
+
+var qdrantEnabler = qdrantInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_QDRANT_ENABLED") != "false"}
+
+//go:linkname qdrantQueryOnEnter github.com/qdrant/go-client/qdrant.qdrantQueryOnEnter
+func qdrantQueryOnEnter(call api.CallContext, c *qdrant.Client, ctx context.Context, points *qdrant.QueryPoints) {
+	request := newQdrantRequest(c, "search", points.GetCollectionName())
+	request.topK = int(points.GetLimit())
+	request.search = true
+	qdrantStart(call, ctx, request)
+}
+
+//go:linkname qdrantQueryOnExit github.com/qdrant/go-client/qdrant.qdrantQueryOnExit
+func qdrantQueryOnExit(call api.CallContext, result []*qdrant.ScoredPoint, err error) {
+	qdrantEnd(call, err, func(request *qdrantRequest) {
+		request.returned = len(result)
+	})
+}
+
+//go:linkname qdrantUpsertOnEnter github.com/qdrant/go-client/qdrant.qdrantUpsertOnEnter
+func qdrantUpsertOnEnter(call api.CallContext, c *qdrant.Client, ctx context.Context, points *qdrant.UpsertPoints) {
+	request := newQdrantRequest(c, "upsert", points.GetCollectionName())
+	request.batchSize = len(points.GetPoints())
+	qdrantStart(call, ctx, request)
+}
+
+//go:linkname qdrantUpsertOnExit github.com/qdrant/go-client/qdrant.qdrantUpsertOnExit
+func qdrantUpsertOnExit(call api.CallContext, result *qdrant.UpdateResult, err error) {
+	qdrantEnd(call, err, nil)
+}
+
+//go:linkname qdrantDeleteOnEnter github.com/qdrant/go-client/qdrant.qdrantDeleteOnEnter
+func qdrantDeleteOnEnter(call api.CallContext, c *qdrant.Client, ctx context.Context, points *qdrant.DeletePoints) {
+	request := newQdrantRequest(c, "delete", points.GetCollectionName())
+	request.batchSize = len(points.GetPoints().GetPoints().GetIds())
+	qdrantStart(call, ctx, request)
+}
+
+//go:linkname qdrantDeleteOnExit github.com/qdrant/go-client/qdrant.qdrantDeleteOnExit
+func qdrantDeleteOnExit(call api.CallContext, result *qdrant.UpdateResult, err error) {
+	qdrantEnd(call, err, nil)
+}
+
+func newQdrantRequest(c *qdrant.Client, operation, collection string) *qdrantRequest {
+	request := &qdrantRequest{
+		operation:  operation,
+		collection: collection,
+	}
+	if c != nil && c.GetConnection() != nil {
+		request.address = c.GetConnection().Target()
+	}
+	return request
+}
+
+func qdrantStart(call api.CallContext, ctx context.Context, request *qdrantRequest) {
+	if !qdrantEnabler.Enable() {
+		return
+	}
+	newCtx := qdrantInstrumenter.Start(ctx, request)
+	// the grpc calls issued by the client are made within the qdrant span
+	call.SetParam(1, newCtx)
+	call.SetKeyData("ctx", newCtx)
+	call.SetKeyData("request", request)
+}
+
+func qdrantEnd(call api.CallContext, err error, onResult func(request *qdrantRequest)) {
+	if !qdrantEnabler.Enable() {
+		return
+	}
+	newCtx, ok := call.GetKeyData("ctx").(context.Context)
+	if !ok {
+		return
+	}
+	request := call.GetKeyData("request").(*qdrantRequest)
+	if err == nil && onResult != nil {
+		onResult(request)
+	}
+	qdrantInstrumenter.End(newCtx, request, nil, err)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qdrant
+
+import (
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+type qdrantAttrsGetter struct {
+}
+
+func (q qdrantAttrsGetter) GetSystem(request *qdrantRequest) string {
+	return "qdrant"
+}
+
+func (q qdrantAttrsGetter) GetServerAddress(request *qdrantRequest) string {
+	return request.address
+}
+
+func (q qdrantAttrsGetter) GetStatement(request *qdrantRequest) string {
+	return ""
+}
+
+func (q qdrantAttrsGetter) GetOperation(request *qdrantRequest) string {
+	return request.operation
+}
+
+func (q qdrantAttrsGetter) GetCollection(request *qdrantRequest) string {
+	return request.collection
+}
+
+func (q qdrantAttrsGetter) GetParameters(request *qdrantRequest) []any {
+	return nil
+}
+
+func (q qdrantAttrsGetter) GetDbNamespace(request *qdrantRequest) string {
+	return ""
+}
+
+func (q qdrantAttrsGetter) GetBatchSize(request *qdrantRequest) int {
+	return request.batchSize
+}
+
+func (q qdrantAttrsGetter) GetTopK(request *qdrantRequest) int {
+	return request.topK
+}
+
+func (q qdrantAttrsGetter) GetReturnedRows(request *qdrantRequest) (int, bool) {
+	return request.returned, request.search
+}
+
+func BuildQdrantOtelInstrumenter() instrumenter.Instrumenter[*qdrantRequest, any] {
+	builder := instrumenter.Builder[*qdrantRequest, any]{}
+	getter := qdrantAttrsGetter{}
+	return builder.Init().SetSpanNameExtractor(&db.DBSpanNameExtractor[*qdrantRequest]{Getter: getter}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[*qdrantRequest]{}).
+		AddOperationListeners(db.DbClientMetrics("vector.qdrant")).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.QDRANT_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		AddAttributesExtractor(&db.DbClientAttrsExtractor[*qdrantRequest, any, db.DbClientAttrsGetter[*qdrantRequest]]{Base: db.DbClientCommonAttrsExtractor[*qdrantRequest, any, db.DbClientAttrsGetter[*qdrantRequest]]{Getter: getter}}).
+		AddAttributesExtractor(&db.DbVectorAttrsExtractor[*qdrantRequest, any, db.DbVectorAttrsGetter[*qdrantRequest]]{Getter: getter}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rocketmq
+
+import (
+	"context"
+	"reflect"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// consumerClient reads the group, namespace and consumption model of the
+// unexported push and pull consumer implementations of the client, both
+// embed the same default consumer
+func consumerClient(c interface{}) rocketmqClient {
+	v := reflect.Indirect(reflect.ValueOf(c))
+	if v.Kind() != reflect.Struct {
+		return rocketmqClient{}
+	}
+	dc := v.FieldByName("defaultConsumer")
+	if dc.Kind() != reflect.Ptr || dc.IsNil() {
+		return rocketmqClient{}
+	}
+	dc = dc.Elem()
+	client := rocketmqClient{}
+	if option := dc.FieldByName("option"); option.IsValid() {
+		if namespace := option.FieldByName("Namespace"); namespace.Kind() == reflect.String {
+			client.namespace = namespace.String()
+		}
+	}
+	if group := dc.FieldByName("consumerGroup"); group.Kind() == reflect.String {
+		client.group = withoutNamespace(client.namespace, group.String())
+	}
+	if model := dc.FieldByName("model"); model.Kind() == reflect.Int {
+		client.model = consumer.MessageModel(model.Int()).String()
+	}
+	return client
+}
+
+// newConsumerReq describes messages consumed at once, all of them come from
+// the same topic
+func newConsumerReq(msgs []*primitive.MessageExt, client rocketmqClient) rocketmqConsumerReq {
+	return rocketmqConsumerReq{
+		msgs:        msgs,
+		destination: withoutNamespace(client.namespace, msgs[0].Topic),
+		client:      client,
+	}
+}
+
+// msgLinks links to the upstream context of every message
+func msgLinks(msgs []*primitive.MessageExt) []trace.Link {
+	links := make([]trace.Link, 0, len(msgs))
+	for _, msg := range msgs {
+		if link, ok := extractMsgLink(&msg.Message); ok {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// pushConsumerConsumeInnerOnEnter starts a process span around the callback
+// of a push consumer. A single message continues the trace of its producer,
+// a batch of messages links to all of them. The callback receives the
+// context of the process span.
+//
+//go:linkname pushConsumerConsumeInnerOnEnter github.com/apache/rocketmq-client-go/v2/consumer.pushConsumerConsumeInnerOnEnter
+func pushConsumerConsumeInnerOnEnter(call api.CallContext, pc interface{}, ctx context.Context, subMsgs []*primitive.MessageExt) {
+	if !rocketmqEnabler.Enable() || len(subMsgs) == 0 {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	request := newConsumerReq(subMsgs, consumerClient(pc))
+	var options []trace.SpanStartOption
+	if len(subMsgs) == 1 {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, rocketmqPropertyCarrier{msg: &subMsgs[0].Message})
+	} else {
+		options = append(options, trace.WithLinks(msgLinks(subMsgs)...))
+	}
+	ctx = processInstrumenter.Start(ctx, request, options...)
+	call.SetParam(1, ctx)
+	call.SetData(map[string]interface{}{
+		"ctx":     ctx,
+		"request": request,
+	})
+}
+
+//go:linkname pushConsumerConsumeInnerOnExit github.com/apache/rocketmq-client-go/v2/consumer.pushConsumerConsumeInnerOnExit
+func pushConsumerConsumeInnerOnExit(call api.CallContext, result consumer.ConsumeResult, err error) {
+	if !rocketmqEnabler.Enable() {
+		return
+	}
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	ctx, ok := data["ctx"].(context.Context)
+	if !ok {
+		return
+	}
+	request, ok := data["request"].(rocketmqConsumerReq)
+	if !ok {
+		return
+	}
+	processInstrumenter.End(ctx, request, result, err)
+}
+
+func pullOnEnter(call api.CallContext, pc interface{}) {
+	if !rocketmqEnabler.Enable() {
+		return
+	}
+	call.SetData(map[string]interface{}{
+		"client":         consumerClient(pc),
+		"startTimestamp": time.Now(),
+	})
+}
+
+// pullOnExit records a single receive span for the messages returned by a
+// pull consumer, the span links to the upstream context of every message.
+// Polls and pulls without a message are not recorded.
+func pullOnExit(call api.CallContext, msgs []*primitive.MessageExt, err error) {
+	if !rocketmqEnabler.Enable() || len(msgs) == 0 {
+		return
+	}
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	client, _ := data["client"].(rocketmqClient)
+	startTimestamp, ok := data["startTimestamp"].(time.Time)
+	if !ok {
+		return
+	}
+	receiveInstrumenter.StartAndEndWithOptions(context.Background(), newConsumerReq(msgs, client), nil, err,
+		startTimestamp, time.Now(), []trace.SpanStartOption{trace.WithLinks(msgLinks(msgs)...)}, nil)
+}
+
+//go:linkname pullConsumerPollOnEnter github.com/apache/rocketmq-client-go/v2/consumer.pullConsumerPollOnEnter
+func pullConsumerPollOnEnter(call api.CallContext, pc interface{}, ctx context.Context, timeout time.Duration) {
+	pullOnEnter(call, pc)
+}
+
+//go:linkname pullConsumerPollOnExit github.com/apache/rocketmq-client-go/v2/consumer.pullConsumerPollOnExit
+func pullConsumerPollOnExit(call api.CallContext, cr *consumer.ConsumeRequest, err error) {
+	if cr == nil {
+		return
+	}
+	pullOnExit(call, cr.GetMsgList(), err)
+}
+
+//go:linkname pullConsumerPullOnEnter github.com/apache/rocketmq-client-go/v2/consumer.pullConsumerPullOnEnter
+func pullConsumerPullOnEnter(call api.CallContext, pc interface{}, ctx context.Context, numbers int) {
+	pullOnEnter(call, pc)
+}
+
+//go:linkname pullConsumerPullOnExit github.com/apache/rocketmq-client-go/v2/consumer.pullConsumerPullOnExit
+func pullConsumerPullOnExit(call api.CallContext, result *primitive.PullResult, err error) {
+	if result == nil {
+		return
+	}
+	pullOnExit(call, result.GetMessageExts(), err)
+}
+
+//go:linkname pullConsumerPullFromOnEnter github.com/apache/rocketmq-client-go/v2/consumer.pullConsumerPullFromOnEnter
+func pullConsumerPullFromOnEnter(call api.CallContext, pc interface{}, ctx context.Context,
+	queue *primitive.MessageQueue, offset int64, numbers int) {
+	pullOnEnter(call, pc)
+}
+
+//go:linkname pullConsumerPullFromOnExit github.com/apache/rocketmq-client-go/v2/consumer.pullConsumerPullFromOnExit
+func pullConsumerPullFromOnExit(call api.CallContext, result *primitive.PullResult, err error) {
+	if result == nil {
+		return
+	}
+	pullOnExit(call, result.GetMessageExts(), err)
+}
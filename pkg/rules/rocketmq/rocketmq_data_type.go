@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rocketmq
+
+import (
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+)
+
+// rocketmqClient describes the producer or consumer that sends or receives
+// the messages. The group is the one configured by the application, without
+// the namespace prefix added by the client.
+type rocketmqClient struct {
+	group     string
+	namespace string
+	// model is the consumption model of consumers, empty for producers
+	model string
+}
+
+// rocketmqProducerReq describes all messages sent by one SendSync, SendAsync
+// or SendOneWay call, more than one message are sent as a batch
+type rocketmqProducerReq struct {
+	msgs   []*primitive.Message
+	client rocketmqClient
+}
+
+// rocketmqConsumerReq describes the messages handed to a push consumer
+// callback, or returned by one poll or pull of a pull consumer
+type rocketmqConsumerReq struct {
+	msgs        []*primitive.MessageExt
+	destination string
+	client      rocketmqClient
+}
@@ -0,0 +1,449 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rocketmq
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const rocketmqSystem = "rocketmq"
+
+// messagingRocketmqClientGroupKey is the name of the producer or consumer
+// group, it is no longer part of the semantic conventions v1.30.0 but still
+// the way RocketMQ users look up their clients
+const messagingRocketmqClientGroupKey = attribute.Key("messaging.rocketmq.client_group")
+
+// Instrumentation enabler controller
+var rocketmqEnabler = rocketmqInnerEnabler{os.Getenv("OTEL_ROCKETMQ_ENABLED") != "false"}
+
+// Cache Instrumenter instances to avoid repeated creation
+var (
+	publishInstrumenter = buildRocketMQPublishInstrumenter()
+	receiveInstrumenter = buildRocketMQReceiveInstrumenter()
+	processInstrumenter = buildRocketMQProcessInstrumenter()
+)
+
+type rocketmqInnerEnabler struct {
+	enabled bool
+}
+
+func (r rocketmqInnerEnabler) Enable() bool {
+	return r.enabled
+}
+
+// rocketmqPropertyCarrier implements OpenTelemetry propagator carrier
+// interface on top of the user properties of a message
+type rocketmqPropertyCarrier struct {
+	msg *primitive.Message
+}
+
+func (carrier rocketmqPropertyCarrier) Get(key string) string {
+	return carrier.msg.GetProperty(key)
+}
+
+func (carrier rocketmqPropertyCarrier) Set(key, value string) {
+	carrier.msg.WithProperty(key, value)
+}
+
+func (carrier rocketmqPropertyCarrier) Keys() []string {
+	properties := carrier.msg.GetProperties()
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// extractMsgLink builds a span link pointing to the upstream context that the
+// producer injected into the message properties
+func extractMsgLink(msg *primitive.Message) (trace.Link, bool) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(),
+		rocketmqPropertyCarrier{msg: msg})
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return trace.Link{}, false
+	}
+	return trace.Link{SpanContext: spanContext}, true
+}
+
+// withoutNamespace strips the namespace prefix the client adds to topics and
+// groups
+func withoutNamespace(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return strings.TrimPrefix(name, namespace+"%")
+}
+
+// messageType tells the RocketMQ message type from the system properties set
+// by the application
+func messageType(msg *primitive.Message) attribute.KeyValue {
+	switch {
+	case msg.GetProperty(primitive.PropertyTransactionPrepared) == "true":
+		return semconv.MessagingRocketmqMessageTypeTransaction
+	case msg.GetProperty(primitive.PropertyDelayTimeLevel) != "":
+		return semconv.MessagingRocketmqMessageTypeDelay
+	case msg.GetProperty(primitive.PropertyShardingKey) != "":
+		return semconv.MessagingRocketmqMessageTypeFifo
+	default:
+		return semconv.MessagingRocketmqMessageTypeNormal
+	}
+}
+
+// messageAttrs returns the attributes describing a single message, they are
+// only recorded when the span covers exactly one message
+func messageAttrs(attributes []attribute.KeyValue, msg *primitive.Message) []attribute.KeyValue {
+	if tag := msg.GetTags(); tag != "" {
+		attributes = append(attributes, semconv.MessagingRocketmqMessageTag(tag))
+	}
+	if keys := msg.GetKeys(); keys != "" {
+		attributes = append(attributes,
+			semconv.MessagingRocketmqMessageKeys(strings.Split(keys, primitive.PropertyKeySeparator)...))
+	}
+	if level, err := strconv.Atoi(msg.GetProperty(primitive.PropertyDelayTimeLevel)); err == nil {
+		attributes = append(attributes, semconv.MessagingRocketmqMessageDelayTimeLevel(level))
+	}
+	return append(attributes, messageType(msg))
+}
+
+// clientAttrs returns the attributes describing the producer or consumer
+func clientAttrs(attributes []attribute.KeyValue, client rocketmqClient) []attribute.KeyValue {
+	if client.namespace != "" {
+		attributes = append(attributes, semconv.MessagingRocketmqNamespace(client.namespace))
+	}
+	if client.group != "" {
+		attributes = append(attributes, messagingRocketmqClientGroupKey.String(client.group))
+	}
+	return attributes
+}
+
+// rocketmqProducerStatusExtractor extracts operation status for producer
+// spans, a send is successful only when the broker stored the message
+type rocketmqProducerStatusExtractor struct{}
+
+func (extractor *rocketmqProducerStatusExtractor) Extract(span trace.Span, request rocketmqProducerReq, response *primitive.SendResult, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	} else if response != nil && response.Status != primitive.SendOK {
+		span.SetStatus(codes.Error, fmt.Sprintf("send status: %d", response.Status))
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+}
+
+// rocketmqConsumerStatusExtractor extracts operation status for consumer
+// spans, a process span fails when the callback asks for redelivery
+type rocketmqConsumerStatusExtractor struct{}
+
+func (extractor *rocketmqConsumerStatusExtractor) Extract(span trace.Span, request rocketmqConsumerReq, response any, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	if result, ok := response.(consumer.ConsumeResult); ok && result != consumer.ConsumeSuccess {
+		span.SetStatus(codes.Error, fmt.Sprintf("consume result: %d", result))
+		return
+	}
+	span.SetStatus(codes.Ok, "")
+}
+
+// rocketmqProducerAttrsGetter retrieves attributes of the messages sent by
+// one producer call
+type rocketmqProducerAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[rocketmqProducerReq, *primitive.SendResult] = rocketmqProducerAttrsGetter{}
+
+func (getter rocketmqProducerAttrsGetter) GetSystem(request rocketmqProducerReq) string {
+	return rocketmqSystem
+}
+
+func (getter rocketmqProducerAttrsGetter) GetDestination(request rocketmqProducerReq) string {
+	// All messages of a batch share the same topic
+	return request.msgs[0].Topic
+}
+
+func (getter rocketmqProducerAttrsGetter) GetDestinationTemplate(request rocketmqProducerReq) string {
+	return ""
+}
+
+func (getter rocketmqProducerAttrsGetter) IsTemporaryDestination(request rocketmqProducerReq) bool {
+	return false
+}
+
+func (getter rocketmqProducerAttrsGetter) IsAnonymousDestination(request rocketmqProducerReq) bool {
+	return false
+}
+
+func (getter rocketmqProducerAttrsGetter) GetConversationId(request rocketmqProducerReq) string {
+	return ""
+}
+
+func (getter rocketmqProducerAttrsGetter) GetMessageBodySize(request rocketmqProducerReq) int64 {
+	var size int64
+	for _, msg := range request.msgs {
+		size += int64(len(msg.Body))
+	}
+	return size
+}
+
+func (getter rocketmqProducerAttrsGetter) GetMessageEnvelopSize(request rocketmqProducerReq) int64 {
+	return 0
+}
+
+func (getter rocketmqProducerAttrsGetter) GetMessageId(request rocketmqProducerReq, response *primitive.SendResult) string {
+	if response == nil {
+		return ""
+	}
+	return response.MsgID
+}
+
+func (getter rocketmqProducerAttrsGetter) GetClientId(request rocketmqProducerReq) string {
+	return ""
+}
+
+func (getter rocketmqProducerAttrsGetter) GetBatchMessageCount(request rocketmqProducerReq, response *primitive.SendResult) int64 {
+	return int64(len(request.msgs))
+}
+
+func (getter rocketmqProducerAttrsGetter) GetMessageHeader(request rocketmqProducerReq, name string) []string {
+	if len(request.msgs) != 1 {
+		return []string{}
+	}
+	return []string{request.msgs[0].GetProperty(name)}
+}
+
+func (getter rocketmqProducerAttrsGetter) GetDestinationPartitionId(request rocketmqProducerReq) string {
+	return ""
+}
+
+// rocketmqConsumerAttrsGetter retrieves attributes of the messages consumed
+// at once
+type rocketmqConsumerAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[rocketmqConsumerReq, any] = rocketmqConsumerAttrsGetter{}
+
+func (getter rocketmqConsumerAttrsGetter) GetSystem(request rocketmqConsumerReq) string {
+	return rocketmqSystem
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetDestination(request rocketmqConsumerReq) string {
+	return request.destination
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetDestinationTemplate(request rocketmqConsumerReq) string {
+	return ""
+}
+
+func (getter rocketmqConsumerAttrsGetter) IsTemporaryDestination(request rocketmqConsumerReq) bool {
+	return false
+}
+
+func (getter rocketmqConsumerAttrsGetter) IsAnonymousDestination(request rocketmqConsumerReq) bool {
+	return false
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetConversationId(request rocketmqConsumerReq) string {
+	return ""
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetMessageBodySize(request rocketmqConsumerReq) int64 {
+	var size int64
+	for _, msg := range request.msgs {
+		size += int64(len(msg.Body))
+	}
+	return size
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetMessageEnvelopSize(request rocketmqConsumerReq) int64 {
+	return 0
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetMessageId(request rocketmqConsumerReq, response any) string {
+	if len(request.msgs) != 1 {
+		return ""
+	}
+	return request.msgs[0].MsgId
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetClientId(request rocketmqConsumerReq) string {
+	return ""
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetBatchMessageCount(request rocketmqConsumerReq, response any) int64 {
+	return int64(len(request.msgs))
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetMessageHeader(request rocketmqConsumerReq, name string) []string {
+	if len(request.msgs) != 1 {
+		return []string{}
+	}
+	return []string{request.msgs[0].GetProperty(name)}
+}
+
+func (getter rocketmqConsumerAttrsGetter) GetDestinationPartitionId(request rocketmqConsumerReq) string {
+	if len(request.msgs) != 1 || request.msgs[0].Queue == nil {
+		return ""
+	}
+	return strconv.Itoa(request.msgs[0].Queue.QueueId)
+}
+
+// rocketmqProducerAttrsExtractor extracts the rocketmq specific producer
+// attributes
+type rocketmqProducerAttrsExtractor struct{}
+
+func (extractor *rocketmqProducerAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request rocketmqProducerReq) ([]attribute.KeyValue, context.Context) {
+	attributes = clientAttrs(attributes, request.client)
+	if len(request.msgs) == 1 {
+		attributes = messageAttrs(attributes, request.msgs[0])
+	}
+	return attributes, parentContext
+}
+
+func (extractor *rocketmqProducerAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request rocketmqProducerReq, response *primitive.SendResult, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+// rocketmqConsumerAttrsExtractor extracts the rocketmq specific consumer
+// attributes
+type rocketmqConsumerAttrsExtractor struct{}
+
+func (extractor *rocketmqConsumerAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request rocketmqConsumerReq) ([]attribute.KeyValue, context.Context) {
+	attributes = clientAttrs(attributes, request.client)
+	if request.client.group != "" {
+		attributes = append(attributes, semconv.MessagingConsumerGroupName(request.client.group))
+	}
+	switch request.client.model {
+	case consumer.Clustering.String():
+		attributes = append(attributes, semconv.MessagingRocketmqConsumptionModelClustering)
+	case consumer.BroadCasting.String():
+		attributes = append(attributes, semconv.MessagingRocketmqConsumptionModelBroadcasting)
+	}
+	if len(request.msgs) == 1 {
+		attributes = messageAttrs(attributes, &request.msgs[0].Message)
+	}
+	return attributes, parentContext
+}
+
+func (extractor *rocketmqConsumerAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request rocketmqConsumerReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+// rocketmqPublishContextCustomizer injects the publish span context into the
+// properties of every sent message, a batch is encoded after the injection so
+// every message of it carries the context
+type rocketmqPublishContextCustomizer struct{}
+
+func (customizer rocketmqPublishContextCustomizer) OnStart(ctx context.Context, request rocketmqProducerReq, startAttributes []attribute.KeyValue) context.Context {
+	for _, msg := range request.msgs {
+		otel.GetTextMapPropagator().Inject(ctx, rocketmqPropertyCarrier{msg: msg})
+	}
+	return ctx
+}
+
+// rocketmqReceiveContextCustomizer injects the receive span context into the
+// properties of every received message, so that the processing of the
+// messages can continue the trace from them
+type rocketmqReceiveContextCustomizer struct{}
+
+func (customizer rocketmqReceiveContextCustomizer) OnStart(ctx context.Context, request rocketmqConsumerReq, startAttributes []attribute.KeyValue) context.Context {
+	for _, msg := range request.msgs {
+		otel.GetTextMapPropagator().Inject(ctx, rocketmqPropertyCarrier{msg: &msg.Message})
+	}
+	return ctx
+}
+
+// Build rocketmq publish instrumenter, one span per producer call
+func buildRocketMQPublishInstrumenter() instrumenter.Instrumenter[rocketmqProducerReq, *primitive.SendResult] {
+	builder := instrumenter.Builder[rocketmqProducerReq, *primitive.SendResult]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.ROCKETMQ_PRODUCER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[rocketmqProducerReq, *primitive.SendResult]{
+			Getter:        rocketmqProducerAttrsGetter{},
+			OperationName: message.PUBLISH,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[rocketmqProducerReq]{}).
+		SetSpanStatusExtractor(&rocketmqProducerStatusExtractor{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[rocketmqProducerReq, *primitive.SendResult, rocketmqProducerAttrsGetter]{
+			Operation: message.PUBLISH,
+		}).
+		AddAttributesExtractor(&rocketmqProducerAttrsExtractor{}).
+		AddContextCustomizers(rocketmqPublishContextCustomizer{}).
+		BuildInstrumenter()
+}
+
+// Build rocketmq receive instrumenter, one span per poll or pull of a pull
+// consumer which links to the upstream context of every received message
+func buildRocketMQReceiveInstrumenter() instrumenter.Instrumenter[rocketmqConsumerReq, any] {
+	builder := instrumenter.Builder[rocketmqConsumerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.ROCKETMQ_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[rocketmqConsumerReq, any]{
+			Getter:        rocketmqConsumerAttrsGetter{},
+			OperationName: message.RECEIVE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[rocketmqConsumerReq]{}).
+		SetSpanStatusExtractor(&rocketmqConsumerStatusExtractor{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[rocketmqConsumerReq, any, rocketmqConsumerAttrsGetter]{
+			Operation: message.RECEIVE,
+		}).
+		AddAttributesExtractor(&rocketmqConsumerAttrsExtractor{}).
+		AddContextCustomizers(rocketmqReceiveContextCustomizer{}).
+		BuildInstrumenter()
+}
+
+// Build rocketmq process instrumenter, one span per call of a push consumer
+// callback
+func buildRocketMQProcessInstrumenter() instrumenter.Instrumenter[rocketmqConsumerReq, any] {
+	builder := instrumenter.Builder[rocketmqConsumerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.ROCKETMQ_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[rocketmqConsumerReq, any]{
+			Getter:        rocketmqConsumerAttrsGetter{},
+			OperationName: message.PROCESS,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[rocketmqConsumerReq]{}).
+		SetSpanStatusExtractor(&rocketmqConsumerStatusExtractor{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[rocketmqConsumerReq, any, rocketmqConsumerAttrsGetter]{
+			Operation: message.PROCESS,
+		}).
+		AddAttributesExtractor(&rocketmqConsumerAttrsExtractor{}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rocketmq
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+)
+
+// producerClient reads the group and namespace of the unexported producer
+// implementation of the client
+func producerClient(p interface{}) rocketmqClient {
+	v := reflect.Indirect(reflect.ValueOf(p))
+	if v.Kind() != reflect.Struct {
+		return rocketmqClient{}
+	}
+	client := rocketmqClient{}
+	if options := v.FieldByName("options"); options.IsValid() {
+		if namespace := options.FieldByName("Namespace"); namespace.Kind() == reflect.String {
+			client.namespace = namespace.String()
+		}
+	}
+	if group := v.FieldByName("group"); group.Kind() == reflect.String {
+		client.group = withoutNamespace(client.namespace, group.String())
+	}
+	return client
+}
+
+// startPublish starts a publish span for the messages of one producer call
+// and returns the function ending it. The function may be called more than
+// once, the client reports some failures of asynchronous sends twice.
+func startPublish(ctx context.Context, p interface{}, msgs []*primitive.Message) func(*primitive.SendResult, error) {
+	if !rocketmqEnabler.Enable() || len(msgs) == 0 {
+		return nil
+	}
+	for _, msg := range msgs {
+		if msg == nil {
+			return nil
+		}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	request := rocketmqProducerReq{msgs: msgs, client: producerClient(p)}
+	ctx = publishInstrumenter.Start(ctx, request)
+	var once sync.Once
+	return func(result *primitive.SendResult, err error) {
+		once.Do(func() {
+			publishInstrumenter.End(ctx, request, result, err)
+		})
+	}
+}
+
+//go:linkname producerSendSyncOnEnter github.com/apache/rocketmq-client-go/v2/producer.producerSendSyncOnEnter
+func producerSendSyncOnEnter(call api.CallContext, p interface{}, ctx context.Context, msgs ...*primitive.Message) {
+	if end := startPublish(ctx, p, msgs); end != nil {
+		call.SetData(end)
+	}
+}
+
+//go:linkname producerSendSyncOnExit github.com/apache/rocketmq-client-go/v2/producer.producerSendSyncOnExit
+func producerSendSyncOnExit(call api.CallContext, result *primitive.SendResult, err error) {
+	if end, ok := call.GetData().(func(*primitive.SendResult, error)); ok {
+		end(result, err)
+	}
+}
+
+// producerSendAsyncOnEnter starts a publish span which is ended by the
+// callback of the application once the broker answered
+//
+//go:linkname producerSendAsyncOnEnter github.com/apache/rocketmq-client-go/v2/producer.producerSendAsyncOnEnter
+func producerSendAsyncOnEnter(call api.CallContext, p interface{}, ctx context.Context,
+	f func(context.Context, *primitive.SendResult, error), msgs ...*primitive.Message) {
+	end := startPublish(ctx, p, msgs)
+	if end == nil {
+		return
+	}
+	call.SetParam(2, func(ctx context.Context, result *primitive.SendResult, err error) {
+		end(result, err)
+		if f != nil {
+			f(ctx, result, err)
+		}
+	})
+	call.SetData(end)
+}
+
+// producerSendAsyncOnExit ends the publish span when the message could not
+// even be handed to the broker, the callback is never called in that case
+//
+//go:linkname producerSendAsyncOnExit github.com/apache/rocketmq-client-go/v2/producer.producerSendAsyncOnExit
+func producerSendAsyncOnExit(call api.CallContext, err error) {
+	if err == nil {
+		return
+	}
+	if end, ok := call.GetData().(func(*primitive.SendResult, error)); ok {
+		end(nil, err)
+	}
+}
+
+//go:linkname producerSendOneWayOnEnter github.com/apache/rocketmq-client-go/v2/producer.producerSendOneWayOnEnter
+func producerSendOneWayOnEnter(call api.CallContext, p interface{}, ctx context.Context, msgs ...*primitive.Message) {
+	if end := startPublish(ctx, p, msgs); end != nil {
+		call.SetData(end)
+	}
+}
+
+//go:linkname producerSendOneWayOnExit github.com/apache/rocketmq-client-go/v2/producer.producerSendOneWayOnExit
+func producerSendOneWayOnExit(call api.CallContext, err error) {
+	if end, ok := call.GetData().(func(*primitive.SendResult, error)); ok {
+		end(nil, err)
+	}
+}
@@ -18,10 +18,20 @@ import (
 	"context"
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
 	"time"
 	_ "unsafe"
 )
 
+// kafkaReadMessageKey marks the context passed down from ReadMessage, which
+// fetches and commits the message itself. The nested FetchMessage and
+// CommitMessages calls are already covered by the ReadMessage span.
+type kafkaReadMessageKey struct{}
+
+func insideReadMessage(ctx context.Context) bool {
+	return ctx != nil && ctx.Value(kafkaReadMessageKey{}) != nil
+}
+
 //go:linkname consumerReadMessageOnEnter github.com/segmentio/kafka-go.consumerReadMessageOnEnter
 func consumerReadMessageOnEnter(call api.CallContext, _ interface{}, ctx context.Context) {
 	if !kafkaEnabler.Enable() {
@@ -33,6 +43,7 @@ func consumerReadMessageOnEnter(call api.CallContext, _ interface{}, ctx context
 		"startTimestamp": time.Now(),
 	}
 	call.SetData(instrumentationData)
+	call.SetParam(1, context.WithValue(ctx, kafkaReadMessageKey{}, true))
 }
 
 //go:linkname consumerReadMessageOnExit github.com/segmentio/kafka-go.consumerReadMessageOnExit
@@ -60,3 +71,88 @@ func consumerReadMessageOnExit(call api.CallContext, message kafka.Message, err
 		endTimestamp,
 	)
 }
+
+//go:linkname consumerFetchMessageOnEnter github.com/segmentio/kafka-go.consumerFetchMessageOnEnter
+func consumerFetchMessageOnEnter(call api.CallContext, _ interface{}, ctx context.Context) {
+	if !kafkaEnabler.Enable() || insideReadMessage(ctx) {
+		return
+	}
+
+	instrumentationData := map[string]interface{}{
+		"parentContext":  ctx,
+		"startTimestamp": time.Now(),
+	}
+	call.SetData(instrumentationData)
+}
+
+//go:linkname consumerFetchMessageOnExit github.com/segmentio/kafka-go.consumerFetchMessageOnExit
+func consumerFetchMessageOnExit(call api.CallContext, message kafka.Message, err error) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+
+	instrumentationData, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	parentContext := instrumentationData["parentContext"].(context.Context)
+	startTimestamp := instrumentationData["startTimestamp"].(time.Time)
+	endTimestamp := time.Now()
+
+	consumerRequest := kafkaConsumerReq{msg: message}
+	receiveInstrumenter.StartAndEnd(
+		parentContext,
+		consumerRequest,
+		nil,
+		err,
+		startTimestamp,
+		endTimestamp,
+	)
+}
+
+//go:linkname consumerCommitMessagesOnEnter github.com/segmentio/kafka-go.consumerCommitMessagesOnEnter
+func consumerCommitMessagesOnEnter(call api.CallContext, reader *kafka.Reader, ctx context.Context, messages ...kafka.Message) {
+	if !kafkaEnabler.Enable() || insideReadMessage(ctx) || len(messages) == 0 {
+		return
+	}
+
+	commitRequest := kafkaCommitReq{
+		msgs:    messages,
+		topic:   messages[0].Topic,
+		groupId: reader.Config().GroupID,
+	}
+	// The commit span links to every committed message
+	links := make([]trace.Link, 0, len(messages))
+	for _, message := range messages {
+		if message.Topic != commitRequest.topic {
+			commitRequest.topic = ""
+		}
+		if link, ok := extractMessageLink(message); ok {
+			links = append(links, link)
+		}
+	}
+	instrumentedContext := commitInstrumenter.Start(ctx, commitRequest, trace.WithLinks(links...))
+
+	instrumentationData := map[string]interface{}{
+		"instrumentedContext": instrumentedContext,
+		"commitRequest":       commitRequest,
+	}
+	call.SetData(instrumentationData)
+}
+
+//go:linkname consumerCommitMessagesOnExit github.com/segmentio/kafka-go.consumerCommitMessagesOnExit
+func consumerCommitMessagesOnExit(call api.CallContext, err error) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+
+	instrumentationData, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	instrumentedContext := instrumentationData["instrumentedContext"].(context.Context)
+	commitRequest := instrumentationData["commitRequest"].(kafkaCommitReq)
+
+	commitInstrumenter.End(instrumentedContext, commitRequest, nil, err)
+}
@@ -29,3 +29,9 @@ type kafkaProducerReq struct {
 type kafkaConsumerReq struct {
 	msg kafka.Message
 }
+
+type kafkaCommitReq struct {
+	msgs    []kafka.Message
+	topic   string
+	groupId string
+}
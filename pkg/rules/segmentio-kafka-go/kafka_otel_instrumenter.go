@@ -29,6 +29,7 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
 	"go.opentelemetry.io/otel/trace"
 	"os"
+	"strconv"
 )
 
 // Instrumentation enabler controller
@@ -36,8 +37,12 @@ var kafkaEnabler = kafkaInnerEnabler{os.Getenv("OTEL_SEGMENTIO_KAFKA_ENABLED") !
 
 // Cache Instrumenter instances to avoid repeated creation
 var (
-	producerInstrumenter = buildKafkaProducerInstrumenter()
-	consumerInstrumenter = buildKafkaConsumerInstrumenter()
+	producerInstrumenter      = buildKafkaProducerInstrumenter()
+	batchProducerInstrumenter = buildKafkaBatchProducerInstrumenter()
+	createInstrumenter        = buildKafkaCreateInstrumenter()
+	consumerInstrumenter      = buildKafkaConsumerInstrumenter()
+	receiveInstrumenter       = buildKafkaReceiveInstrumenter()
+	commitInstrumenter        = buildKafkaCommitInstrumenter()
 )
 
 type kafkaInnerEnabler struct {
@@ -59,13 +64,29 @@ func (carrier kafkaProducerCarrier) Get(key string) string {
 
 func (carrier kafkaProducerCarrier) Set(key, value string) {
 	for _, message := range carrier.messages {
-		message.Headers = append(message.Headers, kafka.Header{
-			Key:   key,
-			Value: []byte(value),
-		})
+		setHeader(message, key, value)
 	}
 }
 
+// setHeader overwrites the existing header so that re-producing a consumed
+// message does not carry the upstream context along with the new one
+func setHeader(message *kafka.Message, key, value string) {
+	for i, header := range message.Headers {
+		if header.Key == key {
+			// Headers may be shared with the caller's message, copy before writing
+			headers := make([]kafka.Header, len(message.Headers))
+			copy(headers, message.Headers)
+			headers[i].Value = []byte(value)
+			message.Headers = headers
+			return
+		}
+	}
+	message.Headers = append(message.Headers, kafka.Header{
+		Key:   key,
+		Value: []byte(value),
+	})
+}
+
 func (carrier kafkaProducerCarrier) Keys() []string {
 	return []string{}
 }
@@ -94,6 +115,24 @@ func (carrier kafkaConsumerCarrier) Keys() []string {
 	return []string{}
 }
 
+// extractMessageLink builds a span link pointing to the upstream context that
+// the producer injected into the message headers
+func extractMessageLink(message kafka.Message) (trace.Link, bool) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(),
+		kafkaConsumerCarrier{message: message})
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return trace.Link{}, false
+	}
+	return trace.Link{
+		SpanContext: spanContext,
+		Attributes: []attribute.KeyValue{
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(message.Partition)),
+			semconv.MessagingKafkaOffset(int(message.Offset)),
+		},
+	}, true
+}
+
 // KafkaProducerStatusExtractor extracts producer operation status
 type kafkaProducerStatusExtractor struct {
 }
@@ -245,6 +284,72 @@ func (getter kafkaMessageConsumerAttrsGetter) GetMessageHeader(request kafkaCons
 	return headerValues
 }
 
+// kafkaCommitStatusExtractor extracts commit operation status
+type kafkaCommitStatusExtractor struct{}
+
+func (extractor *kafkaCommitStatusExtractor) Extract(span trace.Span, request kafkaCommitReq, response any, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+}
+
+// kafkaMessageCommitAttrsGetter retrieves attributes of committed messages
+type kafkaMessageCommitAttrsGetter struct{}
+
+func (getter kafkaMessageCommitAttrsGetter) IsAnonymousDestination(request kafkaCommitReq) bool {
+	return false
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetDestinationPartitionId(request kafkaCommitReq) string {
+	return ""
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetSystem(request kafkaCommitReq) string {
+	return "kafka"
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetDestination(request kafkaCommitReq) string {
+	return request.topic
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetDestinationTemplate(request kafkaCommitReq) string {
+	return ""
+}
+
+func (getter kafkaMessageCommitAttrsGetter) IsTemporaryDestination(request kafkaCommitReq) bool {
+	return false
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetConversationId(request kafkaCommitReq) string {
+	return ""
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetMessageBodySize(request kafkaCommitReq) int64 {
+	return 0
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetMessageEnvelopSize(request kafkaCommitReq) int64 {
+	return 0
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetMessageId(request kafkaCommitReq, response any) string {
+	return ""
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetClientId(request kafkaCommitReq) string {
+	return ""
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetBatchMessageCount(request kafkaCommitReq, response any) int64 {
+	return int64(len(request.msgs))
+}
+
+func (getter kafkaMessageCommitAttrsGetter) GetMessageHeader(request kafkaCommitReq, name string) []string {
+	return []string{}
+}
+
 // KafkaProducerAttributesExtractor extracts producer attributes
 type kafkaProducerAttributesExtractor struct {
 }
@@ -321,3 +426,116 @@ func buildKafkaConsumerInstrumenter() instrumenter.Instrumenter[kafkaConsumerReq
 			otel.GetTextMapPropagator(),
 		)
 }
+
+// kafkaCommitAttributesExtractor extracts commit attributes
+type kafkaCommitAttributesExtractor struct {
+}
+
+func (extractor *kafkaCommitAttributesExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request kafkaCommitReq) ([]attribute.KeyValue, context.Context) {
+	attributes = append(attributes, semconv.MessagingOperationTypeSettle)
+	if request.groupId != "" {
+		attributes = append(attributes, semconv.MessagingConsumerGroupName(request.groupId))
+	}
+	return attributes, parentContext
+}
+
+func (extractor *kafkaCommitAttributesExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request kafkaCommitReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+// Build Kafka batch producer instrumenter, the publish span of a batch does not
+// propagate its own context, every message carries the context of its create
+// span instead and the publish span links to all of them
+func buildKafkaBatchProducerInstrumenter() instrumenter.Instrumenter[kafkaProducerReq, any] {
+	builder := instrumenter.Builder[kafkaProducerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.KAFKAGO_PRODUCER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[kafkaProducerReq, any]{
+			Getter:        kafkaMessageProducerAttrsGetter{},
+			OperationName: message.PUBLISH,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[kafkaProducerReq]{}).
+		SetSpanStatusExtractor(&kafkaProducerStatusExtractor{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[kafkaProducerReq, any, kafkaMessageProducerAttrsGetter]{
+			Operation: message.PUBLISH,
+		}).
+		AddAttributesExtractor(&kafkaProducerAttributesExtractor{}).
+		BuildInstrumenter()
+}
+
+// Build Kafka create instrumenter, one span per message of a batch
+func buildKafkaCreateInstrumenter() instrumenter.Instrumenter[kafkaProducerReq, any] {
+	builder := instrumenter.Builder[kafkaProducerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.KAFKAGO_PRODUCER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[kafkaProducerReq, any]{
+			Getter:        kafkaMessageProducerAttrsGetter{},
+			OperationName: message.CREATE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[kafkaProducerReq]{}).
+		SetSpanStatusExtractor(&kafkaProducerStatusExtractor{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[kafkaProducerReq, any, kafkaMessageProducerAttrsGetter]{
+			Operation: message.CREATE,
+		}).
+		BuildPropagatingToDownstreamInstrumenter(
+			func(request kafkaProducerReq) propagation.TextMapCarrier {
+				return kafkaProducerCarrier{messages: request.msgs}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build Kafka receive instrumenter, used by FetchMessage which hands the
+// message over to the caller without processing it
+func buildKafkaReceiveInstrumenter() instrumenter.Instrumenter[kafkaConsumerReq, any] {
+	builder := instrumenter.Builder[kafkaConsumerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.KAFKAGO_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[kafkaConsumerReq, any]{
+			Getter:        kafkaMessageConsumerAttrsGetter{},
+			OperationName: message.RECEIVE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[kafkaConsumerReq]{}).
+		SetSpanStatusExtractor(&kafkaConsumerStatusExtractor{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[kafkaConsumerReq, any, kafkaMessageConsumerAttrsGetter]{
+			Operation: message.RECEIVE,
+		}).
+		AddAttributesExtractor(&kafkaConsumerAttributesExtractor{}).
+		BuildPropagatingFromUpstreamInstrumenter(
+			func(request kafkaConsumerReq) propagation.TextMapCarrier {
+				return kafkaConsumerCarrier{message: request.msg}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build Kafka commit instrumenter, the commit span links to the upstream
+// context of every committed message
+func buildKafkaCommitInstrumenter() instrumenter.Instrumenter[kafkaCommitReq, any] {
+	builder := instrumenter.Builder[kafkaCommitReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.KAFKAGO_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[kafkaCommitReq, any]{
+			Getter:        kafkaMessageCommitAttrsGetter{},
+			OperationName: message.SETTLE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[kafkaCommitReq]{}).
+		SetSpanStatusExtractor(&kafkaCommitStatusExtractor{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[kafkaCommitReq, any, kafkaMessageCommitAttrsGetter]{
+			Operation: message.SETTLE,
+		}).
+		AddAttributesExtractor(&kafkaCommitAttributesExtractor{}).
+		BuildInstrumenter()
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
 	_ "unsafe"
 )
 
@@ -43,8 +44,29 @@ func producerWriteMessagesOnEnter(call api.CallContext, writer *kafka.Writer, ct
 		msgs:  messagePointers,
 	}
 
-	// Start instrumentation and get instrumented context
-	instrumentedContext := producerInstrumenter.Start(ctx, producerRequest)
+	var instrumentedContext context.Context
+	if len(messagePointers) > 1 {
+		// Every message of a batch gets its own create span whose context is
+		// injected into that message, the publish span links to all of them
+		links := make([]trace.Link, 0, len(messagePointers))
+		for _, msg := range messagePointers {
+			createRequest := kafkaProducerReq{
+				topic: writer.Topic,
+				addr:  writer.Addr,
+				async: writer.Async,
+				msgs:  []*kafka.Message{msg},
+			}
+			if createRequest.topic == "" {
+				createRequest.topic = msg.Topic
+			}
+			createContext := createInstrumenter.Start(ctx, createRequest)
+			createInstrumenter.End(createContext, createRequest, nil, nil)
+			links = append(links, trace.Link{SpanContext: trace.SpanContextFromContext(createContext)})
+		}
+		instrumentedContext = batchProducerInstrumenter.Start(ctx, producerRequest, trace.WithLinks(links...))
+	} else {
+		instrumentedContext = producerInstrumenter.Start(ctx, producerRequest)
+	}
 
 	// Store data for later use in exit hook
 	instrumentationData := map[string]interface{}{
@@ -75,5 +97,9 @@ func producerWriteMessagesOnExit(call api.CallContext, err error) {
 	producerRequest := instrumentationData["producerRequest"].(kafkaProducerReq)
 
 	// End instrumentation with results
-	producerInstrumenter.End(instrumentedContext, producerRequest, nil, err)
+	if len(producerRequest.msgs) > 1 {
+		batchProducerInstrumenter.End(instrumentedContext, producerRequest, nil, err)
+	} else {
+		producerInstrumenter.End(instrumentedContext, producerRequest, nil, err)
+	}
 }
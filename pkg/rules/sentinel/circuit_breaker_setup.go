@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinel
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/experimental"
+	"github.com/alibaba/sentinel-golang/core/base"
+	"github.com/alibaba/sentinel-golang/core/circuitbreaker"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	sentinelCircuitBreakerStrategyKey = attribute.Key("sentinel.circuit_breaker.strategy")
+	sentinelStateFromKey              = attribute.Key("sentinel.circuit_breaker.state.from")
+	sentinelStateToKey                = attribute.Key("sentinel.circuit_breaker.state.to")
+)
+
+const sentinelStateChangeEvent = "sentinel.circuit_breaker.state_change"
+
+// boundRuler is implemented by the unexported circuitBreakerBase that owns
+// all the state transitions.
+type boundRuler interface {
+	BoundRule() *circuitbreaker.Rule
+}
+
+//go:linkname fromClosedToOpenOnEnter github.com/alibaba/sentinel-golang/core/circuitbreaker.fromClosedToOpenOnEnter
+func fromClosedToOpenOnEnter(call api.CallContext, cb interface{}, snapshot interface{}) {
+	call.SetData(cb)
+}
+
+//go:linkname fromClosedToOpenOnExit github.com/alibaba/sentinel-golang/core/circuitbreaker.fromClosedToOpenOnExit
+func fromClosedToOpenOnExit(call api.CallContext, ok bool) {
+	stateChanged(call, ok, circuitbreaker.Closed, circuitbreaker.Open)
+}
+
+//go:linkname fromOpenToHalfOpenOnEnter github.com/alibaba/sentinel-golang/core/circuitbreaker.fromOpenToHalfOpenOnEnter
+func fromOpenToHalfOpenOnEnter(call api.CallContext, cb interface{}, ctx *base.EntryContext) {
+	call.SetData(cb)
+}
+
+//go:linkname fromOpenToHalfOpenOnExit github.com/alibaba/sentinel-golang/core/circuitbreaker.fromOpenToHalfOpenOnExit
+func fromOpenToHalfOpenOnExit(call api.CallContext, ok bool) {
+	stateChanged(call, ok, circuitbreaker.Open, circuitbreaker.HalfOpen)
+}
+
+//go:linkname fromHalfOpenToOpenOnEnter github.com/alibaba/sentinel-golang/core/circuitbreaker.fromHalfOpenToOpenOnEnter
+func fromHalfOpenToOpenOnEnter(call api.CallContext, cb interface{}, snapshot interface{}) {
+	call.SetData(cb)
+}
+
+//go:linkname fromHalfOpenToOpenOnExit github.com/alibaba/sentinel-golang/core/circuitbreaker.fromHalfOpenToOpenOnExit
+func fromHalfOpenToOpenOnExit(call api.CallContext, ok bool) {
+	stateChanged(call, ok, circuitbreaker.HalfOpen, circuitbreaker.Open)
+}
+
+//go:linkname fromHalfOpenToClosedOnEnter github.com/alibaba/sentinel-golang/core/circuitbreaker.fromHalfOpenToClosedOnEnter
+func fromHalfOpenToClosedOnEnter(call api.CallContext, cb interface{}) {
+	call.SetData(cb)
+}
+
+//go:linkname fromHalfOpenToClosedOnExit github.com/alibaba/sentinel-golang/core/circuitbreaker.fromHalfOpenToClosedOnExit
+func fromHalfOpenToClosedOnExit(call api.CallContext, ok bool) {
+	stateChanged(call, ok, circuitbreaker.HalfOpen, circuitbreaker.Closed)
+}
+
+func stateChanged(call api.CallContext, ok bool, from, to circuitbreaker.State) {
+	if !sentinelEnabler.Enable() {
+		return
+	}
+	// the transition lost the race against another goroutine
+	if !ok {
+		return
+	}
+	cb, isCb := call.GetData().(boundRuler)
+	if !isCb || cb.BoundRule() == nil {
+		return
+	}
+	rule := cb.BoundRule()
+	attrs := []attribute.KeyValue{
+		sentinelResourceKey.String(rule.Resource),
+		sentinelCircuitBreakerStrategyKey.String(rule.Strategy.String()),
+		sentinelStateFromKey.String(from.String()),
+		sentinelStateToKey.String(to.String()),
+	}
+	if experimental.SentinelStateChangeCount != nil {
+		experimental.SentinelStateChangeCount.Add(context.Background(), 1, metric.WithAttributes(attrs...))
+	}
+	span := trace.SpanFromGLS()
+	if span == nil {
+		return
+	}
+	span.AddEvent(sentinelStateChangeEvent, oteltrace.WithAttributes(attrs...))
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinel
+
+import (
+	"context"
+	"fmt"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/experimental"
+	"github.com/alibaba/sentinel-golang/core/base"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	sentinelResourceKey       = attribute.Key("sentinel.resource")
+	sentinelRuleTypeKey       = attribute.Key("sentinel.rule.type")
+	sentinelBlockReasonKey    = attribute.Key("sentinel.block.reason")
+	sentinelTriggeredValueKey = attribute.Key("sentinel.block.triggered_value")
+	sentinelErrorMessageKey   = attribute.Key("sentinel.error.message")
+)
+
+const (
+	sentinelBlockEvent = "sentinel.block"
+	sentinelErrorEvent = "sentinel.error"
+)
+
+//go:linkname entryOnExit github.com/alibaba/sentinel-golang/api.entryOnExit
+func entryOnExit(call api.CallContext, entry *base.SentinelEntry, blockErr *base.BlockError) {
+	if !sentinelEnabler.Enable() {
+		return
+	}
+	resource, _ := call.GetParam(0).(string)
+	resourceAttr := sentinelResourceKey.String(resource)
+	if blockErr == nil {
+		if experimental.SentinelPassCount != nil {
+			experimental.SentinelPassCount.Add(context.Background(), 1, metric.WithAttributes(resourceAttr))
+		}
+		return
+	}
+	ruleTypeAttr := sentinelRuleTypeKey.String(ruleType(blockErr.BlockType()))
+	if experimental.SentinelBlockCount != nil {
+		experimental.SentinelBlockCount.Add(context.Background(), 1, metric.WithAttributes(resourceAttr, ruleTypeAttr))
+	}
+	span := trace.SpanFromGLS()
+	if span == nil {
+		return
+	}
+	attrs := []attribute.KeyValue{resourceAttr, ruleTypeAttr, sentinelBlockReasonKey.String(blockReason(blockErr))}
+	if v := blockErr.TriggeredValue(); v != nil {
+		attrs = append(attrs, sentinelTriggeredValueKey.String(fmt.Sprint(v)))
+	}
+	span.AddEvent(sentinelBlockEvent, oteltrace.WithAttributes(attrs...))
+}
+
+// SentinelEntry.SetError, api.TraceError and SentinelEntry.Exit with an error
+// all end up in EntryContext.SetError.
+//
+//go:linkname setErrorOnEnter github.com/alibaba/sentinel-golang/core/base.setErrorOnEnter
+func setErrorOnEnter(call api.CallContext, ctx *base.EntryContext, err error) {
+	if !sentinelEnabler.Enable() {
+		return
+	}
+	if ctx == nil || err == nil {
+		return
+	}
+	resource := ""
+	if ctx.Resource != nil {
+		resource = ctx.Resource.Name()
+	}
+	resourceAttr := sentinelResourceKey.String(resource)
+	if experimental.SentinelErrorCount != nil {
+		experimental.SentinelErrorCount.Add(context.Background(), 1, metric.WithAttributes(resourceAttr))
+	}
+	span := trace.SpanFromGLS()
+	if span == nil {
+		return
+	}
+	span.AddEvent(sentinelErrorEvent, oteltrace.WithAttributes(resourceAttr, sentinelErrorMessageKey.String(err.Error())))
+}
+
+func ruleType(blockType base.BlockType) string {
+	switch blockType {
+	case base.BlockTypeFlow:
+		return "flow"
+	case base.BlockTypeIsolation:
+		return "isolation"
+	case base.BlockTypeCircuitBreaking:
+		return "circuit_breaking"
+	case base.BlockTypeSystemFlow:
+		return "system"
+	case base.BlockTypeHotSpotParamFlow:
+		return "hotspot_param_flow"
+	}
+	return blockType.String()
+}
+
+func blockReason(blockErr *base.BlockError) string {
+	if msg := blockErr.BlockMsg(); msg != "" {
+		return msg
+	}
+	if rule := blockErr.TriggeredRule(); rule != nil {
+		return rule.String()
+	}
+	return blockErr.BlockType().String()
+}
@@ -0,0 +1,3 @@
+module github.com/alibaba/loongsuite-go-agent/pkg/rules/fmt8
+
+go 1.23.0
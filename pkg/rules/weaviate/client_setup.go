@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"reflect"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+var weaviateInstrumenter = BuildWeaviateOtelInstrumenter()
+
+type weaviateInnerEnabler struct {
+	enabled bool
+}
+
+func (w weaviateInnerEnabler) Enable() bool {
+	return w.enabled
+}
+
+var weaviateEnabler = weaviateInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_WEAVIATE_ENABLED") != "false"}
+
+// The builders of the v4 and v5 clients are the same, they are taken as
+// interface{} and their unexported fields are read through reflection, so
+// that the hooks of both major versions share the following functions.
+
+func getBuilderOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	request := newWeaviateRequest(builder, "search", fieldString(builder, "className"))
+	if fieldBool(builder, "includesLimit") {
+		request.topK = fieldInt(builder, "limit")
+	}
+	request.search = true
+	weaviateStart(call, ctx, request)
+}
+
+func getBuilderOnExit(call api.CallContext, resp *models.GraphQLResponse, err error) {
+	weaviateEnd(call, err, func(request *weaviateRequest) {
+		if resp == nil {
+			return
+		}
+		get, _ := resp.Data["Get"].(map[string]interface{})
+		objects, _ := get[request.collection].([]interface{})
+		request.returned = len(objects)
+	})
+}
+
+func objectsBatcherOnEnter(call api.CallContext, batcher interface{}, ctx context.Context) {
+	objects := fieldOf(reflect.ValueOf(batcher), "objects")
+	var collection string
+	if objects.Kind() == reflect.Slice && objects.Len() > 0 {
+		if class := fieldOf(objects.Index(0), "Class"); class.Kind() == reflect.String {
+			collection = class.String()
+		}
+	}
+	request := newWeaviateRequest(batcher, "upsert", collection)
+	if objects.Kind() == reflect.Slice {
+		request.batchSize = objects.Len()
+	}
+	weaviateStart(call, ctx, request)
+}
+
+func creatorOnEnter(call api.CallContext, creator interface{}, ctx context.Context) {
+	weaviateStart(call, ctx, newWeaviateRequest(creator, "insert", fieldString(creator, "className")))
+}
+
+func deleterOnEnter(call api.CallContext, deleter interface{}, ctx context.Context) {
+	weaviateStart(call, ctx, newWeaviateRequest(deleter, "delete", fieldString(deleter, "className")))
+}
+
+func newWeaviateRequest(builder interface{}, operation, collection string) *weaviateRequest {
+	request := &weaviateRequest{
+		operation:  operation,
+		collection: collection,
+	}
+	if u, err := url.Parse(fieldString(builder, "connection", "basePath")); err == nil {
+		request.address = u.Host
+	}
+	return request
+}
+
+func weaviateStart(call api.CallContext, ctx context.Context, request *weaviateRequest) {
+	if !weaviateEnabler.Enable() {
+		return
+	}
+	newCtx := weaviateInstrumenter.Start(ctx, request)
+	// the http calls issued by the builder are made within the weaviate span
+	call.SetParam(1, newCtx)
+	call.SetKeyData("ctx", newCtx)
+	call.SetKeyData("request", request)
+}
+
+func weaviateEnd(call api.CallContext, err error, onResult func(request *weaviateRequest)) {
+	if !weaviateEnabler.Enable() {
+		return
+	}
+	newCtx, ok := call.GetKeyData("ctx").(context.Context)
+	if !ok {
+		return
+	}
+	request := call.GetKeyData("request").(*weaviateRequest)
+	if err == nil && onResult != nil {
+		onResult(request)
+	}
+	weaviateInstrumenter.End(newCtx, request, nil, err)
+}
+
+// fieldOf follows the named fields from v through pointers and interfaces,
+// the zero Value is returned if any of them does not exist.
+func fieldOf(v reflect.Value, names ...string) reflect.Value {
+	for _, name := range names {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		v = v.FieldByName(name)
+	}
+	return v
+}
+
+func fieldString(v interface{}, names ...string) string {
+	f := fieldOf(reflect.ValueOf(v), names...)
+	if f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+func fieldInt(v interface{}, names ...string) int {
+	f := fieldOf(reflect.ValueOf(v), names...)
+	if f.Kind() != reflect.Int {
+		return 0
+	}
+	return int(f.Int())
+}
+
+func fieldBool(v interface{}, names ...string) bool {
+	f := fieldOf(reflect.ValueOf(v), names...)
+	return f.Kind() == reflect.Bool && f.Bool()
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+//go:linkname weaviateV4GetBuilderDoOnEnter github.com/weaviate/weaviate-go-client/v4/weaviate/graphql.weaviateV4GetBuilderDoOnEnter
+func weaviateV4GetBuilderDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	getBuilderOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV4GetBuilderDoOnExit github.com/weaviate/weaviate-go-client/v4/weaviate/graphql.weaviateV4GetBuilderDoOnExit
+func weaviateV4GetBuilderDoOnExit(call api.CallContext, resp *models.GraphQLResponse, err error) {
+	getBuilderOnExit(call, resp, err)
+}
+
+//go:linkname weaviateV4ObjectsBatcherDoOnEnter github.com/weaviate/weaviate-go-client/v4/weaviate/batch.weaviateV4ObjectsBatcherDoOnEnter
+func weaviateV4ObjectsBatcherDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	objectsBatcherOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV4ObjectsBatcherDoOnExit github.com/weaviate/weaviate-go-client/v4/weaviate/batch.weaviateV4ObjectsBatcherDoOnExit
+func weaviateV4ObjectsBatcherDoOnExit(call api.CallContext, resp []models.ObjectsGetResponse, err error) {
+	weaviateEnd(call, err, nil)
+}
+
+//go:linkname weaviateV4ObjectsBatchDeleterDoOnEnter github.com/weaviate/weaviate-go-client/v4/weaviate/batch.weaviateV4ObjectsBatchDeleterDoOnEnter
+func weaviateV4ObjectsBatchDeleterDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	deleterOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV4ObjectsBatchDeleterDoOnExit github.com/weaviate/weaviate-go-client/v4/weaviate/batch.weaviateV4ObjectsBatchDeleterDoOnExit
+func weaviateV4ObjectsBatchDeleterDoOnExit(call api.CallContext, resp *models.BatchDeleteResponse, err error) {
+	weaviateEnd(call, err, nil)
+}
+
+//go:linkname weaviateV4CreatorDoOnEnter github.com/weaviate/weaviate-go-client/v4/weaviate/data.weaviateV4CreatorDoOnEnter
+func weaviateV4CreatorDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	creatorOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV4CreatorDoOnExit github.com/weaviate/weaviate-go-client/v4/weaviate/data.weaviateV4CreatorDoOnExit
+func weaviateV4CreatorDoOnExit(call api.CallContext, object interface{}, err error) {
+	weaviateEnd(call, err, nil)
+}
+
+//go:linkname weaviateV4DeleterDoOnEnter github.com/weaviate/weaviate-go-client/v4/weaviate/data.weaviateV4DeleterDoOnEnter
+func weaviateV4DeleterDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	deleterOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV4DeleterDoOnExit github.com/weaviate/weaviate-go-client/v4/weaviate/data.weaviateV4DeleterDoOnExit
+func weaviateV4DeleterDoOnExit(call api.CallContext, err error) {
+	weaviateEnd(call, err, nil)
+}
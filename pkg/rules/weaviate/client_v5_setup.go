@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+//go:linkname weaviateV5GetBuilderDoOnEnter github.com/weaviate/weaviate-go-client/v5/weaviate/graphql.weaviateV5GetBuilderDoOnEnter
+func weaviateV5GetBuilderDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	getBuilderOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV5GetBuilderDoOnExit github.com/weaviate/weaviate-go-client/v5/weaviate/graphql.weaviateV5GetBuilderDoOnExit
+func weaviateV5GetBuilderDoOnExit(call api.CallContext, resp *models.GraphQLResponse, err error) {
+	getBuilderOnExit(call, resp, err)
+}
+
+//go:linkname weaviateV5ObjectsBatcherDoOnEnter github.com/weaviate/weaviate-go-client/v5/weaviate/batch.weaviateV5ObjectsBatcherDoOnEnter
+func weaviateV5ObjectsBatcherDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	objectsBatcherOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV5ObjectsBatcherDoOnExit github.com/weaviate/weaviate-go-client/v5/weaviate/batch.weaviateV5ObjectsBatcherDoOnExit
+func weaviateV5ObjectsBatcherDoOnExit(call api.CallContext, resp []models.ObjectsGetResponse, err error) {
+	weaviateEnd(call, err, nil)
+}
+
+//go:linkname weaviateV5ObjectsBatchDeleterDoOnEnter github.com/weaviate/weaviate-go-client/v5/weaviate/batch.weaviateV5ObjectsBatchDeleterDoOnEnter
+func weaviateV5ObjectsBatchDeleterDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	deleterOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV5ObjectsBatchDeleterDoOnExit github.com/weaviate/weaviate-go-client/v5/weaviate/batch.weaviateV5ObjectsBatchDeleterDoOnExit
+func weaviateV5ObjectsBatchDeleterDoOnExit(call api.CallContext, resp *models.BatchDeleteResponse, err error) {
+	weaviateEnd(call, err, nil)
+}
+
+//go:linkname weaviateV5CreatorDoOnEnter github.com/weaviate/weaviate-go-client/v5/weaviate/data.weaviateV5CreatorDoOnEnter
+func weaviateV5CreatorDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	creatorOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV5CreatorDoOnExit github.com/weaviate/weaviate-go-client/v5/weaviate/data.weaviateV5CreatorDoOnExit
+func weaviateV5CreatorDoOnExit(call api.CallContext, object interface{}, err error) {
+	weaviateEnd(call, err, nil)
+}
+
+//go:linkname weaviateV5DeleterDoOnEnter github.com/weaviate/weaviate-go-client/v5/weaviate/data.weaviateV5DeleterDoOnEnter
+func weaviateV5DeleterDoOnEnter(call api.CallContext, builder interface{}, ctx context.Context) {
+	deleterOnEnter(call, builder, ctx)
+}
+
+//go:linkname weaviateV5DeleterDoOnExit github.com/weaviate/weaviate-go-client/v5/weaviate/data.weaviateV5DeleterDoOnExit
+func weaviateV5DeleterDoOnExit(call api.CallContext, err error) {
+	weaviateEnd(call, err, nil)
+}
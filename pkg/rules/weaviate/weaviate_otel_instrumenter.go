@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+type weaviateAttrsGetter struct {
+}
+
+func (w weaviateAttrsGetter) GetSystem(request *weaviateRequest) string {
+	return "weaviate"
+}
+
+func (w weaviateAttrsGetter) GetServerAddress(request *weaviateRequest) string {
+	return request.address
+}
+
+func (w weaviateAttrsGetter) GetStatement(request *weaviateRequest) string {
+	return ""
+}
+
+func (w weaviateAttrsGetter) GetOperation(request *weaviateRequest) string {
+	return request.operation
+}
+
+func (w weaviateAttrsGetter) GetCollection(request *weaviateRequest) string {
+	return request.collection
+}
+
+func (w weaviateAttrsGetter) GetParameters(request *weaviateRequest) []any {
+	return nil
+}
+
+func (w weaviateAttrsGetter) GetDbNamespace(request *weaviateRequest) string {
+	return ""
+}
+
+func (w weaviateAttrsGetter) GetBatchSize(request *weaviateRequest) int {
+	return request.batchSize
+}
+
+func (w weaviateAttrsGetter) GetTopK(request *weaviateRequest) int {
+	return request.topK
+}
+
+func (w weaviateAttrsGetter) GetReturnedRows(request *weaviateRequest) (int, bool) {
+	return request.returned, request.search
+}
+
+func BuildWeaviateOtelInstrumenter() instrumenter.Instrumenter[*weaviateRequest, any] {
+	builder := instrumenter.Builder[*weaviateRequest, any]{}
+	getter := weaviateAttrsGetter{}
+	return builder.Init().SetSpanNameExtractor(&db.DBSpanNameExtractor[*weaviateRequest]{Getter: getter}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[*weaviateRequest]{}).
+		AddOperationListeners(db.DbClientMetrics("vector.weaviate")).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.WEAVIATE_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		AddAttributesExtractor(&db.DbClientAttrsExtractor[*weaviateRequest, any, db.DbClientAttrsGetter[*weaviateRequest]]{Base: db.DbClientCommonAttrsExtractor[*weaviateRequest, any, db.DbClientAttrsGetter[*weaviateRequest]]{Getter: getter}}).
+		AddAttributesExtractor(&db.DbVectorAttrsExtractor[*weaviateRequest, any, db.DbVectorAttrsGetter[*weaviateRequest]]{Getter: getter}).
+		BuildInstrumenter()
+}
@@ -30,6 +30,7 @@ func init() {
 	TestCases = append(TestCases,
 		NewGeneralTestCase("rabbitmq_cascading-1.10.0-test", rabbitmq_module_name, "1.10.0", "1.10.0", "1.22.0", "", TestRabbitMQCascading),
 		NewGeneralTestCase("rabbitmq_no_cascading-1.10.0-test", rabbitmq_module_name, "1.10.0", "1.10.0", "1.22.0", "", TestRabbitMQNOCascading),
+		NewGeneralTestCase("rabbitmq_get_settle-1.10.0-test", rabbitmq_module_name, "1.10.0", "1.10.0", "1.22.0", "", TestRabbitMQGetSettle),
 	)
 
 }
@@ -47,6 +48,13 @@ func TestRabbitMQNOCascading(t *testing.T, env ...string) {
 	env = append(env, "RabbitMQ_PORT="+port.Port())
 	RunApp(t, "test_mq_no_cascading", env...)
 }
+func TestRabbitMQGetSettle(t *testing.T, env ...string) {
+	_, port := initRabbitMQContainer()
+	UseApp("amqp091/v1.10.0")
+	RunGoBuild(t, "go", "build", "test_mq_get_settle.go", "base.go")
+	env = append(env, "RabbitMQ_PORT="+port.Port())
+	RunApp(t, "test_mq_get_settle", env...)
+}
 func initRabbitMQContainer() (testcontainers.Container, nat.Port) {
 	req := testcontainers.ContainerRequest{
 		Image:        "rabbitmq:4.0.7-alpine",
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type headersCarrier amqp091.Table
+
+func (c headersCarrier) Get(key string) string {
+	v, _ := c[key].(string)
+	return v
+}
+
+func (c headersCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+func (c headersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func main() {
+	channel := initMQ()
+	if err := channel.Confirm(false); err != nil {
+		panic(err)
+	}
+	confirm, err := channel.PublishWithDeferredConfirm(exchange, routingKey, true, false,
+		amqp091.Publishing{Body: []byte("aabbcc"), DeliveryMode: 2, Headers: map[string]interface{}{}})
+	if err != nil {
+		panic(err)
+	}
+	if !confirm.Wait() {
+		panic("publishing was not confirmed")
+	}
+
+	var msg amqp091.Delivery
+	var ok bool
+	for !ok {
+		msg, ok, err = channel.Get(queueName, false)
+		if err != nil {
+			panic(err)
+		}
+	}
+	// The handler continues the trace from the delivery headers
+	handlerContext := otel.GetTextMapPropagator().Extract(context.Background(), headersCarrier(msg.Headers))
+	handlerSpanContext := trace.SpanContextFromContext(handlerContext)
+	if err = msg.Nack(false, true); err != nil {
+		panic(err)
+	}
+	for ok = false; !ok; {
+		msg, ok, err = channel.Get(queueName, false)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if err = msg.Ack(false); err != nil {
+		panic(err)
+	}
+
+	destination := exchange + ":" + routingKey
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		publishSpan := stubs[0][0]
+		verifier.VerifyMQPublishAttributes(publishSpan, exchange, routingKey, queueName, "publish", destination, "rabbitmq")
+		confirmed := verifier.GetAttribute(publishSpan.Attributes, "messaging.rabbitmq.message.confirmed").AsBool()
+		verifier.Assert(confirmed, "Expect publishing to be confirmed")
+		verifier.Assert(len(stubs[0]) == 5, "Expect 5 spans, got %d", len(stubs[0]))
+		for i, outcome := range []string{"nack", "ack"} {
+			receiveSpan := stubs[0][1+2*i]
+			settleSpan := stubs[0][2+2*i]
+			verifier.VerifyMQConsumeAttributes(receiveSpan, exchange, routingKey, queueName, "receive", destination, "rabbitmq")
+			verifier.Assert(receiveSpan.Parent.SpanID() == publishSpan.SpanContext.SpanID(), "Expect receive span to be child of publish span")
+			verifier.Assert(settleSpan.Name == destination+" settle", "Expect settle span name to be %s, got %s", destination+" settle", settleSpan.Name)
+			verifier.Assert(settleSpan.SpanKind == trace.SpanKindClient, "Expect to be client span, got %d", settleSpan.SpanKind)
+			verifier.Assert(settleSpan.Parent.SpanID() == receiveSpan.SpanContext.SpanID(), "Expect settle span to be child of receive span")
+			actualOutcome := verifier.GetAttribute(settleSpan.Attributes, "messaging.rabbitmq.message.settle_outcome").AsString()
+			verifier.Assert(actualOutcome == outcome, "Expect settle outcome to be %s, got %s", outcome, actualOutcome)
+		}
+		verifier.Assert(handlerSpanContext.SpanID() == stubs[0][1].SpanContext.SpanID(), "Expect delivery headers to carry the receive span context")
+	}, 1)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/anthropics/anthropic-sdk-go"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func main() {
+	client, closeServer := newMockClient()
+	defer closeServer()
+	ctx := context.Background()
+	tool := anthropic.ToolParam{
+		Name:        "get_weather",
+		InputSchema: anthropic.ToolInputSchemaParam{Properties: map[string]any{"city": map[string]any{"type": "string"}}},
+	}
+	_, err := client.Messages.New(ctx, anthropic.MessageNewParams{
+		Model:         anthropic.ModelClaude3_5HaikuLatest,
+		MaxTokens:     256,
+		Temperature:   anthropic.Float(0.5),
+		TopK:          anthropic.Int(40),
+		StopSequences: []string{"\n\nHuman:"},
+		System:        []anthropic.TextBlockParam{{Text: "Be brief."}},
+		Messages:      []anthropic.MessageParam{anthropic.NewUserMessage(anthropic.NewTextBlock("Weather in Paris?"))},
+		Tools:         []anthropic.ToolUnionParam{{OfTool: &tool}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	toolResult := anthropic.NewToolResultBlock("toolu_1")
+	toolResult.OfToolResult.Content = []anthropic.ToolResultBlockParamContentUnion{{OfText: &anthropic.TextBlockParam{Text: "sunny"}}}
+	stream := client.Messages.NewStreaming(ctx, anthropic.MessageNewParams{
+		Model:     anthropic.ModelClaude3_5HaikuLatest,
+		MaxTokens: 256,
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(anthropic.NewTextBlock("Weather in Paris?")),
+			anthropic.NewAssistantMessage(anthropic.NewToolUseBlock("toolu_1", map[string]any{"city": "Paris"}, "get_weather")),
+			anthropic.NewUserMessage(toolResult),
+		},
+	})
+	for stream.Next() {
+	}
+	if stream.Err() != nil {
+		panic(stream.Err())
+	}
+	stream.Close()
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		span := stubs[0][0]
+		verifier.VerifyLLMAttributes(span, "chat", "anthropic", string(anthropic.ModelClaude3_5HaikuLatest))
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.request.temperature").AsFloat64() == 0.5, "Except temperature to be 0.5")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.request.top_k").AsFloat64() == 40, "Except top_k to be 40")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.request.max_tokens").AsInt64() == 256, "Except max tokens to be 256")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.response.model").AsString() == "claude-3-5-haiku-20241022", "Except response model")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.response.id").AsString() == "msg_1", "Except response id msg_1")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.usage.input_tokens").AsInt64() == 25, "Except cached tokens to be counted as input tokens")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.usage.output_tokens").AsInt64() == 8, "Except 8 output tokens")
+		finishReasons := verifier.GetAttribute(span.Attributes, "gen_ai.response.finish_reasons").AsStringSlice()
+		verifier.Assert(len(finishReasons) == 1 && finishReasons[0] == "tool_use", "Except finish reason tool_use, got %v", finishReasons)
+		verifier.Assert(len(span.Events) == 3, "Except system, user and choice events, got %d", len(span.Events))
+		verifier.Assert(span.Events[0].Name == "gen_ai.system.message", "Except system message event, got %s", span.Events[0].Name)
+		verifier.Assert(span.Events[2].Name == "gen_ai.choice", "Except choice event, got %s", span.Events[2].Name)
+
+		span = stubs[1][0]
+		verifier.VerifyLLMAttributes(span, "chat", "anthropic", string(anthropic.ModelClaude3_5HaikuLatest))
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.response.id").AsString() == "msg_2", "Except streamed response id msg_2")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.usage.input_tokens").AsInt64() == 12, "Except 12 streamed input tokens")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.usage.output_tokens").AsInt64() == 6, "Except 6 streamed output tokens")
+		finishReasons = verifier.GetAttribute(span.Attributes, "gen_ai.response.finish_reasons").AsStringSlice()
+		verifier.Assert(len(finishReasons) == 1 && finishReasons[0] == "end_turn", "Except finish reason end_turn, got %v", finishReasons)
+		// user, assistant with the tool call, tool result and the choice
+		verifier.Assert(len(span.Events) == 4, "Except 4 events, got %d", len(span.Events))
+		verifier.Assert(span.Events[2].Name == "gen_ai.tool.message", "Except tool message event, got %s", span.Events[2].Name)
+		verifier.Assert(span.Events[3].Name == "gen_ai.choice", "Except choice event, got %s", span.Events[3].Name)
+	}, 2)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+const anthropic_dependency_name = "github.com/anthropics/anthropic-sdk-go"
+const anthropic_module_name = "anthropic"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("anthropic-message-test", anthropic_module_name, "v1.2.0", "", "1.23.0", "", TestMessageAnthropic),
+		NewLatestDepthTestCase("anthropic-latest-depth-test", anthropic_dependency_name, anthropic_module_name, "v1.2.0", "", "1.23.0", "", TestMessageAnthropic),
+		NewMuzzleTestCase("anthropic-muzzle-test-message", anthropic_dependency_name, anthropic_module_name, "v1.2.0", "", "1.23.0", "", []string{"go", "build", "test_anthropic_message.go", "base.go"}),
+	)
+}
+
+func TestMessageAnthropic(t *testing.T, env ...string) {
+	UseApp("anthropic/v1.5.0")
+	RunGoBuild(t, "go", "build", "test_anthropic_message.go", "base.go")
+	env = append(env, "OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT=true")
+	RunApp(t, "test_anthropic_message", env...)
+}
@@ -15,7 +15,10 @@
 package test
 
 import (
+	"os"
 	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/resource"
 )
 
 func TestBuildProject(t *testing.T) {
@@ -35,6 +38,24 @@ func TestBuildProject2(t *testing.T) {
 	RunGoBuild(t, "go", "build", "./...")
 }
 
+func TestBuildWorkspace(t *testing.T) {
+	UseApp("build/workspace/app")
+
+	// module lib is not required by module app but only used in go.work
+	work := hashFile(t, "../go.work")
+	RunGoBuild(t, "go", "build")
+	ExpectDebugLogContains(t, "Resolve rules with 1 load(s) of the package graph")
+	_, stderr := RunApp(t, "app")
+	ExpectContains(t, stderr, "hello")
+	// checksums of the hook modules are recorded in the generated go.work.sum
+	if hashFile(t, "../go.work") != work {
+		t.Fatal("go.work is modified")
+	}
+	if _, err := os.Stat("../go.work.sum"); err == nil {
+		t.Fatal("go.work.sum is created")
+	}
+}
+
 func TestBuildProject3(t *testing.T) {
 	const AppName = "build"
 	UseApp(AppName)
@@ -77,6 +98,29 @@ func TestBuildProject6(t *testing.T) {
 	ExpectDebugLogNotContains(t, "github.com/alibaba/loongsuite-go-agent/pkg/rules/http")
 }
 
+func TestBuildCache(t *testing.T) {
+	const AppName = "build"
+	UseApp(AppName)
+	// start with an empty build cache, so that whatever is instrumented is
+	// known regardless of the builds run before
+	env := []string{"GOCACHE=" + t.TempDir()}
+
+	RunSet(t, "-disable=all", "-rule=../../tool/data/test_fmt.json", "-verbose")
+	RunGoBuildWithEnv(t, env, "go", "build", "m1")
+	ExpectDebugLogContains(t, "Apply bundle")
+	key := ReadPreprocessLog(t, resource.BuildKeyFile)
+	// nothing changed, the instrumented outputs should be reused
+	RunGoBuildWithEnv(t, env, "go", "build", "m1")
+	ExpectDebugLogNotContains(t, "Apply bundle")
+	ExpectContains(t, ReadPreprocessLog(t, resource.BuildKeyFile), key)
+	// rules changed, the packages should be instrumented again and cached
+	// apart from the previous outputs
+	RunSet(t, "-disable=", "-rule=../../tool/data/test_fmt.json", "-verbose")
+	RunGoBuildWithEnv(t, env, "go", "build", "m1")
+	ExpectDebugLogContains(t, "Apply bundle")
+	ExpectNotContains(t, ReadPreprocessLog(t, resource.BuildKeyFile), key)
+}
+
 func TestGoInstall(t *testing.T) {
 	const AppName = "build"
 	UseApp(AppName)
@@ -40,6 +40,11 @@ func TestRunErrors(t *testing.T) {
 	ExpectNotContains(t, stderr, "failed to exec")
 	ExpectNotContains(t, stderr, "baddep")
 	ExpectContains(t, stderr, "gooddep")
+
+	// The program above may consist of packages reused from the build cache,
+	// whose instrumented sources are not retained as they are not compiled,
+	// rebuild all packages to inspect their sources
+	RunGoBuild(t, "go", "build", "-a")
 	text := ReadInstrumentLog(t, filepath.Join("auxiliary", "helper.go"))
 	re := regexp.MustCompile(".*OtelOnEnterTrampoline_TestSkip.*")
 	matches := re.FindAllString(text, -1)
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const (
+	topicName = "test-topic"
+	groupName = "test-group"
+)
+
+func getKafkaAddress() string {
+	if addr := os.Getenv("KAFKA_ADDR"); addr != "" {
+		return addr
+	}
+	return "127.0.0.1:9092" // Default Kafka address
+}
+
+func initClient() *kgo.Client {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(getKafkaAddress()),
+		kgo.DefaultProduceTopic(topicName),
+		kgo.ConsumeTopics(topicName),
+		kgo.ConsumerGroup(groupName),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+		kgo.AllowAutoTopicCreation(),
+	)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client := initClient()
+	defer client.Close()
+
+	// Send message
+	if err := client.ProduceSync(ctx, &kgo.Record{Value: []byte("hello world")}).FirstErr(); err != nil {
+		panic(err)
+	}
+
+	// Poll until the message arrives and process it
+	for consumed := 0; consumed == 0; {
+		fetches := client.PollFetches(ctx)
+		if err := fetches.Err0(); err != nil {
+			panic(err)
+		}
+		fetches.EachRecord(func(record *kgo.Record) {
+			consumed++
+		})
+	}
+
+	// Verify OpenTelemetry traces
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		var publish, receive, process *tracetest.SpanStub
+		for _, stub := range stubs {
+			for i := range stub {
+				switch stub[i].Name {
+				case topicName + " publish":
+					publish = &stub[i]
+				case topicName + " receive":
+					receive = &stub[i]
+				case topicName + " process":
+					process = &stub[i]
+				}
+			}
+		}
+		verifier.Assert(publish != nil && receive != nil && process != nil, "Expect publish, receive and process spans")
+		verifier.VerifyMQPublishAttributes(*publish, "", "", "", "publish", topicName, "kafka")
+		verifier.VerifyMQConsumeAttributes(*receive, "", "", "", "receive", topicName, "kafka")
+		verifier.VerifyMQConsumeAttributes(*process, "", "", "", "process", topicName, "kafka")
+		verifier.Assert(process.Parent.SpanID() == publish.SpanContext.SpanID(), "Expect process span to be child of publish span")
+		verifier.Assert(len(receive.Links) == 1 && receive.Links[0].SpanContext.SpanID() == publish.SpanContext.SpanID(),
+			"Expect receive span to link to publish span, got %v", receive.Links)
+		verifier.Assert(len(process.Links) == 1 && process.Links[0].SpanContext.SpanID() == receive.SpanContext.SpanID(),
+			"Expect process span to link to receive span, got %v", process.Links)
+		verifier.Assert(receive.SpanKind == trace.SpanKindConsumer, "Expect receive span to be consumer span")
+	}, 2)
+}
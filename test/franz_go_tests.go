@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+)
+
+const franzModuleName = "franz-go"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("franz-go-basic-test", franzModuleName, "1.18.0", "", "1.21.0", "", TestBasicFranz),
+	)
+}
+
+func TestBasicFranz(t *testing.T, env ...string) {
+	containers := initKafkaContainer(t)
+	defer containers.CleanupContainers(context.Background())
+	UseApp("franz-go/v1.18.0")
+	RunGoBuild(t, "go", "build", "test_franz_basic.go", "base.go")
+	env = append(env, "KAFKA_ADDR="+containers.KafkaAddress)
+	RunApp(t, "test_franz_basic", env...)
+}
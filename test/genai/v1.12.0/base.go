@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+const generateBody = `{"candidates":[{"content":{"role":"model","parts":[{"functionCall":{"name":"get_weather","args":{"city":"Paris"}}}]},"finishReason":"STOP","index":0}],` +
+	`"usageMetadata":{"promptTokenCount":10,"candidatesTokenCount":5,"thoughtsTokenCount":2,"totalTokenCount":17},` +
+	`"modelVersion":"gemini-2.0-flash-001","responseId":"resp-1"}`
+
+var generateChunks = []string{
+	`{"candidates":[{"content":{"role":"model","parts":[{"text":"It is "}]},"index":0}],"usageMetadata":{"promptTokenCount":8,"candidatesTokenCount":2,"totalTokenCount":10},"modelVersion":"gemini-2.0-flash-001","responseId":"resp-2"}`,
+	`{"candidates":[{"content":{"role":"model","parts":[{"text":"sunny."}]},"finishReason":"STOP","index":0}],"usageMetadata":{"promptTokenCount":8,"candidatesTokenCount":4,"totalTokenCount":12},"modelVersion":"gemini-2.0-flash-001","responseId":"resp-2"}`,
+}
+
+const embedBody = `{"embeddings":[{"values":[0.1,0.2]}]}`
+
+// newMockClient starts a Gemini API compatible stand-in server and returns
+// a client talking to it.
+func newMockClient() (*genai.Client, string, func()) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":streamGenerateContent"):
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, chunk := range generateChunks {
+				fmt.Fprintf(w, "data: %s\n\n", chunk)
+			}
+		case strings.HasSuffix(r.URL.Path, ":generateContent"):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, generateBody)
+		case strings.HasSuffix(r.URL.Path, ":batchEmbedContents"), strings.HasSuffix(r.URL.Path, ":embedContent"):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, embedBody)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey:      "key",
+		Backend:     genai.BackendGeminiAPI,
+		HTTPOptions: genai.HTTPOptions{BaseURL: ts.URL + "/"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return client, ts.URL + "/", ts.Close
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/genai"
+)
+
+const model = "gemini-2.0-flash"
+
+func main() {
+	client, baseURL, closeServer := newMockClient()
+	defer closeServer()
+	ctx := context.Background()
+	_, err := client.Models.GenerateContent(ctx, model, genai.Text("Weather in Paris?"), &genai.GenerateContentConfig{
+		SystemInstruction: genai.NewContentFromText("Be brief.", genai.RoleUser),
+		Temperature:       genai.Ptr[float32](0.7),
+		TopK:              genai.Ptr[float32](40),
+		MaxOutputTokens:   256,
+		Seed:              genai.Ptr[int32](42),
+		StopSequences:     []string{"END"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	contents := []*genai.Content{
+		genai.NewContentFromText("Weather in Paris?", genai.RoleUser),
+		genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": "Paris"}, genai.RoleModel),
+		genai.NewContentFromFunctionResponse("get_weather", map[string]any{"weather": "sunny"}, genai.RoleUser),
+	}
+	for _, err := range client.Models.GenerateContentStream(ctx, model, contents, nil) {
+		if err != nil {
+			panic(err)
+		}
+	}
+	_, err = client.Models.EmbedContent(ctx, "text-embedding-004", genai.Text("hi"), nil)
+	if err != nil {
+		panic(err)
+	}
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		span := stubs[0][0]
+		verifier.VerifyLLMAttributes(span, "chat", "gemini", model)
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.request.temperature").AsFloat64() == 0.7, "Except temperature to be 0.7")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.request.top_k").AsFloat64() == 40, "Except top_k to be 40")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.request.max_tokens").AsInt64() == 256, "Except max tokens to be 256")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.request.seed").AsInt64() == 42, "Except seed to be 42")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.response.model").AsString() == "gemini-2.0-flash-001", "Except response model")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.usage.input_tokens").AsInt64() == 10, "Except 10 input tokens")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.usage.output_tokens").AsInt64() == 7, "Except thinking tokens to be counted as output tokens")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "server.address").AsString() == baseURL, "Except server address %s", baseURL)
+		finishReasons := verifier.GetAttribute(span.Attributes, "gen_ai.response.finish_reasons").AsStringSlice()
+		verifier.Assert(len(finishReasons) == 1 && finishReasons[0] == "STOP", "Except finish reason STOP, got %v", finishReasons)
+		verifier.Assert(len(span.Events) == 3, "Except system, user and choice events, got %d", len(span.Events))
+		verifier.Assert(span.Events[2].Name == "gen_ai.choice", "Except choice event, got %s", span.Events[2].Name)
+
+		span = stubs[1][0]
+		verifier.VerifyLLMAttributes(span, "chat", "gemini", model)
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.usage.input_tokens").AsInt64() == 8, "Except 8 streamed input tokens")
+		verifier.Assert(verifier.GetAttribute(span.Attributes, "gen_ai.usage.output_tokens").AsInt64() == 4, "Except 4 streamed output tokens")
+		finishReasons = verifier.GetAttribute(span.Attributes, "gen_ai.response.finish_reasons").AsStringSlice()
+		verifier.Assert(len(finishReasons) == 1 && finishReasons[0] == "STOP", "Except streamed finish reason STOP, got %v", finishReasons)
+		// user, assistant with the function call, function response and the choice
+		verifier.Assert(len(span.Events) == 4, "Except 4 events, got %d", len(span.Events))
+		verifier.Assert(span.Events[2].Name == "gen_ai.tool.message", "Except tool message event, got %s", span.Events[2].Name)
+
+		verifier.VerifyLLMAttributes(stubs[2][0], "embeddings", "gemini", "text-embedding-004")
+	}, 3)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+const genai_dependency_name = "google.golang.org/genai"
+const genai_module_name = "genai"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("genai-generate-test", genai_module_name, "v1.0.0", "", "1.23.0", "", TestGenerateGenAI),
+		NewLatestDepthTestCase("genai-latest-depth-test", genai_dependency_name, genai_module_name, "v1.0.0", "", "1.23.0", "", TestGenerateGenAI),
+		NewMuzzleTestCase("genai-muzzle-test-generate", genai_dependency_name, genai_module_name, "v1.0.0", "", "1.23.0", "", []string{"go", "build", "test_genai_generate.go", "base.go"}),
+	)
+}
+
+func TestGenerateGenAI(t *testing.T, env ...string) {
+	UseApp("genai/v1.12.0")
+	RunGoBuild(t, "go", "build", "test_genai_generate.go", "base.go")
+	env = append(env, "OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT=true")
+	RunApp(t, "test_genai_generate", env...)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/sashabaranov/go-openai"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func main() {
+	client, baseURL, closeServer := newMockClient()
+	defer closeServer()
+	ctx := context.Background()
+	messages := []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}}
+	_, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:       "gpt-4o-mini",
+		Messages:    messages,
+		Temperature: 0.5,
+		MaxTokens:   64,
+		Stop:        []string{"\n"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	stream, err := client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:         "gpt-4o-mini",
+		Messages:      messages,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		panic(err)
+	}
+	for {
+		_, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+	stream.Close()
+	_, err = client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Model: openai.SmallEmbedding3,
+		Input: []string{"hi"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.VerifyLLMAttributes(stubs[0][0], "chat", "openai", "gpt-4o-mini")
+		verifier.Assert(verifier.GetAttribute(stubs[0][0].Attributes, "gen_ai.request.temperature").AsFloat64() == 0.5, "Except temperature to be 0.5")
+		verifier.Assert(verifier.GetAttribute(stubs[0][0].Attributes, "gen_ai.request.max_tokens").AsInt64() == 64, "Except max tokens to be 64")
+		verifier.Assert(verifier.GetAttribute(stubs[0][0].Attributes, "gen_ai.usage.input_tokens").AsInt64() == 9, "Except 9 input tokens")
+		verifier.Assert(verifier.GetAttribute(stubs[0][0].Attributes, "gen_ai.usage.output_tokens").AsInt64() == 3, "Except 3 output tokens")
+		verifier.Assert(verifier.GetAttribute(stubs[0][0].Attributes, "server.address").AsString() == baseURL, "Except server address %s", baseURL)
+
+		verifier.VerifyLLMAttributes(stubs[1][0], "chat", "openai", "gpt-4o-mini")
+		finishReasons := verifier.GetAttribute(stubs[1][0].Attributes, "gen_ai.response.finish_reasons").AsStringSlice()
+		verifier.Assert(len(finishReasons) == 1 && finishReasons[0] == "stop", "Except finish reason stop, got %v", finishReasons)
+		verifier.Assert(verifier.GetAttribute(stubs[1][0].Attributes, "gen_ai.usage.output_tokens").AsInt64() == 2, "Except 2 streamed output tokens")
+		verifier.Assert(verifier.GetAttribute(stubs[1][0].Attributes, "gen_ai.response.id").AsString() == "chatcmpl-2", "Except streamed response id")
+
+		verifier.VerifyLLMAttributes(stubs[2][0], "embeddings", "openai", "text-embedding-3-small")
+		verifier.Assert(verifier.GetAttribute(stubs[2][0].Attributes, "gen_ai.usage.input_tokens").AsInt64() == 5, "Except 5 input tokens")
+	}, 3)
+}
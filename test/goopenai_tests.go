@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+const goopenai_dependency_name = "github.com/sashabaranov/go-openai"
+const goopenai_module_name = "goopenai"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("goopenai-chat-test", goopenai_module_name, "v1.32.0", "", "1.23.0", "", TestChatGoOpenAI),
+		NewLatestDepthTestCase("goopenai-latest-depth-test", goopenai_dependency_name, goopenai_module_name, "v1.32.0", "", "1.23.0", "", TestChatGoOpenAI),
+		NewMuzzleTestCase("goopenai-muzzle-test-chat", goopenai_dependency_name, goopenai_module_name, "v1.32.0", "", "1.23.0", "", []string{"go", "build", "test_goopenai_chat.go", "base.go"}),
+	)
+}
+
+func TestChatGoOpenAI(t *testing.T, env ...string) {
+	UseApp("goopenai/v1.41.1")
+	RunGoBuild(t, "go", "build", "test_goopenai_chat.go", "base.go")
+	RunApp(t, "test_goopenai_chat", env...)
+}
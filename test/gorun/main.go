@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	_ "go.opentelemetry.io/otel"
+)
+
+// Echo the arguments and exit with the status given by the last one, if any
+func main() {
+	args := os.Args[1:]
+	fmt.Println("args:", strings.Join(args, " "))
+	if len(args) > 0 {
+		if code, err := strconv.Atoi(args[len(args)-1]); err == nil {
+			os.Exit(code)
+		}
+	}
+}
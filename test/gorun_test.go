@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+)
+
+const GoRunAppName = "gorun"
+
+// newPrinterMarker is printed by the raw rule of fmt.newPrinter in
+// test_fmt.json, i.e. 0x7632, once the program is instrumented
+const newPrinterMarker = "30258"
+
+func TestGoRun(t *testing.T) {
+	UseApp(GoRunAppName)
+	RunSet(t, UseTestRules("test_fmt.json"))
+
+	// flags after the package belong to the program
+	RunGoBuild(t, "go", "run", "-tags", "otel", ".", "-v", "hello")
+	ExpectStdoutContains(t, "args: -v hello")
+	ExpectStderrContains(t, newPrinterMarker)
+	expectRunBinaryRemoved(t, GoRunAppName)
+
+	// the exit status of the program is preserved
+	path := filepath.Join(filepath.Dir(pwd), getExecName())
+	cmd := runCmd([]string{path, "go", "run", "main.go", "7"})
+	err := cmd.Run()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 7 {
+		t.Fatalf("expecting exit status 7, got %v", err)
+	}
+	ExpectStdoutContains(t, "args: 7")
+	expectRunBinaryRemoved(t, "main")
+	// hook packages are loaded along with the package graph at once
+	ExpectDebugLogContains(t, "Resolve rules with 1 load(s) of the package graph")
+
+	// programs outside the current module cannot be instrumented
+	RunGoBuildFallible(t, "go", "run", "golang.org/x/tools/cmd/stringer@latest")
+	ExpectStderrContains(t, "go run pkg@version is not supported")
+}
+
+// expectRunBinaryRemoved checks the temporary directory of the built program
+// is removed, the debug log tells where it was
+func expectRunBinaryRemoved(t *testing.T, name string) {
+	log := readLog(t, filepath.Join(util.TempBuildDir, util.DebugLogFile))
+	match := regexp.MustCompile(`Run program: \[(\S+)`).FindStringSubmatch(log)
+	if match == nil || filepath.Base(match[1]) != name {
+		t.Fatalf("expecting %s to be run, got %v", name, match)
+	}
+	dir := filepath.Dir(match[1])
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("expecting %s to be removed, got %v", dir, err)
+	}
+}
@@ -50,6 +50,10 @@ func TestRunHelloworld(t *testing.T) {
 	// ExpectContains(t, stderr, "GCMG")
 	ExpectContains(t, stderr, "BYD")
 
+	// The program above may consist of packages reused from the build cache,
+	// whose instrumented sources are not retained as they are not compiled,
+	// rebuild all packages to inspect their sources
+	RunGoBuild(t, "go", "build", "-a")
 	text := ReadInstrumentLog(t, filepath.Join("fmt", "print.go"))
 	re := regexp.MustCompile("//line <generated>:1")
 	matches := re.FindAllString(text, -1)
@@ -60,6 +64,7 @@ func TestRunHelloworld(t *testing.T) {
 
 func runModVendor(t *testing.T) {
 	_ = os.RemoveAll("vendor")
+	t.Cleanup(func() { _ = os.RemoveAll("vendor") })
 	cmd := exec.Command("go", "mod", "tidy")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
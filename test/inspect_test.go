@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/resource"
+)
+
+func TestInspect(t *testing.T) {
+	UseApp(HelloworldAppName)
+
+	RunSet(t, UseTestRules("test_fmt.json"))
+	RunGoBuild(t, "go", "build")
+
+	path := filepath.Join(filepath.Dir(pwd), getExecName())
+	cmd := runCmd([]string{path, "inspect", "-format=json", HelloworldAppName})
+	err := cmd.Run()
+	if err != nil {
+		t.Fatal(err, readStderrLog(t))
+	}
+	manifest := &resource.Manifest{}
+	err = json.Unmarshal([]byte(readStdoutLog(t)), manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.ToolVersion == "" || manifest.BuildKey == "" {
+		t.Fatalf("incomplete manifest %v", manifest)
+	}
+	if _, ok := manifest.RuleSets["test_fmt.json"]; !ok {
+		t.Fatalf("expecting test_fmt.json in rule sets %v", manifest.RuleSets)
+	}
+	rules := make([]string, 0)
+	for _, pkg := range manifest.Packages {
+		if pkg.ImportPath == "fmt" {
+			rules = pkg.Rules
+		}
+	}
+	ExpectContainsAllItem(t, rules, "func Printf", "struct pp")
+
+	// binaries built without the tool carry no manifest
+	plain := runCmd([]string{"go", "build", "-o", "plain"})
+	err = plain.Run()
+	if err != nil {
+		t.Fatal(err, readStderrLog(t))
+	}
+	cmd = runCmd([]string{path, "inspect", "plain"})
+	if cmd.Run() == nil {
+		t.Fatal("expecting failure for binary not instrumented")
+	}
+	ExpectStdoutContains(t, "not instrumented by otel")
+}
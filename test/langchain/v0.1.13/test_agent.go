@@ -22,6 +22,12 @@ func main() {
 
 	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
 		verifier.VerifyLLMCommonAttributes(stubs[1][0], "agentAction", "langchain", trace.SpanKindClient)
+		verifier.Assert(len(stubs[1]) == 2, "Expect the tool call within the agent action, got %d spans", len(stubs[1]))
+		toolSpan := stubs[1][1]
+		verifier.VerifyLLMCommonAttributes(toolSpan, "execute_tool", "langchain", trace.SpanKindInternal)
+		verifier.Assert(toolSpan.Parent.SpanID() == stubs[1][0].SpanContext.SpanID(), "Expect the tool span to be a child of the agent action")
+		toolName := verifier.GetAttribute(toolSpan.Attributes, "gen_ai.tool.name").AsString()
+		verifier.Assert(toolName == "getAge", "Except gen_ai.tool.name to be getAge, got %s", toolName)
 	}, 3)
 }
 
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/anthropic"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+)
+
+func main() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		mockBody := `{"id":"msg_1","type":"message","role":"assistant","model":"claude-3-haiku","content":[{"type":"text","text":"hello"}],"stop_reason":"end_turn","usage":{"input_tokens":10,"output_tokens":5}}`
+		w.Write(bytes.NewBufferString(mockBody).Bytes())
+	}))
+	defer ts.Close()
+	llm, err := anthropic.New(
+		anthropic.WithModel("claude-3-haiku"),
+		anthropic.WithToken("token"),
+		anthropic.WithBaseURL(ts.URL),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = llm.GenerateContent(context.Background(), []llms.MessageContent{llms.MessageContent{
+		Role: llms.ChatMessageTypeHuman,
+		Parts: []llms.ContentPart{
+			llms.TextPart("你好"),
+		},
+	}})
+	if err != nil {
+		panic(err)
+	}
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		span := stubs[0][0]
+		verifier.VerifyLLMAttributes(span, "chat", "anthropic", "claude-3-haiku")
+		inputTokens := verifier.GetAttribute(span.Attributes, "gen_ai.usage.input_tokens").AsInt64()
+		verifier.Assert(inputTokens == 10, "Except gen_ai.usage.input_tokens to be 10, got %d", inputTokens)
+		outputTokens := verifier.GetAttribute(span.Attributes, "gen_ai.usage.output_tokens").AsInt64()
+		verifier.Assert(outputTokens == 5, "Except gen_ai.usage.output_tokens to be 5, got %d", outputTokens)
+	}, 1)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/openai"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func main() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		mockBody := "data: {\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"hel\"}}]}\n\n" +
+			"data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"},\"finish_reason\":\"stop\"}]}\n\n" +
+			"data: [DONE]\n\n"
+		w.Write(bytes.NewBufferString(mockBody).Bytes())
+	}))
+	defer ts.Close()
+	llm, err := openai.New(
+		openai.WithModel("deepseek-reasoner"),
+		openai.WithToken("token"),
+		openai.WithBaseURL(ts.URL),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	var lastChunk time.Time
+	var chunkSpanValid bool
+	_, err = llm.GenerateContent(context.Background(), []llms.MessageContent{llms.MessageContent{
+		Role: llms.ChatMessageTypeHuman,
+		Parts: []llms.ContentPart{
+			llms.TextPart("你好"),
+		},
+	}}, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+		time.Sleep(10 * time.Millisecond)
+		chunkSpanValid = trace.SpanContextFromContext(ctx).IsValid()
+		lastChunk = time.Now()
+		return nil
+	}))
+	if err != nil {
+		panic(err)
+	}
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		span := stubs[0][0]
+		verifier.VerifyLLMAttributes(span, "chat", "deepseek-reasoner", "deepseek-reasoner")
+		verifier.Assert(chunkSpanValid, "Expect the streaming func to be called within the llm span")
+		verifier.Assert(!span.EndTime.Before(lastChunk), "Expect the llm span to end after the last chunk, ended at %v, last chunk at %v", span.EndTime, lastChunk)
+	}, 1)
+}
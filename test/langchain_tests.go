@@ -16,6 +16,8 @@ func init() {
 		NewGeneralTestCase("langchain-0.1.13-relevantdoc-test", langchain_module_name, "0.1.13", "0.1.13", "1.22.0", "", TestLangchainRelevantDocuments),
 		NewGeneralTestCase("langchain-0.1.13-llm-openai-test", langchain_module_name, "0.1.13", "0.1.13", "1.22.0", "", TestLangchainLLMOpenAi),
 		NewGeneralTestCase("langchain-0.1.13-llm-ollama-test", langchain_module_name, "0.1.13", "0.1.13", "1.22.0", "", TestLangchainLLMOllama),
+		NewGeneralTestCase("langchain-0.1.13-llm-anthropic-test", langchain_module_name, "0.1.13", "0.1.13", "1.22.0", "", TestLangchainLLMAnthropic),
+		NewGeneralTestCase("langchain-0.1.13-llm-stream-test", langchain_module_name, "0.1.13", "0.1.13", "1.22.0", "", TestLangchainLLMStream),
 	)
 
 }
@@ -55,3 +57,13 @@ func TestLangchainLLMOllama(t *testing.T, env ...string) {
 	RunGoBuild(t, "go", "build", "test_llm_ollama.go")
 	RunApp(t, "test_llm_ollama", env...)
 }
+func TestLangchainLLMAnthropic(t *testing.T, env ...string) {
+	UseApp("langchain/v0.1.13")
+	RunGoBuild(t, "go", "build", "test_llm_anthropic.go")
+	RunApp(t, "test_llm_anthropic", env...)
+}
+func TestLangchainLLMStream(t *testing.T, env ...string) {
+	UseApp("langchain/v0.1.13")
+	RunGoBuild(t, "go", "build", "test_llm_stream.go")
+	RunApp(t, "test_llm_stream", env...)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
+	"strings"
 	"time"
 )
 
@@ -88,7 +89,23 @@ func main() {
 		verifier.VerifyLLMCommonAttributes(stubs[1][3], "execute_other:initialize", "mcp", trace.SpanKindServer)
 		verifier.VerifyLLMCommonAttributes(stubs[3][0], "execute_tool", "mcp", trace.SpanKindClient)
 		verifier.VerifyLLMCommonAttributes(stubs[3][3], "execute_tool", "mcp", trace.SpanKindServer)
+		verifyToolContent(stubs[3][0])
+		verifyToolContent(stubs[3][3])
 		verifier.VerifyLLMCommonAttributes(stubs[4][0], "execute_other:tools/list", "mcp", trace.SpanKindClient)
 		verifier.VerifyLLMCommonAttributes(stubs[4][3], "execute_other:tools/list", "mcp", trace.SpanKindServer)
 	}, 5)
 }
+
+// verifyToolContent checks the arguments and the result of the tool call are
+// recorded, as message content capturing is enabled
+func verifyToolContent(span tracetest.SpanStub) {
+	verifier.Assert(len(span.Events) == 2, "Except tool call and choice events, got %d", len(span.Events))
+	verifier.Assert(span.Events[0].Name == "gen_ai.assistant.message", "Except assistant message event, got %s", span.Events[0].Name)
+	calls := verifier.GetAttribute(span.Events[0].Attributes, "tool_calls").AsString()
+	verifier.Assert(strings.Contains(calls, `"name":"hello_world"`) && strings.Contains(calls, "abc"),
+		"Except the tool call with its arguments, got %s", calls)
+	verifier.Assert(span.Events[1].Name == "gen_ai.choice", "Except choice event, got %s", span.Events[1].Name)
+	message := verifier.GetAttribute(span.Events[1].Attributes, "message").AsString()
+	verifier.Assert(strings.Contains(message, `"role":"tool"`) && strings.Contains(message, "Hello, abc!"),
+		"Except the tool result, got %s", message)
+}
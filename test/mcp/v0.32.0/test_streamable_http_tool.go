@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func main() {
+	mcpServer := server.NewMCPServer("test", "1.0.0",
+		server.WithToolCapabilities(true),
+	)
+	tool := mcp.NewTool("hello_world",
+		mcp.WithDescription("Say hello to someone"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the person to greet"),
+		),
+	)
+	mcpServer.AddTool(tool, helloHandler)
+	testServer := server.NewTestStreamableHTTPServer(mcpServer)
+	defer testServer.Close()
+	c, err := client.NewStreamableHttpClient(testServer.URL + "/mcp")
+	if err != nil {
+		panic(err)
+	}
+	defer c.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		panic(err)
+	}
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    "example-client",
+		Version: "1.0.0",
+	}
+	if _, err = c.Initialize(ctx, initRequest); err != nil {
+		panic(err)
+	}
+
+	callRequest := mcp.CallToolRequest{}
+	callRequest.Params.Name = "hello_world"
+	callRequest.Params.Arguments = map[string]any{
+		"name": "abc",
+	}
+	if _, err = c.CallTool(ctx, callRequest); err != nil {
+		panic(err)
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		clientSpan := findMcpSpan(stubs, "execute_tool", trace.SpanKindClient)
+		serverSpan := findMcpSpan(stubs, "execute_tool", trace.SpanKindServer)
+		verifier.VerifyLLMCommonAttributes(clientSpan, "execute_tool", "mcp", trace.SpanKindClient)
+		verifier.VerifyLLMCommonAttributes(serverSpan, "execute_tool", "mcp", trace.SpanKindServer)
+		toolName := verifier.GetAttribute(serverSpan.Attributes, "gen_ai.tool.name").AsString()
+		verifier.Assert(toolName == "hello_world", "Expect gen_ai.tool.name to be hello_world, got %s", toolName)
+		// The server span is parented by the client span through params._meta
+		verifier.Assert(serverSpan.Parent.SpanID() == clientSpan.SpanContext.SpanID(),
+			"Expect server span to be the child of client span, got parent %s", serverSpan.Parent.SpanID())
+	}, 1)
+}
+
+func findMcpSpan(stubs []tracetest.SpanStubs, name string, kind trace.SpanKind) tracetest.SpanStub {
+	for _, spans := range stubs {
+		for _, span := range spans {
+			if span.Name == name && span.SpanKind == kind {
+				return span
+			}
+		}
+	}
+	verifier.Assert(false, "Expect %s span of kind %s", name, kind)
+	return tracetest.SpanStub{}
+}
@@ -26,6 +26,7 @@ func init() {
 		NewGeneralTestCase("mcp-0.20.0-sse-tool-test", mcp_module_name, "0.20.0", "0.20.0", "1.22.0", "", TestMcpTool),
 		NewGeneralTestCase("mcp-0.20.0-sse-prompt-test", mcp_module_name, "0.20.0", "0.20.0", "1.22.0", "", TestMcpPrompt),
 		NewGeneralTestCase("mcp-0.20.0-sse-resource-test", mcp_module_name, "0.20.0", "0.20.0", "1.22.0", "", TestMcpResource),
+		NewGeneralTestCase("mcp-0.32.0-streamable-http-tool-test", mcp_module_name, "0.32.0", "0.32.0", "1.23.0", "", TestMcpStreamableHttpTool),
 	)
 
 }
@@ -33,6 +34,7 @@ func init() {
 func TestMcpTool(t *testing.T, env ...string) {
 	UseApp("mcp/v0.20.0")
 	RunGoBuild(t, "go", "build", "test_sse_tool.go", "ext.go")
+	env = append(env, "OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT=true")
 	RunApp(t, "test_sse_tool", env...)
 }
 func TestMcpPrompt(t *testing.T, env ...string) {
@@ -45,6 +47,11 @@ func TestMcpResource(t *testing.T, env ...string) {
 	RunGoBuild(t, "go", "build", "test_sse_resource.go", "ext.go")
 	RunApp(t, "test_sse_resource", env...)
 }
+func TestMcpStreamableHttpTool(t *testing.T, env ...string) {
+	UseApp("mcp/v0.32.0")
+	RunGoBuild(t, "go", "build", "test_streamable_http_tool.go", "ext.go")
+	RunApp(t, "test_streamable_http_tool", env...)
+}
 
 // 由于标准输入输出通信通信无法在此处test中实现，会挂住测试进程，所以此方法只留作后续stdio可用时使用，目前不使用
 // Since standard input/output communication cannot be implemented in the test here and will cause the test process to hang, this method is reserved for future use when stdio becomes available, and is currently not used.
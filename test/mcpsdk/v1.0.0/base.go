@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type helloInput struct {
+	Name string `json:"name" jsonschema:"name of the person to greet"`
+}
+
+func helloHandler(ctx context.Context, req *mcp.CallToolRequest, input helloInput) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Hello, %s!", input.Name)}},
+	}, nil, nil
+}
+
+func readmeHandler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: req.Params.URI, Text: "This is a sample resource"}},
+	}, nil
+}
+
+func greetHandler(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return &mcp.GetPromptResult{
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: "Say hello to " + req.Params.Arguments["name"]}},
+		},
+	}, nil
+}
+
+// connect serves a server over in-memory transports, so the client and the
+// server only share the trace context carried in the request _meta.
+func connect(ctx context.Context) *mcp.ClientSession {
+	server := mcp.NewServer(&mcp.Implementation{Name: "server", Version: "v0.0.1"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "hello_world", Description: "Say hello to someone"}, helloHandler)
+	server.AddResource(&mcp.Resource{URI: "test://static/readme", Name: "readme", MIMEType: "text/plain"}, readmeHandler)
+	server.AddPrompt(&mcp.Prompt{Name: "greet", Arguments: []*mcp.PromptArgument{{Name: "name"}}}, greetHandler)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		panic(err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "v0.0.1"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		panic(err)
+	}
+	return session
+}
+
+func findMcpSpan(stubs []tracetest.SpanStubs, name string, kind trace.SpanKind) tracetest.SpanStub {
+	for _, spans := range stubs {
+		for _, span := range spans {
+			if span.Name == name && span.SpanKind == kind {
+				return span
+			}
+		}
+	}
+	verifier.Assert(false, "Expect %s span of kind %s", name, kind)
+	return tracetest.SpanStub{}
+}
+
+func verifyParent(serverSpan, clientSpan tracetest.SpanStub) {
+	verifier.Assert(serverSpan.SpanContext.TraceID() == clientSpan.SpanContext.TraceID(),
+		"Expect server span %s to share the trace of client span", serverSpan.Name)
+	verifier.Assert(serverSpan.Parent.SpanID() == clientSpan.SpanContext.SpanID(),
+		"Expect server span %s to be the child of client span, got parent %s", serverSpan.Name, serverSpan.Parent.SpanID())
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	session := connect(ctx)
+	defer session.Close()
+
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "test://static/readme"}); err != nil {
+		panic(err)
+	}
+	_, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "greet",
+		Arguments: map[string]string{"name": "abc"},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		for _, method := range []string{"resources/read", "prompts/get"} {
+			name := "execute_other:" + method
+			clientSpan := findMcpSpan(stubs, name, trace.SpanKindClient)
+			serverSpan := findMcpSpan(stubs, name, trace.SpanKindServer)
+			verifier.VerifyLLMCommonAttributes(clientSpan, name, "mcp", trace.SpanKindClient)
+			verifier.VerifyLLMCommonAttributes(serverSpan, name, "mcp", trace.SpanKindServer)
+			verifyParent(serverSpan, clientSpan)
+		}
+		uri := verifier.GetAttribute(findMcpSpan(stubs, "execute_other:resources/read", trace.SpanKindServer).Attributes, "gen_ai.other_input.resources_uri").AsString()
+		verifier.Assert(uri == "test://static/readme", "Expect resources uri to be test://static/readme, got %s", uri)
+		prompt := verifier.GetAttribute(findMcpSpan(stubs, "execute_other:prompts/get", trace.SpanKindClient).Attributes, "gen_ai.other_input.prompt_name").AsString()
+		verifier.Assert(prompt == "greet", "Expect prompt name to be greet, got %s", prompt)
+	}, 2)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	session := connect(ctx)
+	defer session.Close()
+
+	if _, err := session.ListTools(ctx, nil); err != nil {
+		panic(err)
+	}
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "hello_world",
+		Arguments: map[string]any{"name": "abc"},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		listSpan := findMcpSpan(stubs, "execute_other:tools/list", trace.SpanKindClient)
+		verifier.VerifyLLMCommonAttributes(listSpan, "execute_other:tools/list", "mcp", trace.SpanKindClient)
+		clientSpan := findMcpSpan(stubs, "execute_tool", trace.SpanKindClient)
+		serverSpan := findMcpSpan(stubs, "execute_tool", trace.SpanKindServer)
+		verifier.VerifyLLMCommonAttributes(clientSpan, "execute_tool", "mcp", trace.SpanKindClient)
+		verifier.VerifyLLMCommonAttributes(serverSpan, "execute_tool", "mcp", trace.SpanKindServer)
+		for _, span := range []tracetest.SpanStub{clientSpan, serverSpan} {
+			toolName := verifier.GetAttribute(span.Attributes, "gen_ai.tool.name").AsString()
+			verifier.Assert(toolName == "hello_world", "Expect gen_ai.tool.name to be hello_world, got %s", toolName)
+		}
+		verifyParent(serverSpan, clientSpan)
+	}, 2)
+}
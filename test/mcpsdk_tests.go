@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+const mcpsdk_dependency_name = "github.com/modelcontextprotocol/go-sdk"
+const mcpsdk_module_name = "mcpsdk"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("mcpsdk-tool-test", mcpsdk_module_name, "v1.0.0", "", "1.23.0", "", TestMcpSdkTool),
+		NewGeneralTestCase("mcpsdk-resource-test", mcpsdk_module_name, "v1.0.0", "", "1.23.0", "", TestMcpSdkResource),
+		NewLatestDepthTestCase("mcpsdk-latest-depth-test", mcpsdk_dependency_name, mcpsdk_module_name, "v1.0.0", "", "1.23.0", "", TestMcpSdkTool),
+		NewMuzzleTestCase("mcpsdk-muzzle-test-tool", mcpsdk_dependency_name, mcpsdk_module_name, "v1.0.0", "", "1.23.0", "", []string{"go", "build", "test_mcpsdk_tool.go", "base.go"}),
+	)
+}
+
+func TestMcpSdkTool(t *testing.T, env ...string) {
+	UseApp("mcpsdk/v1.0.0")
+	RunGoBuild(t, "go", "build", "test_mcpsdk_tool.go", "base.go")
+	RunApp(t, "test_mcpsdk_tool", env...)
+}
+
+func TestMcpSdkResource(t *testing.T, env ...string) {
+	UseApp("mcpsdk/v1.0.0")
+	RunGoBuild(t, "go", "build", "test_mcpsdk_resource.go", "base.go")
+	RunApp(t, "test_mcpsdk_resource", env...)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"google.golang.org/grpc"
+)
+
+const milvusAddr = "127.0.0.1:19530"
+
+// fakeMilvusService answers the service calls made by the tests, the calls
+// it does not implement panic through the embedded nil interface.
+type fakeMilvusService struct {
+	milvuspb.MilvusServiceClient
+}
+
+func success() *commonpb.Status {
+	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}
+}
+
+func int64IDs(ids ...int64) *schemapb.IDs {
+	return &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}}
+}
+
+func (f *fakeMilvusService) HasCollection(ctx context.Context, in *milvuspb.HasCollectionRequest, opts ...grpc.CallOption) (*milvuspb.BoolResponse, error) {
+	return &milvuspb.BoolResponse{Status: success(), Value: true}, nil
+}
+
+func (f *fakeMilvusService) DescribeCollection(ctx context.Context, in *milvuspb.DescribeCollectionRequest, opts ...grpc.CallOption) (*milvuspb.DescribeCollectionResponse, error) {
+	return &milvuspb.DescribeCollectionResponse{
+		Status:         success(),
+		CollectionName: in.GetCollectionName(),
+		Schema: &schemapb.CollectionSchema{
+			Name: in.GetCollectionName(),
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+				{FieldID: 101, Name: "vector", DataType: schemapb.DataType_FloatVector,
+					TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "2"}}},
+			},
+		},
+	}, nil
+}
+
+func (f *fakeMilvusService) Search(ctx context.Context, in *milvuspb.SearchRequest, opts ...grpc.CallOption) (*milvuspb.SearchResults, error) {
+	return &milvuspb.SearchResults{
+		Status: success(),
+		Results: &schemapb.SearchResultData{
+			NumQueries: 1,
+			TopK:       3,
+			Topks:      []int64{2},
+			Scores:     []float32{0.9, 0.8},
+			Ids:        int64IDs(1, 2),
+		},
+	}, nil
+}
+
+func (f *fakeMilvusService) Upsert(ctx context.Context, in *milvuspb.UpsertRequest, opts ...grpc.CallOption) (*milvuspb.MutationResult, error) {
+	return &milvuspb.MutationResult{Status: success(), IDs: int64IDs(1, 2)}, nil
+}
+
+func (f *fakeMilvusService) Delete(ctx context.Context, in *milvuspb.DeleteRequest, opts ...grpc.CallOption) (*milvuspb.MutationResult, error) {
+	return &milvuspb.MutationResult{Status: success(), IDs: int64IDs(1)}, nil
+}
+
+func newFakeMilvusClient() *client.GrpcClient {
+	// skip the connect handshake and the blocking dial, the connection is
+	// never used, it only tells the address of the server
+	c, err := client.NewClient(context.Background(), client.Config{
+		Address:     milvusAddr,
+		DialOptions: []grpc.DialOption{},
+		DisableConn: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	grpcClient := c.(*client.GrpcClient)
+	grpcClient.Service = &fakeMilvusService{}
+	return grpcClient
+}
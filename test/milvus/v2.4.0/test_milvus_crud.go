@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func main() {
+	c := newFakeMilvusClient()
+	ctx := context.Background()
+	_, err := c.Upsert(ctx, "docs", "",
+		entity.NewColumnInt64("id", []int64{1, 2}),
+		entity.NewColumnFloatVector("vector", 2, [][]float32{{0.1, 0.2}, {0.3, 0.4}}))
+	if err != nil {
+		panic(err)
+	}
+	sp, err := entity.NewIndexFlatSearchParam()
+	if err != nil {
+		panic(err)
+	}
+	results, err := c.Search(ctx, "docs", nil, "", nil,
+		[]entity.Vector{entity.FloatVector([]float32{0.1, 0.2})}, "vector", entity.L2, 3, sp)
+	if err != nil {
+		panic(err)
+	}
+	if len(results) != 1 || results[0].ResultCount != 2 {
+		panic("unexpected search result")
+	}
+	err = c.Delete(ctx, "docs", "", "id in [1]")
+	if err != nil {
+		panic(err)
+	}
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.VerifyDbAttributes(stubs[0][0], "upsert docs", "milvus", milvusAddr, "", "upsert", "docs", nil)
+		batchSize := verifier.GetAttribute(stubs[0][0].Attributes, "db.operation.batch.size").AsInt64()
+		verifier.Assert(batchSize == 2, "Expect db.operation.batch.size to be 2, got %d", batchSize)
+
+		verifier.VerifyDbAttributes(stubs[1][0], "search docs", "milvus", milvusAddr, "", "search", "docs", nil)
+		topK := verifier.GetAttribute(stubs[1][0].Attributes, "db.vector.query.top_k").AsInt64()
+		verifier.Assert(topK == 3, "Expect db.vector.query.top_k to be 3, got %d", topK)
+		returned := verifier.GetAttribute(stubs[1][0].Attributes, "db.response.returned_rows").AsInt64()
+		verifier.Assert(returned == 2, "Expect db.response.returned_rows to be 2, got %d", returned)
+
+		verifier.VerifyDbAttributes(stubs[2][0], "delete docs", "milvus", milvusAddr, "id in [1]", "delete", "docs", nil)
+	}, 3)
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+const milvus_dependency_name = "github.com/milvus-io/milvus-sdk-go/v2"
+const milvus_module_name = "milvus"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("milvus-crud-test", milvus_module_name, "v2.4.0", "", "1.23.0", "", TestMilvusCrud),
+		NewLatestDepthTestCase("milvus-latest-depth-test", milvus_dependency_name, milvus_module_name, "v2.4.0", "", "1.23.0", "", TestMilvusCrud),
+		NewMuzzleTestCase("milvus-muzzle-test", milvus_dependency_name, milvus_module_name, "v2.4.0", "", "1.23.0", "", []string{"go", "build", "test_milvus_crud.go", "base.go"}),
+	)
+}
+
+func TestMilvusCrud(t *testing.T, env ...string) {
+	UseApp("milvus/v2.4.0")
+	RunGoBuild(t, "go", "build", "test_milvus_crud.go", "base.go")
+	RunApp(t, "test_milvus_crud", env...)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func getNatsURL() string {
+	if url := os.Getenv("NATS_URL"); url != "" {
+		return url
+	}
+	return nats.DefaultURL
+}
+
+func initConn() *nats.Conn {
+	nc, err := nats.Connect(getNatsURL())
+	if err != nil {
+		panic(err)
+	}
+	return nc
+}
+
+func verifyNatsAttributes(span tracetest.SpanStub, subject, operation string, kind trace.SpanKind) {
+	verifier.Assert(span.Name == subject+" "+operation, "Expect span name to be %s, got %s", subject+" "+operation, span.Name)
+	verifier.Assert(span.SpanKind == kind, "Expect span kind to be %d, got %d", kind, span.SpanKind)
+	system := verifier.GetAttribute(span.Attributes, "messaging.system").AsString()
+	verifier.Assert(system == "nats", "Expect messaging.system to be nats, got %s", system)
+	destination := verifier.GetAttribute(span.Attributes, "messaging.destination.name").AsString()
+	verifier.Assert(destination == subject, "Expect messaging.destination.name to be %s, got %s", subject, destination)
+	optName := verifier.GetAttribute(span.Attributes, "messaging.operation.name").AsString()
+	verifier.Assert(optName == operation, "Expect messaging.operation.name to be %s, got %s", operation, optName)
+}
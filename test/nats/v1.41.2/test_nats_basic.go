@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func main() {
+	nc := initConn()
+	defer nc.Close()
+
+	// Asynchronous subscription
+	done := make(chan struct{})
+	sub, err := nc.QueueSubscribe("test.async", "test-queue", func(msg *nats.Msg) {
+		close(done)
+	})
+	if err != nil {
+		panic(err)
+	}
+	if err = nc.Publish("test.async", []byte("async")); err != nil {
+		panic(err)
+	}
+	<-done
+	_ = sub.Unsubscribe()
+
+	// Channel subscription
+	ch := make(chan *nats.Msg, 1)
+	sub, err = nc.ChanSubscribe("test.chan", ch)
+	if err != nil {
+		panic(err)
+	}
+	if err = nc.PublishMsg(&nats.Msg{Subject: "test.chan", Data: []byte("chan")}); err != nil {
+		panic(err)
+	}
+	<-ch
+	_ = sub.Unsubscribe()
+
+	// Synchronous subscription
+	sub, err = nc.SubscribeSync("test.sync")
+	if err != nil {
+		panic(err)
+	}
+	if err = nc.Publish("test.sync", []byte("sync")); err != nil {
+		panic(err)
+	}
+	if _, err = sub.NextMsg(5 * time.Second); err != nil {
+		panic(err)
+	}
+	_ = sub.Unsubscribe()
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		for i, expected := range []struct {
+			subject   string
+			operation string
+		}{
+			{"test.async", "process"},
+			{"test.chan", "receive"},
+			{"test.sync", "receive"},
+		} {
+			verifier.Assert(len(stubs[i]) == 2, "Expect 2 spans in trace %d, got %d", i, len(stubs[i]))
+			publishSpan, consumerSpan := stubs[i][0], stubs[i][1]
+			verifyNatsAttributes(publishSpan, expected.subject, "publish", trace.SpanKindProducer)
+			verifyNatsAttributes(consumerSpan, expected.subject, expected.operation, trace.SpanKindConsumer)
+			verifier.Assert(consumerSpan.Parent.SpanID() == publishSpan.SpanContext.SpanID(),
+				"Expect %s span to be child of publish span", expected.operation)
+		}
+		group := verifier.GetAttribute(stubs[0][1].Attributes, "messaging.consumer.group.name").AsString()
+		verifier.Assert(group == "test-queue", "Expect messaging.consumer.group.name to be test-queue, got %s", group)
+	}, 3)
+}
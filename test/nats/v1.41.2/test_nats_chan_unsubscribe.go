@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/nats-io/nats.go"
+)
+
+func main() {
+	nc := initConn()
+	defer nc.Close()
+
+	// Read only the first message and unsubscribe with the rest unread
+	ch := make(chan *nats.Msg)
+	sub, err := nc.ChanSubscribe("test.chan.unsubscribe", ch)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = nc.Publish("test.chan.unsubscribe", []byte("chan")); err != nil {
+			panic(err)
+		}
+	}
+	if err = nc.Flush(); err != nil {
+		panic(err)
+	}
+	<-ch
+	if err = sub.Unsubscribe(); err != nil {
+		panic(err)
+	}
+
+	// The goroutine forwarding messages to the channel must not be stuck on
+	// the unread ones, it checks the subscription every second
+	time.Sleep(3 * time.Second)
+	buf := make([]byte, 1<<20)
+	stacks := string(buf[:runtime.Stack(buf, true)])
+	verifier.Assert(!strings.Contains(stacks, "forwardChanSubscription"),
+		"Expect the forwarding goroutine to exit after unsubscribing, got %s", stacks)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func main() {
+	nc := initConn()
+	defer nc.Close()
+	js, err := nc.JetStream()
+	if err != nil {
+		panic(err)
+	}
+	if _, err = js.AddStream(&nats.StreamConfig{Name: "ORDERS", Subjects: []string{"orders.>"}}); err != nil {
+		panic(err)
+	}
+	if _, err = js.Publish("orders.created", []byte("order")); err != nil {
+		panic(err)
+	}
+	sub, err := js.PullSubscribe("orders.created", "test-durable")
+	if err != nil {
+		panic(err)
+	}
+	msgs, err := sub.Fetch(1, nats.MaxWait(5*time.Second))
+	if err != nil {
+		panic(err)
+	}
+	for _, msg := range msgs {
+		if err = msg.AckSync(); err != nil {
+			panic(err)
+		}
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		publishSpan := stubs[0][0]
+		verifyNatsAttributes(publishSpan, "orders.created", "publish", trace.SpanKindProducer)
+		verifier.Assert(len(stubs[1]) == 2, "Expect 2 spans in the fetch trace, got %d", len(stubs[1]))
+		receiveSpan, settleSpan := stubs[1][0], stubs[1][1]
+		verifyNatsAttributes(receiveSpan, "orders.created", "receive", trace.SpanKindConsumer)
+		verifier.Assert(len(receiveSpan.Links) == 1, "Expect receive span to have 1 link, got %d", len(receiveSpan.Links))
+		verifier.Assert(receiveSpan.Links[0].SpanContext.SpanID() == publishSpan.SpanContext.SpanID(),
+			"Expect receive span to link to the publish span")
+		count := verifier.GetAttribute(receiveSpan.Attributes, "messaging.batch.message_count").AsInt64()
+		verifier.Assert(count == 1, "Expect messaging.batch.message_count to be 1, got %d", count)
+		verifyNatsAttributes(settleSpan, "orders.created", "settle", trace.SpanKindClient)
+		verifier.Assert(settleSpan.Parent.SpanID() == receiveSpan.SpanContext.SpanID(),
+			"Expect settle span to be child of receive span")
+		ackType := verifier.GetAttribute(settleSpan.Attributes, "messaging.nats.message.ack_type").AsString()
+		verifier.Assert(ackType == "ack", "Expect messaging.nats.message.ack_type to be ack, got %s", ackType)
+	}, 2)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const natsModuleName = "nats"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("nats-basic-test", natsModuleName, "1.38.0", "", "1.23.0", "", TestBasicNats),
+		NewGeneralTestCase("nats-jetstream-test", natsModuleName, "1.38.0", "", "1.23.0", "", TestJetStreamNats),
+		NewGeneralTestCase("nats-chan-unsubscribe-test", natsModuleName, "1.38.0", "", "1.23.0", "", TestChanUnsubscribeNats),
+	)
+}
+
+func TestBasicNats(t *testing.T, env ...string) {
+	_, port := initNatsContainer()
+	UseApp("nats/v1.41.2")
+	RunGoBuild(t, "go", "build", "test_nats_basic.go", "base.go")
+	env = append(env, "NATS_URL=nats://127.0.0.1:"+port.Port())
+	RunApp(t, "test_nats_basic", env...)
+}
+
+func TestJetStreamNats(t *testing.T, env ...string) {
+	_, port := initNatsContainer()
+	UseApp("nats/v1.41.2")
+	RunGoBuild(t, "go", "build", "test_nats_jetstream.go", "base.go")
+	env = append(env, "NATS_URL=nats://127.0.0.1:"+port.Port())
+	RunApp(t, "test_nats_jetstream", env...)
+}
+
+func TestChanUnsubscribeNats(t *testing.T, env ...string) {
+	_, port := initNatsContainer()
+	UseApp("nats/v1.41.2")
+	RunGoBuild(t, "go", "build", "test_nats_chan_unsubscribe.go", "base.go")
+	env = append(env, "NATS_URL=nats://127.0.0.1:"+port.Port())
+	RunApp(t, "test_nats_chan_unsubscribe", env...)
+}
+
+func initNatsContainer() (testcontainers.Container, nat.Port) {
+	req := testcontainers.ContainerRequest{
+		Image:        "nats:2.10-alpine",
+		Cmd:          []string{"-js"},
+		ExposedPorts: []string{"4222/tcp"},
+		WaitingFor:   wait.ForLog("Server is ready"),
+	}
+	natsC, err := testcontainers.GenericContainer(context.Background(), testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	port, err := natsC.MappedPort(context.Background(), "4222")
+	if err != nil {
+		panic(err)
+	}
+	return natsC, port
+}
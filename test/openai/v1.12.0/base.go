@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+)
+
+const chatBody = `{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"gpt-4o-mini-2024-07-18",` +
+	`"choices":[{"index":0,"message":{"role":"assistant","content":"hello"},"finish_reason":"stop"}],` +
+	`"usage":{"prompt_tokens":9,"completion_tokens":3,"total_tokens":12}}`
+
+var chatChunks = []string{
+	`{"id":"chatcmpl-2","object":"chat.completion.chunk","created":1,"model":"gpt-4o-mini-2024-07-18","choices":[{"index":0,"delta":{"role":"assistant","content":"hel"},"finish_reason":null}]}`,
+	`{"id":"chatcmpl-2","object":"chat.completion.chunk","created":1,"model":"gpt-4o-mini-2024-07-18","choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]}`,
+	`{"id":"chatcmpl-2","object":"chat.completion.chunk","created":1,"model":"gpt-4o-mini-2024-07-18","choices":[],"usage":{"prompt_tokens":9,"completion_tokens":2,"total_tokens":11}}`,
+}
+
+const embeddingBody = `{"object":"list","data":[{"object":"embedding","index":0,"embedding":[0.1,0.2]}],` +
+	`"model":"text-embedding-3-small","usage":{"prompt_tokens":5,"total_tokens":5}}`
+
+const responseBody = `{"id":"resp_1","object":"response","created_at":1,"status":"completed","model":"gpt-4o-mini-2024-07-18",` +
+	`"output":[{"type":"message","id":"msg_1","status":"completed","role":"assistant","content":[{"type":"output_text","text":"hello","annotations":[]}]}],` +
+	`"usage":{"input_tokens":7,"input_tokens_details":{"cached_tokens":0},"output_tokens":4,"output_tokens_details":{"reasoning_tokens":0},"total_tokens":11}}`
+
+var responseEvents = [][2]string{
+	{"response.created", `{"type":"response.created","sequence_number":0,"response":{"id":"resp_2","object":"response","created_at":1,"status":"in_progress","model":"gpt-4o-mini-2024-07-18","output":[]}}`},
+	{"response.output_text.delta", `{"type":"response.output_text.delta","sequence_number":1,"item_id":"msg_1","output_index":0,"content_index":0,"delta":"hello"}`},
+	{"response.completed", `{"type":"response.completed","sequence_number":2,"response":{"id":"resp_2","object":"response","created_at":1,"status":"completed","model":"gpt-4o-mini-2024-07-18","output":[],` +
+		`"usage":{"input_tokens":7,"input_tokens_details":{"cached_tokens":0},"output_tokens":1,"output_tokens_details":{"reasoning_tokens":0},"total_tokens":8}}}`},
+}
+
+// newMockClient starts an OpenAI compatible stand-in server and returns a
+// client talking to it.
+func newMockClient() (openai.Client, func()) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body strings.Builder
+		buf := make([]byte, 4096)
+		for {
+			n, err := r.Body.Read(buf)
+			body.Write(buf[:n])
+			if err != nil {
+				break
+			}
+		}
+		stream := strings.Contains(body.String(), `"stream":true`)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/chat/completions") && stream:
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, chunk := range chatChunks {
+				fmt.Fprintf(w, "data: %s\n\n", chunk)
+			}
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		case strings.HasSuffix(r.URL.Path, "/chat/completions"):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, chatBody)
+		case strings.HasSuffix(r.URL.Path, "/embeddings"):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, embeddingBody)
+		case strings.HasSuffix(r.URL.Path, "/responses") && stream:
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, event := range responseEvents {
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event[0], event[1])
+			}
+		case strings.HasSuffix(r.URL.Path, "/responses"):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, responseBody)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := openai.NewClient(option.WithAPIKey("token"), option.WithBaseURL(ts.URL+"/v1/"), option.WithMaxRetries(0))
+	return client, ts.Close
+}
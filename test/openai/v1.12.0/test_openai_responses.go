@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/responses"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func main() {
+	client, closeServer := newMockClient()
+	defer closeServer()
+	ctx := context.Background()
+	params := responses.ResponseNewParams{
+		Model:           "gpt-4o-mini",
+		Input:           responses.ResponseNewParamsInputUnion{OfString: openai.String("hi")},
+		MaxOutputTokens: openai.Int(32),
+	}
+	_, err := client.Responses.New(ctx, params)
+	if err != nil {
+		panic(err)
+	}
+	stream := client.Responses.NewStreaming(ctx, params)
+	for stream.Next() {
+	}
+	if stream.Err() != nil {
+		panic(stream.Err())
+	}
+	stream.Close()
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		for i, id := range []string{"resp_1", "resp_2"} {
+			verifier.VerifyLLMAttributes(stubs[i][0], "chat", "openai", "gpt-4o-mini")
+			verifier.Assert(verifier.GetAttribute(stubs[i][0].Attributes, "gen_ai.response.id").AsString() == id, "Except response id %s", id)
+			verifier.Assert(verifier.GetAttribute(stubs[i][0].Attributes, "gen_ai.request.max_tokens").AsInt64() == 32, "Except max tokens to be 32")
+			verifier.Assert(verifier.GetAttribute(stubs[i][0].Attributes, "gen_ai.usage.input_tokens").AsInt64() == 7, "Except 7 input tokens")
+			finishReasons := verifier.GetAttribute(stubs[i][0].Attributes, "gen_ai.response.finish_reasons").AsStringSlice()
+			verifier.Assert(len(finishReasons) == 1 && finishReasons[0] == "completed", "Except status completed, got %v", finishReasons)
+		}
+	}, 2)
+}
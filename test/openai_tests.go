@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+const openai_dependency_name = "github.com/openai/openai-go"
+const openai_module_name = "openai"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("openai-chat-test", openai_module_name, "v1.0.0", "", "1.23.0", "", TestChatOpenAI),
+		NewGeneralTestCase("openai-responses-test", openai_module_name, "v1.0.0", "", "1.23.0", "", TestResponsesOpenAI),
+		NewLatestDepthTestCase("openai-latest-depth-test", openai_dependency_name, openai_module_name, "v1.0.0", "", "1.23.0", "", TestChatOpenAI),
+		NewMuzzleTestCase("openai-muzzle-test-chat", openai_dependency_name, openai_module_name, "v1.0.0", "", "1.23.0", "", []string{"go", "build", "test_openai_chat.go", "base.go"}),
+	)
+}
+
+func TestChatOpenAI(t *testing.T, env ...string) {
+	UseApp("openai/v1.12.0")
+	RunGoBuild(t, "go", "build", "test_openai_chat.go", "base.go")
+	RunApp(t, "test_openai_chat", env...)
+}
+
+func TestResponsesOpenAI(t *testing.T, env ...string) {
+	UseApp("openai/v1.12.0")
+	RunGoBuild(t, "go", "build", "test_openai_responses.go", "base.go")
+	RunApp(t, "test_openai_responses", env...)
+}
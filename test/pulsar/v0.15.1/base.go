@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/apache/pulsar-client-go/pulsar"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func getPulsarURL() string {
+	if url := os.Getenv("PULSAR_URL"); url != "" {
+		return url
+	}
+	return "pulsar://127.0.0.1:6650"
+}
+
+func initClient() pulsar.Client {
+	client, err := pulsar.NewClient(pulsar.ClientOptions{URL: getPulsarURL()})
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+func verifyPulsarAttributes(span tracetest.SpanStub, topic, operation string, kind trace.SpanKind) {
+	verifier.Assert(span.Name == topic+" "+operation, "Expect span name to be %s, got %s", topic+" "+operation, span.Name)
+	verifier.Assert(span.SpanKind == kind, "Expect span kind to be %d, got %d", kind, span.SpanKind)
+	system := verifier.GetAttribute(span.Attributes, "messaging.system").AsString()
+	verifier.Assert(system == "pulsar", "Expect messaging.system to be pulsar, got %s", system)
+	destination := verifier.GetAttribute(span.Attributes, "messaging.destination.name").AsString()
+	verifier.Assert(destination == topic, "Expect messaging.destination.name to be %s, got %s", topic, destination)
+	optName := verifier.GetAttribute(span.Attributes, "messaging.operation.name").AsString()
+	verifier.Assert(optName == operation, "Expect messaging.operation.name to be %s, got %s", operation, optName)
+}
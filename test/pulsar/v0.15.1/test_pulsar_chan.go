@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/apache/pulsar-client-go/pulsar"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const chanTopic = "persistent://public/default/test-chan-topic"
+
+func main() {
+	client := initClient()
+	defer client.Close()
+	consumer, err := client.Subscribe(pulsar.ConsumerOptions{
+		Topic:                       chanTopic,
+		SubscriptionName:            "test-chan-subscription",
+		SubscriptionInitialPosition: pulsar.SubscriptionPositionEarliest,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer consumer.Close()
+	producer, err := client.CreateProducer(pulsar.ProducerOptions{Topic: chanTopic})
+	if err != nil {
+		panic(err)
+	}
+	defer producer.Close()
+	sent := make(chan error, 1)
+	producer.SendAsync(context.Background(), &pulsar.ProducerMessage{Payload: []byte("hello pulsar")},
+		func(id pulsar.MessageID, msg *pulsar.ProducerMessage, err error) {
+			sent <- err
+		})
+	if err = <-sent; err != nil {
+		panic(err)
+	}
+	select {
+	case cm := <-consumer.Chan():
+		consumer.Nack(cm.Message)
+	case <-time.After(30 * time.Second):
+		panic("timeout waiting for the message")
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.Assert(len(stubs[0]) == 3, "Expect 3 spans in the trace, got %d", len(stubs[0]))
+		publishSpan, receiveSpan, settleSpan := stubs[0][0], stubs[0][1], stubs[0][2]
+		verifyPulsarAttributes(publishSpan, chanTopic, "publish", trace.SpanKindProducer)
+		verifyPulsarAttributes(receiveSpan, chanTopic, "receive", trace.SpanKindConsumer)
+		verifier.Assert(receiveSpan.Parent.SpanID() == publishSpan.SpanContext.SpanID(),
+			"Expect receive span to be child of publish span")
+		verifyPulsarAttributes(settleSpan, chanTopic, "settle", trace.SpanKindClient)
+		verifier.Assert(settleSpan.Parent.SpanID() == receiveSpan.SpanContext.SpanID(),
+			"Expect settle span to be child of receive span")
+		ackType := verifier.GetAttribute(settleSpan.Attributes, "messaging.pulsar.message.ack_type").AsString()
+		verifier.Assert(ackType == "nack", "Expect messaging.pulsar.message.ack_type to be nack, got %s", ackType)
+	}, 1)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/apache/pulsar-client-go/pulsar"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const receiveTopic = "persistent://public/default/test-receive-topic"
+
+func main() {
+	client := initClient()
+	defer client.Close()
+	consumer, err := client.Subscribe(pulsar.ConsumerOptions{
+		Topic:                       receiveTopic,
+		SubscriptionName:            "test-receive-subscription",
+		SubscriptionInitialPosition: pulsar.SubscriptionPositionEarliest,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer consumer.Close()
+	producer, err := client.CreateProducer(pulsar.ProducerOptions{Topic: receiveTopic})
+	if err != nil {
+		panic(err)
+	}
+	defer producer.Close()
+	id, err := producer.Send(context.Background(), &pulsar.ProducerMessage{Payload: []byte("hello pulsar")})
+	if err != nil {
+		panic(err)
+	}
+	msg, err := consumer.Receive(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	if err = consumer.Ack(msg); err != nil {
+		panic(err)
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.Assert(len(stubs[0]) == 3, "Expect 3 spans in the trace, got %d", len(stubs[0]))
+		publishSpan, receiveSpan, settleSpan := stubs[0][0], stubs[0][1], stubs[0][2]
+		verifyPulsarAttributes(publishSpan, receiveTopic, "publish", trace.SpanKindProducer)
+		msgId := verifier.GetAttribute(publishSpan.Attributes, "messaging.message.id").AsString()
+		verifier.Assert(msgId == id.String(), "Expect messaging.message.id to be %s, got %s", id.String(), msgId)
+		verifyPulsarAttributes(receiveSpan, receiveTopic, "receive", trace.SpanKindConsumer)
+		verifier.Assert(receiveSpan.Parent.SpanID() == publishSpan.SpanContext.SpanID(),
+			"Expect receive span to be child of publish span")
+		subscription := verifier.GetAttribute(receiveSpan.Attributes, "messaging.destination.subscription.name").AsString()
+		verifier.Assert(subscription == "test-receive-subscription",
+			"Expect messaging.destination.subscription.name to be test-receive-subscription, got %s", subscription)
+		verifyPulsarAttributes(settleSpan, receiveTopic, "settle", trace.SpanKindClient)
+		verifier.Assert(settleSpan.Parent.SpanID() == receiveSpan.SpanContext.SpanID(),
+			"Expect settle span to be child of receive span")
+		ackType := verifier.GetAttribute(settleSpan.Attributes, "messaging.pulsar.message.ack_type").AsString()
+		verifier.Assert(ackType == "ack", "Expect messaging.pulsar.message.ack_type to be ack, got %s", ackType)
+	}, 1)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const pulsarModuleName = "pulsar"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("pulsar-receive-test", pulsarModuleName, "0.10.0", "", "1.23.0", "", TestReceivePulsar),
+		NewGeneralTestCase("pulsar-chan-test", pulsarModuleName, "0.10.0", "", "1.23.0", "", TestChanPulsar),
+	)
+}
+
+func TestReceivePulsar(t *testing.T, env ...string) {
+	_, port := initPulsarContainer()
+	UseApp("pulsar/v0.15.1")
+	RunGoBuild(t, "go", "build", "test_pulsar_receive.go", "base.go")
+	env = append(env, "PULSAR_URL=pulsar://127.0.0.1:"+port.Port())
+	RunApp(t, "test_pulsar_receive", env...)
+}
+
+func TestChanPulsar(t *testing.T, env ...string) {
+	_, port := initPulsarContainer()
+	UseApp("pulsar/v0.15.1")
+	RunGoBuild(t, "go", "build", "test_pulsar_chan.go", "base.go")
+	env = append(env, "PULSAR_URL=pulsar://127.0.0.1:"+port.Port())
+	RunApp(t, "test_pulsar_chan", env...)
+}
+
+func initPulsarContainer() (testcontainers.Container, nat.Port) {
+	req := testcontainers.ContainerRequest{
+		Image:        "apachepulsar/pulsar:3.3.2",
+		Cmd:          []string{"bin/pulsar", "standalone", "--no-functions-worker", "--no-stream-storage"},
+		ExposedPorts: []string{"6650/tcp", "8080/tcp"},
+		WaitingFor: wait.ForAll(
+			wait.ForHTTP("/admin/v2/clusters").WithPort("8080/tcp"),
+			wait.ForLog("Successfully updated the policies on namespace public/default"),
+		).WithDeadline(120 * time.Second),
+	}
+	pulsarC, err := testcontainers.GenericContainer(context.Background(), testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	port, err := pulsarC.MappedPort(context.Background(), "6650")
+	if err != nil {
+		panic(err)
+	}
+	return pulsarC, port
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/qdrant/go-client/qdrant"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// startFakeQdrant serves the points service methods used by the tests, as
+// the client does not ship the server side stubs.
+func startFakeQdrant() (*qdrant.Client, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	server := grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		var req, resp proto.Message
+		switch method {
+		case "/qdrant.Points/Query":
+			req = &qdrant.QueryPoints{}
+			resp = &qdrant.QueryResponse{Result: []*qdrant.ScoredPoint{
+				{Id: qdrant.NewIDNum(1), Score: 0.9},
+				{Id: qdrant.NewIDNum(2), Score: 0.8},
+			}}
+		case "/qdrant.Points/Upsert":
+			req = &qdrant.UpsertPoints{}
+			resp = &qdrant.PointsOperationResponse{Result: &qdrant.UpdateResult{Status: qdrant.UpdateStatus_Completed}}
+		case "/qdrant.Points/Delete":
+			req = &qdrant.DeletePoints{}
+			resp = &qdrant.PointsOperationResponse{Result: &qdrant.UpdateResult{Status: qdrant.UpdateStatus_Completed}}
+		default:
+			return fmt.Errorf("unexpected method %s", method)
+		}
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		return stream.SendMsg(resp)
+	}))
+	go server.Serve(lis)
+	addr := lis.Addr().(*net.TCPAddr)
+	client, err := qdrant.NewClient(&qdrant.Config{Host: addr.IP.String(), Port: addr.Port})
+	if err != nil {
+		panic(err)
+	}
+	return client, addr.String()
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/qdrant/go-client/qdrant"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func main() {
+	client, addr := startFakeQdrant()
+	defer client.Close()
+	ctx := context.Background()
+	_, err := client.Upsert(ctx, &qdrant.UpsertPoints{
+		CollectionName: "docs",
+		Points: []*qdrant.PointStruct{
+			{Id: qdrant.NewIDNum(1), Vectors: qdrant.NewVectors(0.1, 0.2)},
+			{Id: qdrant.NewIDNum(2), Vectors: qdrant.NewVectors(0.3, 0.4)},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	limit := uint64(3)
+	points, err := client.Query(ctx, &qdrant.QueryPoints{
+		CollectionName: "docs",
+		Query:          qdrant.NewQuery(0.1, 0.2),
+		Limit:          &limit,
+	})
+	if err != nil {
+		panic(err)
+	}
+	if len(points) != 2 {
+		panic("unexpected query result")
+	}
+	_, err = client.Delete(ctx, &qdrant.DeletePoints{
+		CollectionName: "docs",
+		Points:         qdrant.NewPointsSelector(qdrant.NewIDNum(1)),
+	})
+	if err != nil {
+		panic(err)
+	}
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		// newer clients also check the server version on their own
+		var traces []tracetest.SpanStubs
+		for _, stub := range stubs {
+			if verifier.GetAttribute(stub[0].Attributes, "db.system.name").AsString() == "qdrant" {
+				traces = append(traces, stub)
+			}
+		}
+		verifier.Assert(len(traces) == 3, "Expect 3 qdrant traces, got %d", len(traces))
+		verifier.VerifyDbAttributes(traces[0][0], "upsert docs", "qdrant", addr, "", "upsert", "docs", nil)
+		batchSize := verifier.GetAttribute(traces[0][0].Attributes, "db.operation.batch.size").AsInt64()
+		verifier.Assert(batchSize == 2, "Expect db.operation.batch.size to be 2, got %d", batchSize)
+
+		verifier.VerifyDbAttributes(traces[1][0], "search docs", "qdrant", addr, "", "search", "docs", nil)
+		topK := verifier.GetAttribute(traces[1][0].Attributes, "db.vector.query.top_k").AsInt64()
+		verifier.Assert(topK == 3, "Expect db.vector.query.top_k to be 3, got %d", topK)
+		returned := verifier.GetAttribute(traces[1][0].Attributes, "db.response.returned_rows").AsInt64()
+		verifier.Assert(returned == 2, "Expect db.response.returned_rows to be 2, got %d", returned)
+
+		verifier.VerifyDbAttributes(traces[2][0], "delete docs", "qdrant", addr, "", "delete", "docs", nil)
+		// the grpc call is made within the qdrant span
+		verifier.Assert(len(traces[2]) > 1 && traces[2][1].Parent.SpanID() == traces[2][0].SpanContext.SpanID(), "Expect the grpc span to be a child of the qdrant span")
+	}, 3)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/qdrant/go-client/qdrant"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func main() {
+	client, addr := startFakeQdrant()
+	defer client.Close()
+	limit := uint64(3)
+	_, err := client.Query(context.Background(), &qdrant.QueryPoints{
+		CollectionName: "docs",
+		Query:          qdrant.NewQuery(0.1, 0.2),
+		Limit:          &limit,
+	})
+	if err != nil {
+		panic(err)
+	}
+	verifier.WaitAndAssertMetrics(map[string]func(metricdata.ResourceMetrics){
+		"db.client.request.duration": func(mrs metricdata.ResourceMetrics) {
+			if len(mrs.ScopeMetrics) <= 0 {
+				panic("No db.client.request.duration metrics received!")
+			}
+			point := mrs.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+			if point.DataPoints[0].Count <= 0 {
+				panic("db.client.request.duration metrics count is not positive, actually " + strconv.Itoa(int(point.DataPoints[0].Count)))
+			}
+			verifier.VerifyDbMetricsAttributes(point.DataPoints[0].Attributes.ToSlice(), "qdrant", "search", addr)
+		},
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+const qdrant_dependency_name = "github.com/qdrant/go-client"
+const qdrant_module_name = "qdrant"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("qdrant-crud-test", qdrant_module_name, "v1.12.0", "", "1.23.0", "", TestQdrantCrud),
+		NewGeneralTestCase("qdrant-metrics-test", qdrant_module_name, "v1.12.0", "", "1.23.0", "", TestQdrantMetrics),
+		NewLatestDepthTestCase("qdrant-latest-depth-test", qdrant_dependency_name, qdrant_module_name, "v1.12.0", "", "1.23.0", "", TestQdrantCrud),
+		NewMuzzleTestCase("qdrant-muzzle-test", qdrant_dependency_name, qdrant_module_name, "v1.12.0", "", "1.23.0", "", []string{"go", "build", "test_qdrant_crud.go", "base.go"}),
+	)
+}
+
+func TestQdrantCrud(t *testing.T, env ...string) {
+	UseApp("qdrant/v1.12.0")
+	RunGoBuild(t, "go", "build", "test_qdrant_crud.go", "base.go")
+	RunApp(t, "test_qdrant_crud", env...)
+}
+
+func TestQdrantMetrics(t *testing.T, env ...string) {
+	UseApp("qdrant/v1.12.0")
+	RunGoBuild(t, "go", "build", "test_qdrant_metrics.go", "base.go")
+	RunApp(t, "test_qdrant_metrics", env...)
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rate is a local replacement of golang.org/x/time/rate, which has no
+// version in its path.
+package rate
+
+import "time"
+
+type Limit float64
+
+func Every(interval time.Duration) Limit {
+	if interval <= 0 {
+		return Limit(0)
+	}
+	return 1 / Limit(interval.Seconds())
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/zip"
+)
+
+const ReplacedAppName = "replaced"
+
+func TestBuildReplacedModule(t *testing.T) {
+	UseApp(ReplacedAppName)
+
+	// golang.org/x/time is replaced by a local directory without version, its
+	// version is the one required by go.mod, i.e. v0.5.0
+	RunSet(t, UseTestRules("test_fmt.json"), "-replace-policy=")
+	RunGoBuild(t, "go", "build", "-a")
+	_, stderr := RunApp(t, ReplacedAppName)
+	ExpectContains(t, stderr, "GOOD")
+	ExpectContains(t, stderr, "GCMG")
+	ExpectContains(t, stderr, "BYD")
+	ExpectNotContains(t, stderr, "BAD")
+
+	// replaced modules match any version range
+	RunSet(t, UseTestRules("test_fmt.json"), "-replace-policy=match")
+	RunGoBuild(t, "go", "build", "-a")
+	_, stderr = RunApp(t, ReplacedAppName)
+	ExpectContains(t, stderr, "BAD")
+
+	// unknown policy is rejected
+	path := filepath.Join(filepath.Dir(pwd), getExecName())
+	err := runCmd([]string{path, "set", "-replace-policy=latest"}).Run()
+	if err == nil {
+		t.Fatal("expecting unknown replace policy to be rejected")
+	}
+}
+
+// forkProxy serves the module in src as path@version from a file based module
+// proxy, and returns the GOPROXY to use it
+func forkProxy(t *testing.T, path, version, src string) string {
+	dir := t.TempDir()
+	srcDir := t.TempDir()
+	err := util.CopyDir(src, srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gomod := fmt.Sprintf("module %s\n\ngo 1.18\n", path)
+	versionDir := filepath.Join(dir, path, "@v")
+	err = os.MkdirAll(versionDir, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(srcDir, "go.mod"):            gomod,
+		filepath.Join(versionDir, "list"):          version + "\n",
+		filepath.Join(versionDir, version+".mod"):  gomod,
+		filepath.Join(versionDir, version+".info"): `{"Version":"` + version + `"}`,
+	}
+	for name, content := range files {
+		_, err = util.WriteFile(name, content)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := os.Create(filepath.Join(versionDir, version+".zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	err = zip.CreateFromDir(f, module.Version{Path: path, Version: version},
+		srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "file://" + filepath.ToSlash(dir)
+}
+
+func TestBuildForkedModule(t *testing.T) {
+	UseApp(ReplacedAppName)
+
+	// golang.org/x/time is replaced by a fork with another module path, whose
+	// v0.7.0 has nothing to do with v0.7.0 of golang.org/x/time
+	proxy := forkProxy(t, "example.com/time", "v0.7.0", "time")
+	out, err := exec.Command("go", "env", "GOPROXY").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	envs := []string{
+		"GOPROXY=" + proxy + "," + strings.TrimSpace(string(out)),
+		"GONOSUMDB=example.com",
+	}
+	err = os.Chdir(copyProject(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"mod", "edit", "-replace=golang.org/x/time=example.com/time@v0.7.0"},
+		{"mod", "tidy"},
+	} {
+		cmd := exec.Command("go", args...)
+		cmd.Env = append(os.Environ(), envs...)
+		out, err = cmd.CombinedOutput()
+		if err != nil {
+			t.Fatal(string(out), err)
+		}
+	}
+
+	// The version of the original requirement, i.e. v0.5.0, is used
+	RunSet(t, UseTestRules("test_fmt.json"), "-replace-policy=")
+	RunGoBuildWithEnv(t, envs, "go", "build", "-a")
+	_, stderr := RunApp(t, ReplacedAppName)
+	ExpectContains(t, stderr, "GOOD")
+	ExpectContains(t, stderr, "GCMG")
+	ExpectContains(t, stderr, "BYD")
+	ExpectNotContains(t, stderr, "BAD")
+}
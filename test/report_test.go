@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/resource"
+)
+
+func runReport(t *testing.T, args ...string) {
+	path := filepath.Join(filepath.Dir(pwd), getExecName())
+	cmd := runCmd(append([]string{path, "report"}, args...))
+	err := cmd.Run()
+	if err != nil {
+		t.Fatal(err, readStderrLog(t))
+	}
+}
+
+func findPackageReport(t *testing.T, report *resource.Report,
+	importPath string) *resource.PackageReport {
+	for _, pkg := range report.Packages {
+		if pkg.ImportPath == importPath {
+			return pkg
+		}
+	}
+	t.Fatalf("package %s is not reported", importPath)
+	return nil
+}
+
+func TestReport(t *testing.T) {
+	UseApp(HelloworldAppName)
+
+	RunSet(t, UseTestRules("test_fmt.json"))
+	RunGoBuild(t, "go", "build")
+
+	runReport(t, "-format=json")
+	report := &resource.Report{}
+	err := json.Unmarshal([]byte(readStdoutLog(t)), report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmtReport := findPackageReport(t, report, "fmt")
+	hooks := make([]string, 0)
+	for _, rr := range fmtReport.Applied {
+		hooks = append(hooks, rr.Hook)
+	}
+	ExpectContainsAllItem(t, hooks,
+		"github.com/alibaba/loongsuite-go-agent/pkg/rules/test/fmt1")
+	reasons := make([]string, 0)
+	for _, rr := range fmtReport.Skipped {
+		reasons = append(reasons, rr.Reason)
+	}
+	ExpectContainsAllItem(t, reasons, "struct MyPoint is not found")
+
+	// rules out of the version range are skipped
+	rateReport := findPackageReport(t, report, "golang.org/x/time/rate")
+	if len(rateReport.Skipped) == 0 {
+		t.Fatal("expecting skipped rules of golang.org/x/time/rate")
+	}
+	ExpectContains(t, rateReport.Skipped[0].Reason, "is out of range")
+
+	runReport(t)
+	ExpectStdoutContains(t, "applied  func")
+	ExpectStdoutContains(t, "Coverage:")
+
+	runReport(t, "-format=html")
+	ExpectStdoutContains(t, "<h2>fmt</h2>")
+
+	// disabled rules are reported for packages in the build
+	RunSet(t, "-disable=all", UseTestRules("test_fmt.json"))
+	RunGoBuild(t, "go", "build")
+	runReport(t)
+	ExpectStdoutContains(t, "disabled by grpc.json")
+
+	// rules targeting cgo files are skipped as well
+	RunSet(t, UseTestRules("test_cgo.json"))
+	RunGoBuildWithEnv(t, []string{"CGO_ENABLED=1"}, "go", "build")
+	runReport(t, "-format=json")
+	report = &resource.Report{}
+	err = json.Unmarshal([]byte(readStdoutLog(t)), report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	netReport := findPackageReport(t, report, "net")
+	for _, rr := range netReport.Applied {
+		if rr.Target == "_C_free" {
+			t.Fatal("expecting rule of cgo file to be skipped")
+		}
+	}
+	reasons = make([]string, 0)
+	for _, rr := range netReport.Skipped {
+		reasons = append(reasons, rr.Reason)
+	}
+	ExpectContains(t, strings.Join(reasons, "\n"),
+		"cgo_unix_cgo.go is not instrumented")
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+)
+
+func hashFile(t *testing.T, path string) string {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:])
+}
+
+// copyProject copies the sources of the project in the current directory to a
+// sibling directory, so that relative replace directives still resolve
+func copyProject(t *testing.T) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := os.MkdirTemp(filepath.Dir(cwd), filepath.Base(cwd)+"-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range append(files, "go.mod", "go.sum") {
+		err = util.CopyFile(file, filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestReproducibleBuild(t *testing.T) {
+	UseApp(HelloworldAppName)
+	copied := copyProject(t)
+	// Configure both builds in full, settings left by other tests must not
+	// tell them apart
+	settings := []string{UseTestRules("test_fmt.json"), "-disable=",
+		"-replace-policy="}
+
+	RunSet(t, settings...)
+	// Force rebuilding all packages so that the build instruments the code from
+	// scratch rather than reusing the build cache
+	RunGoBuild(t, "go", "build", "-a", "-trimpath")
+	first := hashFile(t, HelloworldAppName)
+	stdout, _ := RunApp(t, HelloworldAppName)
+	ExpectContains(t, stdout, "olleH")
+
+	// Build the same project from another directory with an empty build cache,
+	// neither of them should leak into the binary
+	err := os.Chdir(copied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	RunSet(t, settings...)
+	RunGoBuildWithEnv(t, []string{"GOCACHE=" + t.TempDir()},
+		"go", "build", "-trimpath")
+	second := hashFile(t, HelloworldAppName)
+	ExpectSame(t, first, second)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/admin"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"github.com/apache/rocketmq-client-go/v2/producer"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const producerGroup = "test-producer-group"
+
+func getNameServer() string {
+	if addr := os.Getenv("ROCKETMQ_NAMESRV"); addr != "" {
+		return addr
+	}
+	return "127.0.0.1:9876"
+}
+
+func getBrokerAddr() string {
+	if addr := os.Getenv("ROCKETMQ_BROKER"); addr != "" {
+		return addr
+	}
+	return "127.0.0.1:10911"
+}
+
+func createTopic(topic string) {
+	mqAdmin, err := admin.NewAdmin(admin.WithResolver(primitive.NewPassthroughResolver([]string{getNameServer()})))
+	if err != nil {
+		panic(err)
+	}
+	defer mqAdmin.Close()
+	err = mqAdmin.CreateTopic(context.Background(), admin.WithTopicCreate(topic),
+		admin.WithBrokerAddrCreate(getBrokerAddr()))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func initProducer() rocketmq.Producer {
+	p, err := rocketmq.NewProducer(
+		producer.WithNsResolver(primitive.NewPassthroughResolver([]string{getNameServer()})),
+		producer.WithGroupName(producerGroup),
+		producer.WithRetry(2),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err = p.Start(); err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func verifyRocketMQAttributes(span tracetest.SpanStub, topic, operation, group string, kind trace.SpanKind) {
+	verifier.Assert(span.Name == topic+" "+operation, "Expect span name to be %s, got %s", topic+" "+operation, span.Name)
+	verifier.Assert(span.SpanKind == kind, "Expect span kind to be %d, got %d", kind, span.SpanKind)
+	system := verifier.GetAttribute(span.Attributes, "messaging.system").AsString()
+	verifier.Assert(system == "rocketmq", "Expect messaging.system to be rocketmq, got %s", system)
+	destination := verifier.GetAttribute(span.Attributes, "messaging.destination.name").AsString()
+	verifier.Assert(destination == topic, "Expect messaging.destination.name to be %s, got %s", topic, destination)
+	optName := verifier.GetAttribute(span.Attributes, "messaging.operation.name").AsString()
+	verifier.Assert(optName == operation, "Expect messaging.operation.name to be %s, got %s", operation, optName)
+	clientGroup := verifier.GetAttribute(span.Attributes, "messaging.rocketmq.client_group").AsString()
+	verifier.Assert(clientGroup == group, "Expect messaging.rocketmq.client_group to be %s, got %s", group, clientGroup)
+}
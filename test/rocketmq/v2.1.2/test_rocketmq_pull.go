@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	pullTopic         = "test-pull-topic"
+	pullConsumerGroup = "test-pull-consumer-group"
+)
+
+func main() {
+	createTopic(pullTopic)
+	p := initProducer()
+	defer p.Shutdown()
+	sent := make(chan error, 1)
+	err := p.SendAsync(context.Background(), func(ctx context.Context, result *primitive.SendResult, err error) {
+		sent <- err
+	}, primitive.NewMessage(pullTopic, []byte("hello rocketmq")))
+	if err != nil {
+		panic(err)
+	}
+	if err = <-sent; err != nil {
+		panic(err)
+	}
+
+	c, err := rocketmq.NewPullConsumer(
+		consumer.WithNsResolver(primitive.NewPassthroughResolver([]string{getNameServer()})),
+		consumer.WithGroupName(pullConsumerGroup),
+		consumer.WithConsumeFromWhere(consumer.ConsumeFromFirstOffset),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err = c.Subscribe(pullTopic, consumer.MessageSelector{}); err != nil {
+		panic(err)
+	}
+	if err = c.Start(); err != nil {
+		panic(err)
+	}
+	defer c.Shutdown()
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		cr, err := c.Poll(context.Background(), time.Second)
+		if err == nil {
+			c.ACK(context.Background(), cr, consumer.ConsumeSuccess)
+			break
+		}
+		if time.Now().After(deadline) {
+			panic("timeout waiting for the polled message")
+		}
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		publishSpan := stubs[0][0]
+		verifyRocketMQAttributes(publishSpan, pullTopic, "publish", producerGroup, trace.SpanKindProducer)
+		receiveSpan := stubs[1][0]
+		verifyRocketMQAttributes(receiveSpan, pullTopic, "receive", pullConsumerGroup, trace.SpanKindConsumer)
+		verifier.Assert(len(receiveSpan.Links) == 1, "Expect receive span to have 1 link, got %d", len(receiveSpan.Links))
+		verifier.Assert(receiveSpan.Links[0].SpanContext.SpanID() == publishSpan.SpanContext.SpanID(),
+			"Expect receive span to link to the publish span")
+		count := verifier.GetAttribute(receiveSpan.Attributes, "messaging.batch.message_count").AsInt64()
+		verifier.Assert(count == 1, "Expect messaging.batch.message_count to be 1, got %d", count)
+	}, 2)
+}
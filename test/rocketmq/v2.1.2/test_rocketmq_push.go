@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	pushTopic         = "test-push-topic"
+	pushConsumerGroup = "test-push-consumer-group"
+)
+
+func main() {
+	createTopic(pushTopic)
+	received := make(chan struct{}, 1)
+	c, err := rocketmq.NewPushConsumer(
+		consumer.WithNsResolver(primitive.NewPassthroughResolver([]string{getNameServer()})),
+		consumer.WithGroupName(pushConsumerGroup),
+		consumer.WithConsumeFromWhere(consumer.ConsumeFromFirstOffset),
+	)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Subscribe(pushTopic, consumer.MessageSelector{}, func(ctx context.Context,
+		msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+		return consumer.ConsumeSuccess, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	if err = c.Start(); err != nil {
+		panic(err)
+	}
+	defer c.Shutdown()
+
+	p := initProducer()
+	defer p.Shutdown()
+	msg := primitive.NewMessage(pushTopic, []byte("hello rocketmq")).WithTag("TagA").WithKeys([]string{"order-1"})
+	result, err := p.SendSync(context.Background(), msg)
+	if err != nil {
+		panic(err)
+	}
+	select {
+	case <-received:
+	case <-time.After(30 * time.Second):
+		panic("timeout waiting for the pushed message")
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.Assert(len(stubs[0]) == 2, "Expect 2 spans in the trace, got %d", len(stubs[0]))
+		publishSpan, processSpan := stubs[0][0], stubs[0][1]
+		verifyRocketMQAttributes(publishSpan, pushTopic, "publish", producerGroup, trace.SpanKindProducer)
+		msgId := verifier.GetAttribute(publishSpan.Attributes, "messaging.message.id").AsString()
+		verifier.Assert(msgId == result.MsgID, "Expect messaging.message.id to be %s, got %s", result.MsgID, msgId)
+		tag := verifier.GetAttribute(publishSpan.Attributes, "messaging.rocketmq.message.tag").AsString()
+		verifier.Assert(tag == "TagA", "Expect messaging.rocketmq.message.tag to be TagA, got %s", tag)
+		keys := verifier.GetAttribute(publishSpan.Attributes, "messaging.rocketmq.message.keys").AsStringSlice()
+		verifier.Assert(len(keys) == 1 && keys[0] == "order-1", "Expect messaging.rocketmq.message.keys to be [order-1], got %v", keys)
+		msgType := verifier.GetAttribute(publishSpan.Attributes, "messaging.rocketmq.message.type").AsString()
+		verifier.Assert(msgType == "normal", "Expect messaging.rocketmq.message.type to be normal, got %s", msgType)
+
+		verifyRocketMQAttributes(processSpan, pushTopic, "process", pushConsumerGroup, trace.SpanKindConsumer)
+		verifier.Assert(processSpan.Parent.SpanID() == publishSpan.SpanContext.SpanID(),
+			"Expect process span to be child of publish span")
+		consumerGroup := verifier.GetAttribute(processSpan.Attributes, "messaging.consumer.group.name").AsString()
+		verifier.Assert(consumerGroup == pushConsumerGroup, "Expect messaging.consumer.group.name to be %s, got %s", pushConsumerGroup, consumerGroup)
+		model := verifier.GetAttribute(processSpan.Attributes, "messaging.rocketmq.consumption_model").AsString()
+		verifier.Assert(model == "clustering", "Expect messaging.rocketmq.consumption_model to be clustering, got %s", model)
+		msgId = verifier.GetAttribute(processSpan.Attributes, "messaging.message.id").AsString()
+		verifier.Assert(msgId == result.MsgID, "Expect messaging.message.id to be %s, got %s", result.MsgID, msgId)
+	}, 1)
+}
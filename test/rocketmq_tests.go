@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const rocketmqModuleName = "rocketmq"
+
+const rocketmqImage = "apache/rocketmq:5.3.1"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("rocketmq-push-test", rocketmqModuleName, "2.1.0", "", "1.23.0", "", TestRocketMQPush),
+		NewGeneralTestCase("rocketmq-pull-test", rocketmqModuleName, "2.1.2", "", "1.23.0", "", TestRocketMQPull),
+	)
+}
+
+func TestRocketMQPush(t *testing.T, env ...string) {
+	namesrvAddr, brokerAddr := initRocketMQContainers(t)
+	UseApp("rocketmq/v2.1.2")
+	RunGoBuild(t, "go", "build", "test_rocketmq_push.go", "base.go")
+	env = append(env, "ROCKETMQ_NAMESRV="+namesrvAddr, "ROCKETMQ_BROKER="+brokerAddr)
+	RunApp(t, "test_rocketmq_push", env...)
+}
+
+func TestRocketMQPull(t *testing.T, env ...string) {
+	namesrvAddr, brokerAddr := initRocketMQContainers(t)
+	UseApp("rocketmq/v2.1.2")
+	RunGoBuild(t, "go", "build", "test_rocketmq_pull.go", "base.go")
+	env = append(env, "ROCKETMQ_NAMESRV="+namesrvAddr, "ROCKETMQ_BROKER="+brokerAddr)
+	RunApp(t, "test_rocketmq_pull", env...)
+}
+
+// initRocketMQContainers starts a name server and a broker. The broker
+// registers its address at the name server and clients connect to it
+// directly, so it advertises the host address and listens on a fixed host
+// port.
+func initRocketMQContainers(t *testing.T) (string, string) {
+	ctx := context.Background()
+	testNetwork, err := network.New(ctx, network.WithCheckDuplicate())
+	if err != nil {
+		t.Fatalf("Failed to create test network: %v", err)
+	}
+	namesrvReq := testcontainers.ContainerRequest{
+		Image:        rocketmqImage,
+		Cmd:          []string{"sh", "mqnamesrv"},
+		ExposedPorts: []string{"9876/tcp"},
+		WaitingFor:   wait.ForLog("The Name Server boot success").WithStartupTimeout(60 * time.Second),
+		Networks:     []string{testNetwork.Name},
+		NetworkAliases: map[string][]string{
+			testNetwork.Name: {"namesrv"},
+		},
+	}
+	namesrvC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: namesrvReq,
+		Started:          true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start RocketMQ name server container: %v", err)
+	}
+	namesrvPort, err := namesrvC.MappedPort(ctx, "9876")
+	if err != nil {
+		t.Fatalf("Failed to get RocketMQ name server port: %v", err)
+	}
+
+	hostIP := "127.0.0.1"
+	brokerReq := testcontainers.ContainerRequest{
+		Image: rocketmqImage,
+		Cmd: []string{"sh", "-c", fmt.Sprintf("echo 'brokerIP1=%s' > /tmp/broker.conf && "+
+			"echo 'autoCreateTopicEnable=true' >> /tmp/broker.conf && "+
+			"sh mqbroker -n namesrv:9876 -c /tmp/broker.conf", hostIP)},
+		ExposedPorts: []string{"10911/tcp"},
+		Env: map[string]string{
+			"JAVA_OPT_EXT": "-Xms512m -Xmx512m -Xmn256m",
+		},
+		HostConfigModifier: func(hc *container.HostConfig) {
+			hc.PortBindings = nat.PortMap{
+				"10911/tcp": []nat.PortBinding{{
+					HostIP:   "0.0.0.0",
+					HostPort: "10911",
+				}},
+			}
+		},
+		WaitingFor: wait.ForLog("boot success").WithStartupTimeout(120 * time.Second),
+		Networks:   []string{testNetwork.Name},
+	}
+	_, err = testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: brokerReq,
+		Started:          true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start RocketMQ broker container: %v", err)
+	}
+	// Wait for the broker to register at the name server
+	time.Sleep(5 * time.Second)
+	return net.JoinHostPort(hostIP, namesrvPort.Port()), net.JoinHostPort(hostIP, "10911")
+}
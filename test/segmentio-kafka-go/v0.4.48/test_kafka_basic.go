@@ -53,10 +53,18 @@ func main() {
 		panic(err)
 	}
 
-	// Verify OpenTelemetry traces
+	// Verify OpenTelemetry traces, every message of the batch has its own
+	// create span which the publish span links to
 	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
-		verifier.VerifyMQPublishAttributes(stubs[0][0], "", "", "", "publish", topicName, "kafka")
+		verifier.VerifyMQPublishAttributes(stubs[0][0], "", "", "", "create", topicName, "kafka")
 		verifier.VerifyMQConsumeAttributes(stubs[0][1], "", "", "", "process", topicName, "kafka")
-		verifier.VerifyMQConsumeAttributes(stubs[0][2], "", "", "", "process", topicName, "kafka")
-	}, 1)
+		verifier.VerifyMQPublishAttributes(stubs[1][0], "", "", "", "create", topicName, "kafka")
+		verifier.VerifyMQConsumeAttributes(stubs[1][1], "", "", "", "process", topicName, "kafka")
+		verifier.VerifyMQPublishAttributes(stubs[2][0], "", "", "", "publish", topicName, "kafka")
+		links := stubs[2][0].Links
+		verifier.Assert(len(links) == 2, "Expect publish span to have 2 links, got %d", len(links))
+		verifier.Assert(links[0].SpanContext.SpanID() == stubs[0][0].SpanContext.SpanID() &&
+			links[1].SpanContext.SpanID() == stubs[1][0].SpanContext.SpanID(),
+			"Expect publish span to link to the create spans")
+	}, 3)
 }
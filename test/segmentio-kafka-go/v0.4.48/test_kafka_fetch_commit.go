@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize producer with cleanup
+	producer := initProducer()
+	defer producer.Close()
+
+	// Initialize consumer with cleanup
+	consumer := initConsumer()
+	defer consumer.Close()
+
+	// Send message
+	if err := producer.WriteMessages(ctx, kafka.Message{Value: []byte("hello world")}); err != nil {
+		panic(err)
+	}
+
+	// Fetch and commit message
+	message, err := consumer.FetchMessage(ctx)
+	if err != nil {
+		panic(err)
+	}
+	if err = consumer.CommitMessages(ctx, message); err != nil {
+		panic(err)
+	}
+
+	// Verify OpenTelemetry traces
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.VerifyMQPublishAttributes(stubs[0][0], "", "", "", "publish", topicName, "kafka")
+		verifier.VerifyMQConsumeAttributes(stubs[0][1], "", "", "", "receive", topicName, "kafka")
+		verifier.Assert(stubs[0][1].Parent.SpanID() == stubs[0][0].SpanContext.SpanID(), "Expect receive span to be child of publish span")
+
+		commit := stubs[1][0]
+		verifier.Assert(commit.Name == topicName+" settle", "Expect commit span name to be %s, got %s", topicName+" settle", commit.Name)
+		verifier.Assert(commit.SpanKind == trace.SpanKindClient, "Expect commit span to be client span, got %d", commit.SpanKind)
+		actualGroup := verifier.GetAttribute(commit.Attributes, "messaging.consumer.group.name").AsString()
+		verifier.Assert(actualGroup == groupName, "Expect messaging.consumer.group.name to be %s, got %s", groupName, actualGroup)
+		verifier.Assert(len(commit.Links) == 1 && commit.Links[0].SpanContext.SpanID() == stubs[0][0].SpanContext.SpanID(),
+			"Expect commit span to link to the publish span")
+	}, 2)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	sentinel "github.com/alibaba/sentinel-golang/api"
+	"github.com/alibaba/sentinel-golang/core/circuitbreaker"
+	"github.com/alibaba/sentinel-golang/core/flow"
+)
+
+const (
+	flowResource           = "flow-res"
+	circuitBreakerResource = "cb-res"
+)
+
+func setupSentinel() {
+	if err := sentinel.InitDefault(); err != nil {
+		panic(err)
+	}
+	// only the first request within the interval passes
+	if _, err := flow.LoadRules([]*flow.Rule{{
+		Resource:               flowResource,
+		TokenCalculateStrategy: flow.Direct,
+		ControlBehavior:        flow.Reject,
+		Threshold:              1,
+		StatIntervalInMs:       10000,
+	}}); err != nil {
+		panic(err)
+	}
+	// a single error opens the circuit breaker, which allows a probe request soon after
+	if _, err := circuitbreaker.LoadRules([]*circuitbreaker.Rule{{
+		Resource:         circuitBreakerResource,
+		Strategy:         circuitbreaker.ErrorCount,
+		RetryTimeoutMs:   100,
+		MinRequestAmount: 1,
+		StatIntervalMs:   10000,
+		Threshold:        1,
+	}}); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	sentinel "github.com/alibaba/sentinel-golang/api"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func main() {
+	setupSentinel()
+	_, span := otel.Tracer("sentinel-test").Start(context.Background(), "handle")
+	e, b := sentinel.Entry(flowResource)
+	if b != nil {
+		panic("the first flow entry should pass")
+	}
+	e.Exit()
+	if _, b = sentinel.Entry(flowResource); b == nil {
+		panic("the second flow entry should be blocked")
+	}
+	e, b = sentinel.Entry(circuitBreakerResource)
+	if b != nil {
+		panic("the first circuit breaker entry should pass")
+	}
+	sentinel.TraceError(e, errors.New("downstream failure"))
+	e.Exit()
+	if _, b = sentinel.Entry(circuitBreakerResource); b == nil {
+		panic("the circuit breaker should be open")
+	}
+	time.Sleep(200 * time.Millisecond)
+	e, b = sentinel.Entry(circuitBreakerResource)
+	if b != nil {
+		panic("the probe entry should pass")
+	}
+	e.Exit()
+	span.End()
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		events := stubs[0][0].Events
+		verifier.Assert(len(events) == 6, "Expect 6 sentinel events, got %d", len(events))
+
+		verifier.Assert(events[0].Name == "sentinel.block", "Expect sentinel.block event, got %s", events[0].Name)
+		verifier.Assert(verifier.GetAttribute(events[0].Attributes, "sentinel.resource").AsString() == flowResource, "Expect the flow resource to be blocked")
+		verifier.Assert(verifier.GetAttribute(events[0].Attributes, "sentinel.rule.type").AsString() == "flow", "Expect flow rule type")
+		verifier.Assert(verifier.GetAttribute(events[0].Attributes, "sentinel.block.reason").AsString() != "", "Expect a block reason")
+
+		verifier.Assert(events[1].Name == "sentinel.error", "Expect sentinel.error event, got %s", events[1].Name)
+		verifier.Assert(verifier.GetAttribute(events[1].Attributes, "sentinel.error.message").AsString() == "downstream failure", "Expect the traced error message")
+
+		verifyStateChange(events[2], "Closed", "Open")
+
+		verifier.Assert(events[3].Name == "sentinel.block", "Expect sentinel.block event, got %s", events[3].Name)
+		verifier.Assert(verifier.GetAttribute(events[3].Attributes, "sentinel.resource").AsString() == circuitBreakerResource, "Expect the circuit breaker resource to be blocked")
+		verifier.Assert(verifier.GetAttribute(events[3].Attributes, "sentinel.rule.type").AsString() == "circuit_breaking", "Expect circuit_breaking rule type")
+
+		verifyStateChange(events[4], "Open", "HalfOpen")
+		verifyStateChange(events[5], "HalfOpen", "Closed")
+	}, 1)
+}
+
+func verifyStateChange(event sdktrace.Event, from, to string) {
+	verifier.Assert(event.Name == "sentinel.circuit_breaker.state_change", "Expect sentinel.circuit_breaker.state_change event, got %s", event.Name)
+	verifier.Assert(verifier.GetAttribute(event.Attributes, "sentinel.resource").AsString() == circuitBreakerResource, "Expect the circuit breaker resource")
+	actualFrom := verifier.GetAttribute(event.Attributes, "sentinel.circuit_breaker.state.from").AsString()
+	actualTo := verifier.GetAttribute(event.Attributes, "sentinel.circuit_breaker.state.to").AsString()
+	verifier.Assert(actualFrom == from && actualTo == to, "Expect state change from %s to %s, got %s to %s", from, to, actualFrom, actualTo)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	sentinel "github.com/alibaba/sentinel-golang/api"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func main() {
+	setupSentinel()
+	e, b := sentinel.Entry(flowResource)
+	if b != nil {
+		panic("the first flow entry should pass")
+	}
+	e.Exit()
+	if _, b = sentinel.Entry(flowResource); b == nil {
+		panic("the second flow entry should be blocked")
+	}
+	verifier.WaitAndAssertMetrics(map[string]func(metricdata.ResourceMetrics){
+		"sentinel.entry.pass": func(mrs metricdata.ResourceMetrics) {
+			verifyCount(mrs, "sentinel.entry.pass", "")
+		},
+		"sentinel.entry.block": func(mrs metricdata.ResourceMetrics) {
+			verifyCount(mrs, "sentinel.entry.block", "flow")
+		},
+	})
+}
+
+func verifyCount(mrs metricdata.ResourceMetrics, name, ruleType string) {
+	if len(mrs.ScopeMetrics) <= 0 {
+		panic("No " + name + " metrics received!")
+	}
+	point := mrs.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	verifier.Assert(point.DataPoints[0].Value == 1, "Expect %s to be 1, got %d", name, point.DataPoints[0].Value)
+	attrs := point.DataPoints[0].Attributes.ToSlice()
+	verifier.Assert(verifier.GetAttribute(attrs, "sentinel.resource").AsString() == flowResource, "Expect %s to be recorded for %s", name, flowResource)
+	if ruleType != "" {
+		verifier.Assert(verifier.GetAttribute(attrs, "sentinel.rule.type").AsString() == ruleType, "Expect %s rule type to be %s", name, ruleType)
+	}
+}
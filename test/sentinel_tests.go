@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+const sentinel_dependency_name = "github.com/alibaba/sentinel-golang"
+const sentinel_module_name = "sentinel"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("sentinel-flow-test", sentinel_module_name, "v1.0.0", "", "1.23.0", "", TestSentinelFlow),
+		NewGeneralTestCase("sentinel-metrics-test", sentinel_module_name, "v1.0.0", "", "1.23.0", "", TestSentinelMetrics),
+		NewLatestDepthTestCase("sentinel-latest-depth-test", sentinel_dependency_name, sentinel_module_name, "v1.0.0", "", "1.23.0", "", TestSentinelFlow),
+		NewMuzzleTestCase("sentinel-muzzle-test", sentinel_dependency_name, sentinel_module_name, "v1.0.0", "", "1.23.0", "", []string{"go", "build", "test_sentinel_flow.go", "base.go"}),
+	)
+}
+
+func TestSentinelFlow(t *testing.T, env ...string) {
+	UseApp("sentinel/v1.0.0")
+	RunGoBuild(t, "go", "build", "test_sentinel_flow.go", "base.go")
+	RunApp(t, "test_sentinel_flow", env...)
+}
+
+func TestSentinelMetrics(t *testing.T, env ...string) {
+	UseApp("sentinel/v1.0.0")
+	RunGoBuild(t, "go", "build", "test_sentinel_metrics.go", "base.go")
+	RunApp(t, "test_sentinel_metrics", env...)
+}
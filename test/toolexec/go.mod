@@ -0,0 +1,11 @@
+module toolexec
+
+go 1.23.0
+
+replace github.com/alibaba/loongsuite-go-agent/pkg => ../../pkg
+
+replace github.com/alibaba/loongsuite-go-agent/pkg/rules/test/fmt1 => ../../pkg/rules/test/fmt1
+
+require github.com/alibaba/loongsuite-go-agent/pkg/rules/test/fmt1 v0.0.0-00010101000000-000000000000
+
+require github.com/alibaba/loongsuite-go-agent/pkg v0.0.0-20250613015359-8313b2644a4a // indirect
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+)
+
+const ToolexecAppName = "toolexec"
+
+func TestToolexec(t *testing.T) {
+	UseApp(ToolexecAppName)
+	// start from scratch, rules shared by earlier builds are kept by keys
+	err := os.RemoveAll(util.TempBuildDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the go command is driven directly, the tool is only used as -toolexec
+	path := filepath.Join(filepath.Dir(pwd), getExecName())
+	rules := filepath.Join(filepath.Dir(pwd), "tool", "data", "test_fmt.json") +
+		"," + filepath.Join(filepath.Dir(pwd), "tool", "data", "test_toolexec.json")
+	cmd := runCmd([]string{"go", "build", "-a", "-toolexec=" + path, "."})
+	cmd.Env = append(cmd.Env, "OTELTOOL_DISABLE_RULES=all",
+		"OTELTOOL_RULE_JSON_FILES="+rules)
+	err = cmd.Run()
+	if err != nil {
+		t.Fatal(err, readStderrLog(t))
+	}
+	stdout, stderr := RunApp(t, ToolexecAppName)
+	ExpectContains(t, stdout, "olleH")
+	ExpectContains(t, stderr, "Entering hook1") // imported by the app
+	ExpectContains(t, stderr, "30258")          // raw rules are self-contained
+	ExpectNotContains(t, stderr, "hook2")       // fmt5 is not imported
+
+	// rules of hook packages not imported by the app are skipped
+	log := ReadLog(t)
+	ExpectContains(t, log, "is not imported by")
+	// so are file rules importing packages that are not dependencies
+	ExpectContains(t, log, "net/http is not a dependency of fmt")
+	// the main module is loaded once and shared by later compile actions
+	if !util.PathExists(filepath.Join(util.TempBuildDir, "main_module.json")) {
+		t.Fatal("expecting the main module to be shared")
+	}
+	// so are the rules, every compile action reads those of its package only
+	shared, err := filepath.Glob(filepath.Join(util.TempBuildDir, "lazy-rules-*"))
+	if err != nil || len(shared) != 1 {
+		t.Fatalf("expecting the rules to be shared once, got %v %v", shared, err)
+	}
+
+	// build again without -a, the shared main module is reused
+	cmd = runCmd([]string{"go", "build", "-toolexec=" + path, "."})
+	cmd.Env = append(cmd.Env, "OTELTOOL_DISABLE_RULES=all",
+		"OTELTOOL_RULE_JSON_FILES="+rules)
+	err = cmd.Run()
+	if err != nil {
+		t.Fatal(err, readStderrLog(t))
+	}
+	stdout, _ = RunApp(t, ToolexecAppName)
+	ExpectContains(t, stdout, "olleH")
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+	"github.com/weaviate/weaviate/entities/models"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const docID = "5b6a3b4e-4b52-4c3a-9f0e-1c1d2e3f4a5b"
+
+func main() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/meta":
+			w.Write([]byte(`{"version":"1.27.0"}`))
+		case r.URL.Path == "/v1/batch/objects":
+			w.Write([]byte(`[{"class":"Doc","id":"` + docID + `","result":{}}]`))
+		case r.URL.Path == "/v1/graphql":
+			w.Write([]byte(`{"data":{"Get":{"Doc":[{"title":"a"},{"title":"b"}]}}}`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/objects/"):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+	client, err := weaviate.NewClient(weaviate.Config{Host: host, Scheme: "http"})
+	if err != nil {
+		panic(err)
+	}
+	ctx := context.Background()
+	_, err = client.Batch().ObjectsBatcher().WithObjects(
+		&models.Object{Class: "Doc", ID: docID, Vector: []float32{0.1, 0.2}},
+	).Do(ctx)
+	if err != nil {
+		panic(err)
+	}
+	_, err = client.GraphQL().Get().WithClassName("Doc").
+		WithFields(graphql.Field{Name: "title"}).
+		WithNearVector(client.GraphQL().NearVectorArgBuilder().WithVector([]float32{0.1, 0.2})).
+		WithLimit(3).Do(ctx)
+	if err != nil {
+		panic(err)
+	}
+	err = client.Data().Deleter().WithClassName("Doc").WithID(docID).Do(ctx)
+	if err != nil {
+		panic(err)
+	}
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		// the client may also query the server version on its own
+		var spans []tracetest.SpanStub
+		for _, stub := range stubs {
+			if verifier.GetAttribute(stub[0].Attributes, "db.system.name").AsString() == "weaviate" {
+				spans = append(spans, stub[0])
+			}
+		}
+		verifier.Assert(len(spans) == 3, "Expect 3 weaviate spans, got %d", len(spans))
+		verifier.VerifyDbAttributes(spans[0], "upsert Doc", "weaviate", host, "", "upsert", "Doc", nil)
+		batchSize := verifier.GetAttribute(spans[0].Attributes, "db.operation.batch.size").AsInt64()
+		verifier.Assert(batchSize == 1, "Expect db.operation.batch.size to be 1, got %d", batchSize)
+
+		verifier.VerifyDbAttributes(spans[1], "search Doc", "weaviate", host, "", "search", "Doc", nil)
+		topK := verifier.GetAttribute(spans[1].Attributes, "db.vector.query.top_k").AsInt64()
+		verifier.Assert(topK == 3, "Expect db.vector.query.top_k to be 3, got %d", topK)
+		returned := verifier.GetAttribute(spans[1].Attributes, "db.response.returned_rows").AsInt64()
+		verifier.Assert(returned == 2, "Expect db.response.returned_rows to be 2, got %d", returned)
+
+		verifier.VerifyDbAttributes(spans[2], "delete Doc", "weaviate", host, "", "delete", "Doc", nil)
+	}, 3)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+const weaviate_dependency_name = "github.com/weaviate/weaviate-go-client/v4"
+const weaviate_module_name = "weaviate"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("weaviate-crud-test", weaviate_module_name, "v4.16.1", "", "1.23.0", "", TestWeaviateCrud),
+		NewGeneralTestCase("weaviate-v5-crud-test", weaviate_module_name, "v5.0.2", "", "1.23.0", "", TestWeaviateV5Crud),
+		NewLatestDepthTestCase("weaviate-latest-depth-test", weaviate_dependency_name, weaviate_module_name, "v4.16.1", "", "1.23.0", "", TestWeaviateCrud),
+		NewMuzzleTestCase("weaviate-muzzle-test", weaviate_dependency_name, weaviate_module_name, "v4.16.1", "", "1.23.0", "", []string{"go", "build", "test_weaviate_crud.go"}),
+	)
+}
+
+func TestWeaviateCrud(t *testing.T, env ...string) {
+	UseApp("weaviate/v4.16.1")
+	RunGoBuild(t, "go", "build", "test_weaviate_crud.go")
+	RunApp(t, "test_weaviate_crud", env...)
+}
+
+func TestWeaviateV5Crud(t *testing.T, env ...string) {
+	UseApp("weaviate/v5.0.2")
+	RunGoBuild(t, "go", "build", "test_weaviate_crud.go")
+	RunApp(t, "test_weaviate_crud", env...)
+}
@@ -56,8 +56,26 @@ type BuildConfig struct {
 	// Note that base.json is inevitable to be enabled, even if it is explicitly
 	// disabled.
 	DisableRules string
+
+	// ReplacePolicy specifies which version of a module replaced by a local
+	// directory or a fork, e.g. via replace directives or go.work, is matched
+	// against the version range of rules. It can be:
+	// - "replaced" to use the version of the replacement if it is another
+	//   version of the same module, or the version of the original
+	//   requirement if the replacement is a local directory or a fork with a
+	//   different module path
+	// - "original" to always use the version of the original requirement
+	// - "match" to treat replaced modules as matching any version range
+	// - empty string is the same as "replaced"
+	ReplacePolicy string
 }
 
+const (
+	ReplacePolicyReplaced = "replaced"
+	ReplacePolicyOriginal = "original"
+	ReplacePolicyMatch    = "match"
+)
+
 // @@This value is specified by the build system.
 // This is the version of the tool, which will be printed when the -version flag
 // is passed.
@@ -74,6 +92,22 @@ func (bc *BuildConfig) IsDisableAll() bool {
 	return bc.DisableRules == "all"
 }
 
+// GetReplacePolicy returns the replace policy, which defaults to "replaced"
+func (bc *BuildConfig) GetReplacePolicy() string {
+	if bc.ReplacePolicy == "" {
+		return ReplacePolicyReplaced
+	}
+	return bc.ReplacePolicy
+}
+
+func (bc *BuildConfig) checkReplacePolicy() error {
+	switch bc.GetReplacePolicy() {
+	case ReplacePolicyReplaced, ReplacePolicyOriginal, ReplacePolicyMatch:
+		return nil
+	}
+	return errc.New(errc.ErrInvalidConfig, "bad replace policy "+bc.ReplacePolicy)
+}
+
 // GetDisabledRules returns a set of rule file names that should be disabled
 func (bc *BuildConfig) GetDisabledRules() string {
 	return bc.DisableRules
@@ -213,15 +247,20 @@ func InitConfig() (err error) {
 	}
 	loadConfigFromEnv(conf)
 
+	err = conf.checkReplacePolicy()
+	if err != nil {
+		return err
+	}
 	err = conf.parseRuleFiles()
 	if err != nil {
 		return err
 	}
 
-	mode := os.O_WRONLY | os.O_APPEND
+	mode := os.O_WRONLY | os.O_CREATE | os.O_APPEND
 	if util.InPreprocess() {
 		// We always create log file in preprocess phase, but in further
-		// instrument phase, we append log content to the existing file.
+		// instrument phase, we append log content to the existing file, which
+		// is created on demand when used as a plain -toolexec.
 		mode = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
 	}
 	// Always redirect log to debug log file
@@ -256,7 +295,13 @@ func Configure() error {
 		"Use custom.json rules. Multiple rules are separated by comma.")
 	flag.StringVar(&bc.DisableRules, "disable", bc.DisableRules,
 		"Disable specific rules. Use 'all' to disable all default rules, or comma-separated list of rule file names to disable specific rules")
+	flag.StringVar(&bc.ReplacePolicy, "replace-policy", bc.ReplacePolicy,
+		"Version of replaced modules to match rules with. Use 'replaced', 'original' or 'match' to match any version")
 	flag.CommandLine.Parse(os.Args[2:])
+	err = bc.checkReplacePolicy()
+	if err != nil {
+		return err
+	}
 
 	util.Log("Configured in %s", getConfPath(BuildConfFile))
 
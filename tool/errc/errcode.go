@@ -44,6 +44,9 @@ const (
 	ErrGetExecutable
 	ErrInstrument
 	ErrPreprocess
+	ErrReport
+	ErrInspect
+	ErrInvalidConfig
 )
 
 var errMessages = map[int]string{
@@ -70,6 +73,9 @@ var errMessages = map[int]string{
 	ErrNotModularized: "Not a modularized project",
 	ErrGetExecutable:  "Failed to get executable",
 	ErrInstrument:     "Failed to instrument",
+	ErrReport:         "Failed to report",
+	ErrInspect:        "Failed to inspect",
+	ErrInvalidConfig:  "Invalid configuration",
 }
 
 type PlentifulError struct {
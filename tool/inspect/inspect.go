@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/resource"
+)
+
+// -----------------------------------------------------------------------------
+// Inspect
+//
+// Binaries built by the tool carry the manifest of the instrumentation, the
+// inspect command extracts it and tells whether, by which tool version and
+// with which rules the binary was instrumented.
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+func Inspect() error {
+	format := flag.String("format", FormatText,
+		"Output format, one of text and json")
+	flag.CommandLine.Parse(os.Args[2:])
+	if flag.NArg() != 1 {
+		return errc.New(errc.ErrInspect, "expect exactly one binary")
+	}
+	binary := flag.Arg(0)
+
+	content, err := os.ReadFile(binary)
+	if err != nil {
+		return errc.New(errc.ErrOpenFile, err.Error())
+	}
+	manifest := resource.FindManifest(content)
+	if manifest == nil {
+		return errc.New(errc.ErrInspect, "not instrumented by otel").
+			With("binary", binary)
+	}
+	switch *format {
+	case FormatText:
+		// Build info is merely supplementary, tolerate its absence
+		info, _ := buildinfo.Read(bytes.NewReader(content))
+		printText(os.Stdout, binary, info, manifest)
+	case FormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(manifest)
+	default:
+		return errc.New(errc.ErrInspect, "unknown format "+*format)
+	}
+	if err != nil {
+		return errc.New(errc.ErrInspect, err.Error())
+	}
+	return nil
+}
+
+func printText(w io.Writer, binary string, info *buildinfo.BuildInfo,
+	m *resource.Manifest) {
+	fmt.Fprintf(w, "%s: instrumented by otel %s\n", binary, m.ToolVersion)
+	if info != nil {
+		fmt.Fprintf(w, "Module: %s %s\n", info.Main.Path, info.Main.Version)
+	}
+	fmt.Fprintf(w, "Go version: %s\n", m.GoVersion)
+	fmt.Fprintf(w, "Build key: %s\n", m.BuildKey)
+	fmt.Fprintf(w, "Config: rule=%q disable=%q replace=%q verbose=%v debug=%v\n",
+		m.Config.RuleJsonFiles, m.Config.DisableRules, m.Config.ReplacePolicy,
+		m.Config.Verbose, m.Config.Debug)
+	fmt.Fprintf(w, "Rule sets:\n")
+	names := make([]string, 0, len(m.RuleSets))
+	for name := range m.RuleSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s %s\n", name, m.RuleSets[name])
+	}
+	fmt.Fprintf(w, "Instrumented packages:\n")
+	for _, pkg := range m.Packages {
+		fmt.Fprintf(w, "  %s\n", strings.TrimSpace(pkg.ImportPath+" "+pkg.Version))
+		for _, rule := range pkg.Rules {
+			fmt.Fprintf(w, "    %s\n", rule)
+		}
+	}
+}
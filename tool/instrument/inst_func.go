@@ -17,8 +17,11 @@ package instrument
 import (
 	"fmt"
 	"go/parser"
+	"hash/fnv"
+	"maps"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -106,6 +109,27 @@ func (rp *RuleProcessor) makeName(r *resource.InstFuncRule,
 	return fmt.Sprintf("%s_%s%s", prefix, funcDecl.Name.Name, rp.rule2Suffix[r])
 }
 
+// makeSuffix derives the suffix of generated names from the identity of the
+// rule and the target function instead of picking a random one, so that
+// building the same code twice yields the same output. In the rare case of a
+// collision, e.g. the same rule is declared twice, we rehash with a counter,
+// which is still deterministic as rules are always applied in the same order.
+func (rp *RuleProcessor) makeSuffix(t *resource.InstFuncRule,
+	funcDecl *dst.FuncDecl) string {
+	identity := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%d|%s|%s",
+		t.ImportPath, t.Version, t.Function, t.ReceiverType, t.OnEnter,
+		t.OnExit, t.Order, filepath.Base(t.Path), funcDecl.Name.Name)
+	for i := 0; ; i++ {
+		h := fnv.New32a()
+		_, _ = fmt.Fprintf(h, "%s|%d", identity, i)
+		suffix := fmt.Sprintf("%05d", h.Sum32()%100000)
+		if !rp.suffixes[suffix] {
+			rp.suffixes[suffix] = true
+			return suffix
+		}
+	}
+}
+
 func findJumpPoint(jumpIf *dst.IfStmt) *dst.BlockStmt {
 	// Multiple func rules may apply to the same function, we need to find the
 	// appropriate jump point to insert trampoline jump.
@@ -129,18 +153,21 @@ func (rp *RuleProcessor) insertTJump(t *resource.InstFuncRule,
 	funcDecl *dst.FuncDecl) error {
 	util.Assert(t.OnEnter != "" || t.OnExit != "", "sanity check")
 
+	varSuffix := rp.makeSuffix(t, funcDecl)
+	rp.rule2Suffix[t] = varSuffix
+
 	var retVals []dst.Expr // nil by default
 	if retList := funcDecl.Type.Results; retList != nil {
 		retVals = make([]dst.Expr, 0)
 		// If return values are named, collect their names, otherwise we try to
 		// name them manually for further use
-		for _, field := range retList.List {
+		for i, field := range retList.List {
 			if field.Names != nil {
 				for _, name := range field.Names {
 					retVals = append(retVals, dst.NewIdent(name.Name))
 				}
 			} else {
-				retValIdent := dst.NewIdent("retVal" + util.RandomString(5))
+				retValIdent := dst.NewIdent(fmt.Sprintf("retVal%d_%s", i, varSuffix))
 				field.Names = []*dst.Ident{retValIdent}
 				retVals = append(retVals, dst.Clone(retValIdent).(*dst.Ident))
 			}
@@ -165,9 +192,6 @@ func (rp *RuleProcessor) insertTJump(t *resource.InstFuncRule,
 		}
 	}
 
-	varSuffix := util.RandomString(5)
-	rp.rule2Suffix[t] = varSuffix
-
 	// Generate the trampoline-jump-if. N.B. Note that future optimization pass
 	// heavily depends on the structure of trampoline-jump-if. Any change in it
 	// should be carefully examined.
@@ -349,8 +373,10 @@ func (rp *RuleProcessor) applyFuncRules(bundle *resource.RuleBundle) (err error)
 		return err
 	}
 	// Applied all matched func rules, either inserting raw code or inserting
-	// our trampoline calls.
-	for file, fn2rules := range bundle.File2FuncRules {
+	// our trampoline calls. Files and functions are visited in a fixed order,
+	// which decides the order of generated declarations.
+	for _, file := range slices.Sorted(maps.Keys(bundle.File2FuncRules)) {
+		fn2rules := bundle.File2FuncRules[file]
 		util.Assert(filepath.IsAbs(file), "file path must be absolute")
 		astRoot, err := rp.loadAst(file)
 		if err != nil {
@@ -362,7 +388,8 @@ func (rp *RuleProcessor) applyFuncRules(bundle *resource.RuleBundle) (err error)
 		// the generated function are excluded from the instrumented file.
 		oldDecls := make([]dst.Decl, len(astRoot.Decls))
 		copy(oldDecls, astRoot.Decls)
-		for fnName, rules := range fn2rules {
+		for _, fnName := range slices.Sorted(maps.Keys(fn2rules)) {
+			rules := fn2rules[fnName]
 			for _, decl := range oldDecls {
 				nameAndRecvType := strings.Split(fnName, ",")
 				name := nameAndRecvType[0]
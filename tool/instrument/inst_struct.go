@@ -15,7 +15,9 @@
 package instrument
 
 import (
+	"maps"
 	"path/filepath"
+	"slices"
 
 	"github.com/alibaba/loongsuite-go-agent/tool/resource"
 	"github.com/alibaba/loongsuite-go-agent/tool/util"
@@ -30,7 +32,8 @@ func (rp *RuleProcessor) addStructField(rule *resource.InstStructRule, decl dst.
 }
 
 func (rp *RuleProcessor) applyStructRules(bundle *resource.RuleBundle) error {
-	for file, struct2Rules := range bundle.File2StructRules {
+	for _, file := range slices.Sorted(maps.Keys(bundle.File2StructRules)) {
+		struct2Rules := bundle.File2StructRules[file]
 		util.Assert(filepath.IsAbs(file), "file path must be absolute")
 		// Apply struct rules to the file
 		astRoot, err := rp.loadAst(file)
@@ -38,9 +41,9 @@ func (rp *RuleProcessor) applyStructRules(bundle *resource.RuleBundle) error {
 			return err
 		}
 		for _, decl := range astRoot.Decls {
-			for structName, rules := range struct2Rules {
+			for _, structName := range slices.Sorted(maps.Keys(struct2Rules)) {
 				if util.MatchStructDecl(decl, structName) {
-					for _, rule := range rules {
+					for _, rule := range struct2Rules[structName] {
 						rp.addStructField(rule, decl)
 					}
 				}
@@ -45,8 +45,10 @@ type RuleProcessor struct {
 	parser *util.AstParser
 	// The compiling arguments for the target file
 	compileArgs []string
-	// Randomly generated suffix for the rule, used to avoid name collision
+	// Suffix of generated names for the rule, used to avoid name collision
 	rule2Suffix map[*resource.InstFuncRule]string
+	// Suffixes already in use
+	suffixes map[string]bool
 	// The target function to be instrumented
 	rawFunc *dst.FuncDecl
 	// Whether the rule is exact match with target function, or it's a regexp match
@@ -84,6 +86,7 @@ func newRuleProcessor(args []string, pkgName string) *RuleProcessor {
 		target:      nil,
 		compileArgs: args,
 		rule2Suffix: make(map[*resource.InstFuncRule]string),
+		suffixes:    make(map[string]bool),
 		relocated:   make(map[string]string),
 	}
 	return rp
@@ -233,6 +236,14 @@ func compileRemix(bundle *resource.RuleBundle, args []string) error {
 func Instrument() error {
 	// Remove the tool itself from the command line arguments
 	args := os.Args[2:]
+	// Is querying the tool id?
+	if isToolIDQuery(args) {
+		key, err := resource.LoadBuildKey()
+		if err != nil {
+			return err
+		}
+		return reportToolID(args, key)
+	}
 	// Is compile command?
 	if util.IsCompileCommand(strings.Join(args, " ")) {
 		if config.GetConf().Verbose {
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrument
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/config"
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/preprocess"
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+)
+
+// When used as a plain -toolexec, e.g. go build -toolexec=otel, there is no
+// preprocess phase that matches the rules in advance. Every compile action
+// matches the rules against the package it compiles instead, and all other
+// tools are run as is.
+
+// compilePackage returns the import path, the source files and the import
+// config of the compile command
+func compilePackage(args []string) (string, []string, string) {
+	importPath, importcfg := "", ""
+	files := make([]string, 0)
+	for i, arg := range args {
+		if arg == util.BuildPattern && i+1 < len(args) {
+			importPath = args[i+1]
+		}
+		if arg == "-importcfg" && i+1 < len(args) {
+			importcfg = args[i+1]
+		}
+		if util.IsGoFile(arg) {
+			files = append(files, arg)
+		}
+	}
+	return importPath, files, importcfg
+}
+
+// compilerGoVersion asks the compiler for the Go version it belongs to, its
+// answer looks like "compile version go1.23.1"
+func compilerGoVersion(compiler string) (string, error) {
+	out, err := exec.Command(compiler, "-V=full").Output()
+	if err != nil {
+		return "", errc.New(errc.ErrRunCmd, err.Error()).
+			With("command", compiler+" -V=full")
+	}
+	for _, field := range strings.Fields(string(out)) {
+		if strings.HasPrefix(field, "go") {
+			return field, nil
+		}
+	}
+	return "", errc.New(errc.ErrRunCmd, "unknown compiler version").
+		With("version", string(out))
+}
+
+func InstrumentLazily() error {
+	// Remove the tool itself from the command line arguments
+	args := os.Args[1:]
+	// Is querying the tool id?
+	if isToolIDQuery(args) {
+		key, err := preprocess.LazyBuildKey()
+		if err != nil {
+			return err
+		}
+		return reportToolID(args, key)
+	}
+	// Is compile command?
+	if util.IsCompileCommand(strings.Join(args, " ")) {
+		if config.GetConf().Verbose {
+			util.Log("RunCmd: %v", args)
+		}
+		importPath, files, importcfg := compilePackage(args)
+		bundle, err := preprocess.MatchPackage(importPath, files, importcfg,
+			func() (string, error) { return compilerGoVersion(args[0]) })
+		if err != nil {
+			err = errc.Adhere(err, "cmd", fmt.Sprintf("%v", args))
+			return err
+		}
+		if bundle != nil {
+			util.Log("Apply bundle %v", bundle)
+			err = compileRemix(bundle, args)
+			if err != nil {
+				err = errc.Adhere(err, "cmd", fmt.Sprintf("%v", args))
+				err = errc.Adhere(err, "bundle", bundle.String())
+				return err
+			}
+			return nil
+		}
+	}
+	// Not a compile command, or nothing to instrument, just run it as is
+	return runTool(args)
+}
+
+// runTool runs the tool as if it were invoked by the go command directly. Its
+// failure is returned as is, e.g. a syntax error of the user code, so that the
+// caller exits with the status of the tool without blaming itself.
+func runTool(args []string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if _, ok := err.(*exec.ExitError); ok {
+		return err
+	}
+	if err != nil {
+		return errc.New(errc.ErrRunCmd, err.Error()).
+			With("command", fmt.Sprintf("%v", args))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrument
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+)
+
+// The go command asks every tool for its identity by running "tool -V=full"
+// and mixes the answer into the action ids of the build cache. Appending the
+// build key to the answer makes instrumented outputs cached apart from regular
+// ones and from builds with different rules, so unchanged packages, including
+// the instrumented standard library, are reused by subsequent builds.
+
+func isToolIDQuery(args []string) bool {
+	return len(args) == 2 && args[1] == "-V=full"
+}
+
+func reportToolID(args []string, key string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return errc.New(errc.ErrRunCmd, err.Error()).
+			With("command", fmt.Sprintf("%v", args))
+	}
+	id := strings.TrimSpace(string(out))
+	fields := strings.Fields(id)
+	if len(fields) > 0 && strings.HasPrefix(fields[len(fields)-1], "buildID=") {
+		// Development toolchains are identified by the content id within
+		// the trailing buildID field only, extend that field instead
+		id += "-otel" + key
+	} else {
+		id += " otel=" + key
+	}
+	fmt.Println(id)
+	return nil
+}
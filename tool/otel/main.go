@@ -17,13 +17,17 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 
 	"github.com/alibaba/loongsuite-go-agent/tool/config"
 	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/inspect"
 	"github.com/alibaba/loongsuite-go-agent/tool/instrument"
 	"github.com/alibaba/loongsuite-go-agent/tool/preprocess"
+	"github.com/alibaba/loongsuite-go-agent/tool/report"
 	"github.com/alibaba/loongsuite-go-agent/tool/util"
 )
 
@@ -32,6 +36,8 @@ const (
 	SubcommandGo      = "go"
 	SubcommandVersion = "version"
 	SubcommandRemix   = "remix"
+	SubcommandReport  = "report"
+	SubcommandInspect = "inspect"
 )
 
 var usage = `Usage: {} <command> [args]
@@ -39,13 +45,19 @@ Example:
 	{} go build
 	{} go install
 	{} go build main.go
+	{} go run main.go
+	go build -toolexec=$(which {})
 	{} version
 	{} set -verbose -rule=custom.json
+	{} report -format=html -o=report.html
+	{} inspect ./app
 
 Command:
 	version    print the version
 	set        set the configuration
-	go         build the Go application
+	go         build or run the Go application
+	report     report the instrumentation of the latest build
+	inspect    print how the binary was instrumented
 `
 
 func printUsage() {
@@ -54,10 +66,30 @@ func printUsage() {
 	fmt.Print(usage)
 }
 
+// isToolexec reports whether the tool is used as a plain -toolexec, in which
+// case the go command passes the path of the tool to run, e.g. compile, asm
+// and link, rather than a subcommand
+func isToolexec(arg string) bool {
+	return strings.ContainsRune(arg, filepath.Separator) && util.PathExists(arg)
+}
+
 func initTempDir() error {
 	// All temp directories are prepared before, instrument phase should not
-	// create any new directories.
+	// create any new directories. Except for plain -toolexec, where nobody
+	// prepares them, only the top one is ensured as it's shared by concurrent
+	// compile actions.
 	if util.GetRunPhase() == util.PInstrument {
+		if isToolexec(os.Args[1]) {
+			err := os.MkdirAll(util.TempBuildDir, 0777)
+			if err != nil {
+				return errc.New(errc.ErrMkdirAll, err.Error())
+			}
+		}
+		return nil
+	}
+
+	// Report and inspect only read what the builds left, keep them as is
+	if os.Args[1] == SubcommandReport || os.Args[1] == SubcommandInspect {
 		return nil
 	}
 
@@ -84,6 +116,10 @@ func initEnv() error {
 
 	// Determine the run phase
 	switch {
+	case isToolexec(os.Args[1]):
+		// go build -toolexec=otel? Check it first, as the path of cgo ends
+		// with "go" as well
+		util.SetRunPhase(util.PInstrument)
 	case strings.HasSuffix(os.Args[1], SubcommandGo):
 		// otel go build?
 		util.SetRunPhase(util.PPreprocess)
@@ -144,13 +180,38 @@ func main() {
 		err = preprocess.Preprocess()
 	case SubcommandRemix:
 		err = instrument.Instrument()
+	case SubcommandReport:
+		err = report.Report()
+	case SubcommandInspect:
+		err = inspect.Inspect()
 	default:
-		printUsage()
+		if isToolexec(subcmd) {
+			err = instrument.InstrumentLazily()
+		} else {
+			printUsage()
+		}
 	}
 	if err != nil {
-		if subcmd != SubcommandRemix {
+		// The program started by "otel go run" or the tool run by plain
+		// -toolexec failed, which is not our fault, exit with its status so
+		// that callers observe the program's own code
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			code := exitErr.ExitCode()
+			if code < 0 {
+				code = 1
+			}
+			os.Exit(code)
+		}
+		switch {
+		case isToolexec(subcmd):
+			// Nobody else is going to report the error of plain -toolexec,
+			// tell the go command the reason and where to find more details
+			fmt.Fprintf(os.Stderr, "%s\nsee %s for details\n",
+				err.Error(), util.GetLoggerPath())
+			util.LogFatal(err.Error())
+		case subcmd != SubcommandRemix:
 			fatal(err)
-		} else {
+		default:
 			// If error occurs in remix phase, we dont want to decoret the error
 			// message with the environments, just print the error message, the
 			// caller(preprocess) phase will decorate instead.
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/config"
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/resource"
+)
+
+// computeBuildKey returns a digest of everything that decides how a package is
+// instrumented apart from the package itself, i.e. the tool binary, which
+// embeds the default rules along with their hook code, the available rules and
+// the hook code of the matched custom rules. The package sources, its
+// dependencies and the toolchain are already part of the action id computed
+// by go build, so combined with this key, unchanged packages are reused from
+// the build cache while any change of the instrumentation invalidates them.
+func (dp *DepProcessor) computeBuildKey(bundles []*resource.RuleBundle) (string, error) {
+	h, err := newBuildKeyHash()
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range dp.customHookDirs(bundles) {
+		err = hashHookDir(h, dir)
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// newBuildKeyHash starts a build key with the tool and the available rules
+func newBuildKeyHash() (hash.Hash, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "tool %s\n", config.ToolVersion)
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, errc.New(errc.ErrGetExecutable, err.Error())
+	}
+	err = hashFile(h, exe)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range findAvailableRules() {
+		fmt.Fprintf(h, "rule %s\n", rule.String())
+	}
+	// Which version of replaced modules is matched depends on the policy
+	fmt.Fprintf(h, "replace %s\n", config.GetConf().GetReplacePolicy())
+	return h, nil
+}
+
+func hashHookDir(h hash.Hash, dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return errc.New(errc.ErrInternal, err.Error())
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		fmt.Fprintf(h, "hook %s\n", file)
+		err = hashFile(h, file)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// customHookDirs returns the directories of hook code that is not shipped
+// with the tool, they are referred by custom rules only.
+func (dp *DepProcessor) customHookDirs(bundles []*resource.RuleBundle) []string {
+	dirs := make(map[string]bool)
+	addDir := func(dir string) {
+		if dir == "" || strings.HasPrefix(dir, dp.pkgLocalCache) {
+			return
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(dp.getGoModDir(), dir)
+		}
+		dirs[dir] = true
+	}
+	for _, bundle := range bundles {
+		for _, funcRules := range bundle.File2FuncRules {
+			for _, rs := range funcRules {
+				for _, rule := range rs {
+					if !rule.UseRaw {
+						addDir(rule.GetPath())
+					}
+				}
+			}
+		}
+		for _, fileRule := range bundle.FileRules {
+			addDir(fileRule.GetPath())
+		}
+	}
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func hashFile(h hash.Hash, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errc.New(errc.ErrOpenFile, err.Error())
+	}
+	defer file.Close()
+	_, err = io.Copy(h, file)
+	if err != nil {
+		return errc.New(errc.ErrCopyFile, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,575 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/config"
+	"github.com/alibaba/loongsuite-go-agent/tool/data"
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/resource"
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+	"golang.org/x/mod/modfile"
+)
+
+// When the tool is used as a plain -toolexec, e.g. go build -toolexec=otel,
+// the build is driven by someone else and there is no chance to prepare the
+// project in advance. Rules are then matched against each compiled package on
+// demand, and since no otel_importer.go brings hook packages into the build,
+// the main module is expected to import them on its own. Rules whose hook
+// package is not imported are therefore skipped, they would fail to link
+// otherwise. Likewise, file rules are skipped if the file imports packages the
+// compiled package does not depend on. Struct rules and raw rules are
+// self-contained and always apply.
+
+const (
+	mainModuleFile = "main_module.json"
+	lazyRulesDir   = "lazy-rules"
+)
+
+// mainModule is what the lazy matching needs to know about the module being
+// built, the packages it imports and where custom rules are located. Every
+// compile action and tool id query needs it, so it's loaded once per build and
+// shared through a file, until any source file of the module changes.
+type mainModule struct {
+	GoMod    string            // Path to go.mod, empty if not in module mode
+	Digest   string            // Digest of the module files it's loaded from
+	Imports  map[string]bool   // Packages imported by the main module
+	Replaces map[string]string // Module paths replaced by local directories
+}
+
+func loadMainModule() (*mainModule, error) {
+	mm := &mainModule{
+		Imports:  map[string]bool{},
+		Replaces: map[string]string{},
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, errc.New(errc.ErrGetwd, err.Error())
+	}
+	gomod, err := findGoMod(dir)
+	if err != nil {
+		// Not in module mode, e.g. building the standard library, no hook
+		// packages are available then
+		return mm, nil
+	}
+	digest, err := digestModule(gomod)
+	if err != nil {
+		return nil, err
+	}
+	if cached := loadCachedMainModule(gomod, digest); cached != nil {
+		return cached, nil
+	}
+	mf, err := parseGoMod(gomod)
+	if err != nil {
+		return nil, err
+	}
+	mm.GoMod = gomod
+	mm.Digest = digest
+	for _, r := range mf.Replace {
+		// Only local replacements tell us where custom rules are located
+		if !modfile.IsDirectoryPath(r.New.Path) {
+			continue
+		}
+		p := r.New.Path
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(filepath.Dir(gomod), p)
+		}
+		mm.Replaces[r.Old.Path] = p
+	}
+	err = mm.collectImports()
+	if err != nil {
+		return nil, err
+	}
+	mm.store()
+	return mm, nil
+}
+
+func loadCachedMainModule(gomod, digest string) *mainModule {
+	data, err := os.ReadFile(util.GetTempBuildDirWith(mainModuleFile))
+	if err != nil {
+		return nil
+	}
+	mm := &mainModule{}
+	err = json.Unmarshal(data, mm)
+	if err != nil || mm.GoMod != gomod || mm.Digest != digest {
+		return nil
+	}
+	return mm
+}
+
+// store saves the main module for later compile actions. They run concurrently,
+// so it's written to a temporary file first and then moved into place. Failing
+// to store it only costs the later ones to load it again.
+func (mm *mainModule) store() {
+	data, err := json.Marshal(mm)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(util.TempBuildDir, mainModuleFile+".*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	_ = tmp.Close()
+	if err == nil {
+		err = os.Rename(tmp.Name(), util.GetTempBuildDirWith(mainModuleFile))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// walkModule visits all non-test Go files of the module, skipping what the go
+// command ignores, and nested modules
+func walkModule(gomod string, visit func(path string, d fs.DirEntry) error) error {
+	root := filepath.Dir(gomod)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+				name == "testdata" || name == "vendor" ||
+				util.PathExists(filepath.Join(path, util.GoModFile)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !util.IsGoFile(path) || util.IsGoTestFile(path) {
+			return nil
+		}
+		return visit(path, d)
+	})
+	if err != nil {
+		return errc.New(errc.ErrReadDir, err.Error())
+	}
+	return nil
+}
+
+// digestModule identifies the state of the module by go.mod, go.sum and the
+// size and modification time of its Go files, which is much cheaper than
+// parsing them
+func digestModule(gomod string) (string, error) {
+	h := sha256.New()
+	for _, name := range []string{util.GoModFile, "go.sum"} {
+		path := filepath.Join(filepath.Dir(gomod), name)
+		if util.PathNotExists(path) {
+			continue
+		}
+		fmt.Fprintf(h, "%s\n", name)
+		err := hashFile(h, path)
+		if err != nil {
+			return "", err
+		}
+	}
+	err := walkModule(gomod, func(path string, d fs.DirEntry) error {
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s %d %d\n", path, info.Size(),
+			info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// collectImports collects the imports of all non-test files of the main module,
+// build constraints are not taken into account
+func (mm *mainModule) collectImports() error {
+	fset := token.NewFileSet()
+	return walkModule(mm.GoMod, func(path string, d fs.DirEntry) error {
+		file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			// Leave the syntax error to the compiler
+			return nil
+		}
+		for _, spec := range file.Imports {
+			p, err := strconv.Unquote(spec.Path.Value)
+			if err == nil {
+				mm.Imports[p] = true
+			}
+		}
+		return nil
+	})
+}
+
+// dropUnimported removes func rules whose hook package is not imported
+func (mm *mainModule) dropUnimported(bundle *resource.RuleBundle) {
+	for file, funcRules := range bundle.File2FuncRules {
+		for fn, rules := range funcRules {
+			kept := make([]*resource.InstFuncRule, 0, len(rules))
+			for _, rule := range rules {
+				if rule.UseRaw || mm.Imports[rule.GetPath()] {
+					kept = append(kept, rule)
+					continue
+				}
+				util.Log("Skip rule %s as %s is not imported by %s",
+					rule, rule.GetPath(), mm.GoMod)
+			}
+			if len(kept) == 0 {
+				delete(funcRules, fn)
+			} else {
+				funcRules[fn] = kept
+			}
+		}
+		if len(funcRules) == 0 {
+			delete(bundle.File2FuncRules, file)
+		}
+	}
+}
+
+// resolvableImports reads the packages that the compile action is able to
+// import from its import config, i.e. the dependencies of the package
+func resolvableImports(importcfg string) (map[string]bool, error) {
+	data, err := util.ReadFile(importcfg)
+	if err != nil {
+		return nil, err
+	}
+	imports := map[string]bool{"unsafe": true, "C": true}
+	for _, line := range strings.Split(data, "\n") {
+		verb, args, found := strings.Cut(strings.TrimSpace(line), " ")
+		if !found || (verb != "packagefile" && verb != "importmap") {
+			continue
+		}
+		path, _, _ := strings.Cut(args, "=")
+		imports[path] = true
+	}
+	return imports, nil
+}
+
+// dropUnresolved removes file rules whose file imports packages out of reach
+// of the compile action, they would fail to compile otherwise
+func dropUnresolved(bundle *resource.RuleBundle, importcfg string) error {
+	if len(bundle.FileRules) == 0 {
+		return nil
+	}
+	imports, err := resolvableImports(importcfg)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	kept := make([]*resource.InstFileRule, 0, len(bundle.FileRules))
+	for _, rule := range bundle.FileRules {
+		file, err := parser.ParseFile(fset, rule.FileName, nil,
+			parser.ImportsOnly)
+		if err != nil {
+			return errc.New(errc.ErrParseCode, err.Error()).
+				With("file", rule.FileName)
+		}
+		missing := ""
+		for _, spec := range file.Imports {
+			p, err := strconv.Unquote(spec.Path.Value)
+			if err == nil && !imports[p] {
+				missing = p
+				break
+			}
+		}
+		if missing != "" {
+			util.Log("Skip rule %s as %s is not a dependency of %s",
+				rule, missing, bundle.ImportPath)
+			continue
+		}
+		kept = append(kept, rule)
+	}
+	bundle.FileRules = kept
+	return nil
+}
+
+// extractPkg extracts the embedded pkg module for lazy matching. Compile
+// actions run concurrently, so each of them extracts to a directory of its own
+// and tries to move it to the shared place, whoever comes first wins.
+func extractPkg() (string, error) {
+	bs, err := data.UseEmbededPkg()
+	if err != nil {
+		return "", errc.New(errc.ErrPreprocess, err.Error())
+	}
+	sum := sha256.Sum256(bs)
+	dir := util.GetTempBuildDirWith("alibaba-pkg-" +
+		hex.EncodeToString(sum[:])[:16])
+	dir, err = abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if util.PathExists(dir) {
+		return filepath.Join(dir, "pkg"), nil
+	}
+	tmp, err := os.MkdirTemp(util.TempBuildDir, "alibaba-pkg-*")
+	if err != nil {
+		return "", errc.New(errc.ErrMkdirAll, err.Error())
+	}
+	err = extractGZip(bs, tmp)
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		return "", err
+	}
+	err = os.Rename(tmp, dir)
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		if util.PathNotExists(dir) {
+			return "", errc.New(errc.ErrMkdirAll, err.Error())
+		}
+	}
+	return filepath.Join(dir, "pkg"), nil
+}
+
+// lazyModVersion finds the version of the module that provides the package.
+// Modules in the module cache carry their versions in the path, otherwise the
+// module graph is consulted, but only if any rule requires a version range.
+func lazyModVersion(importPath string, files []string,
+	rules []resource.InstRule) (modVersion, error) {
+	if len(files) > 0 {
+		if version := extractVersion(files[0]); version != "" {
+			return modVersion{version: version}, nil
+		}
+	}
+	ranged := false
+	for _, rule := range rules {
+		if rule.GetVersion() != "" {
+			ranged = true
+			break
+		}
+	}
+	if !ranged {
+		return modVersion{}, nil
+	}
+	m, err := lookupModule(importPath)
+	if err != nil || m == nil {
+		return modVersion{}, err
+	}
+	requires := make(map[string]string)
+	if m.Main && m.GoMod != "" {
+		// Used by go.work, its original version is what the module being
+		// built requires
+		dir, err := os.Getwd()
+		if err != nil {
+			return modVersion{}, errc.New(errc.ErrGetwd, err.Error())
+		}
+		gomod, err := findGoMod(dir)
+		if err == nil && gomod != m.GoMod {
+			requires = mainRequires([]*listedPackage{
+				{Module: &listedModule{Main: true, GoMod: gomod}},
+			})
+		}
+	}
+	return resolveVersion(m, requires), nil
+}
+
+// lazyRulesKey identifies the available rules without loading them, i.e. by the
+// tool binary, the configuration and the custom rule files. Like digestModule,
+// sizes and modification times of the files stand in for their contents.
+func lazyRulesKey() (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "tool %s\n", config.ToolVersion)
+	exe, err := os.Executable()
+	if err != nil {
+		return "", errc.New(errc.ErrGetExecutable, err.Error())
+	}
+	conf, err := json.Marshal(config.GetConf())
+	if err != nil {
+		return "", errc.New(errc.ErrInvalidJSON, err.Error())
+	}
+	fmt.Fprintf(h, "config %s\n", conf)
+	files := []string{exe}
+	if config.GetConf().RuleJsonFiles != "" {
+		files = append(files,
+			strings.Split(config.GetConf().RuleJsonFiles, ",")...)
+	}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			fmt.Fprintf(h, "%s missing\n", file)
+			continue
+		}
+		fmt.Fprintf(h, "%s %d %d\n", file, info.Size(),
+			info.ModTime().UnixNano())
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// lazyRulesFile is where the rules targeting the package are stored
+func lazyRulesFile(dir, importPath string) string {
+	sum := sha256.Sum256([]byte(importPath))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])[:16]+".json")
+}
+
+// storeLazyRules loads the available rules and stores them by the packages
+// they target. Compile actions run concurrently, so they are written to a
+// directory of its own and moved to the shared place, as in extractPkg.
+func storeLazyRules(dir string) error {
+	tmp, err := os.MkdirTemp(util.TempBuildDir, lazyRulesDir+"-*")
+	if err != nil {
+		return errc.New(errc.ErrMkdirAll, err.Error())
+	}
+	for importPath, rules := range newRuleMatcher().availableRules {
+		bs, err := json.Marshal(rules)
+		if err != nil {
+			_ = os.RemoveAll(tmp)
+			return errc.New(errc.ErrInvalidJSON, err.Error())
+		}
+		_, err = util.WriteFile(lazyRulesFile(tmp, importPath), string(bs))
+		if err != nil {
+			_ = os.RemoveAll(tmp)
+			return err
+		}
+	}
+	err = os.Rename(tmp, dir)
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		if util.PathNotExists(dir) {
+			return errc.New(errc.ErrMkdirAll, err.Error())
+		}
+	}
+	return nil
+}
+
+// lazyRules returns the available rules targeting the package. All rules are
+// loaded once per configuration and shared through the .otel-build directory,
+// every compile action then only reads the rules of the package it compiles.
+func lazyRules(importPath string) ([]resource.InstRule, error) {
+	key, err := lazyRulesKey()
+	if err != nil {
+		return nil, err
+	}
+	dir := util.GetTempBuildDirWith(lazyRulesDir + "-" + key)
+	if util.PathNotExists(dir) {
+		err = storeLazyRules(dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	data, err := os.ReadFile(lazyRulesFile(dir, importPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errc.New(errc.ErrOpenFile, err.Error())
+	}
+	return loadRuleRaw(string(data))
+}
+
+// MatchPackage matches the available rules against the package being compiled
+// when the tool is used as a plain -toolexec. importcfg is the import config
+// of the compile action, if any. goVersion is called only if there are any
+// candidate rules for the package.
+func MatchPackage(importPath string, files []string, importcfg string,
+	goVersion func() (string, error)) (*resource.RuleBundle, error) {
+	rules, err := lazyRules(importPath)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+	matcher := &ruleMatcher{
+		availableRules: map[string][]resource.InstRule{importPath: rules},
+	}
+	version, err := goVersion()
+	if err != nil {
+		return nil, err
+	}
+	modVer, err := lazyModVersion(importPath, files, rules)
+	if err != nil {
+		return nil, err
+	}
+	bundle, _ := matcher.match(importPath, modVer, version, files, nil)
+	if !bundle.IsValid() {
+		return nil, nil
+	}
+	mm, err := loadMainModule()
+	if err != nil {
+		return nil, err
+	}
+	mm.dropUnimported(bundle)
+	if !bundle.IsValid() {
+		return nil, nil
+	}
+	pkgDir, err := extractPkg()
+	if err != nil {
+		return nil, err
+	}
+	bundles := []*resource.RuleBundle{bundle}
+	err = rectifyBundles(bundles, pkgDir, mm.Replaces)
+	if err != nil {
+		return nil, err
+	}
+	if importcfg != "" {
+		err = dropUnresolved(bundle, importcfg)
+		if err != nil {
+			return nil, err
+		}
+		if !bundle.IsValid() {
+			return nil, nil
+		}
+	}
+	return bundle, nil
+}
+
+// LazyBuildKey is the counterpart of the build key for builds that use the tool
+// as a plain -toolexec. Which rules apply is decided per package, so the key
+// covers the hook packages imported by the main module and the hook code of
+// all custom rules instead of the matched ones.
+func LazyBuildKey() (string, error) {
+	h, err := newBuildKeyHash()
+	if err != nil {
+		return "", err
+	}
+	mm, err := loadMainModule()
+	if err != nil {
+		return "", err
+	}
+	hooks := map[string]bool{}
+	for _, rule := range findAvailableRules() {
+		path := rule.GetPath()
+		if path == "" || hooks[path] {
+			continue
+		}
+		hooks[path] = true
+		if dir, ok := mm.Replaces[path]; ok {
+			err = hashHookDir(h, dir)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	imported := make([]string, 0)
+	for path := range hooks {
+		if mm.Imports[path] {
+			imported = append(imported, path)
+		}
+	}
+	sort.Strings(imported)
+	for _, path := range imported {
+		fmt.Fprintf(h, "import %s\n", path)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
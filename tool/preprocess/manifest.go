@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/config"
+	"github.com/alibaba/loongsuite-go-agent/tool/data"
+	"github.com/alibaba/loongsuite-go-agent/tool/resource"
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+)
+
+// The manifest variable of the pkg module, the runtime reads it to tell which
+// distribution produced the telemetry
+const manifestVar = pkgPrefix + ".otelManifest"
+
+// ruleSetHashes returns the content hashes of the enabled default rule files
+// and the custom rule files, custom ones are keyed by their base names as the
+// full paths vary from machine to machine
+func ruleSetHashes() map[string]string {
+	hashes := make(map[string]string)
+	sum := func(content []byte) string {
+		s := sha256.Sum256(content)
+		return hex.EncodeToString(s[:])
+	}
+	files, err := data.ListRuleFiles()
+	if err != nil {
+		util.Log("Failed to list default rule json files: %v", err)
+	}
+	for _, name := range enabledRuleFiles(files) {
+		content, err := data.ReadRuleFile(name)
+		if err != nil {
+			util.Log("Failed to read rule file %s: %v", name, err)
+			continue
+		}
+		hashes[name] = sum(content)
+	}
+	if custom := config.GetConf().RuleJsonFiles; custom != "" {
+		for _, file := range strings.Split(custom, ",") {
+			content, err := util.ReadFile(file)
+			if err != nil {
+				util.Log("Failed to read rule file %s: %v", file, err)
+				continue
+			}
+			hashes[filepath.Base(file)] = sum([]byte(content))
+		}
+	}
+	return hashes
+}
+
+// embedManifest adds the manifest to the generated importer, which is compiled
+// along with the main package
+func (dp *DepProcessor) embedManifest(key string, report *resource.Report) error {
+	conf := config.GetConf()
+	ruleFiles := make([]string, 0)
+	if conf.RuleJsonFiles != "" {
+		for _, file := range strings.Split(conf.RuleJsonFiles, ",") {
+			ruleFiles = append(ruleFiles, filepath.Base(file))
+		}
+	}
+	manifest := &resource.Manifest{
+		ToolVersion: config.ToolVersion,
+		GoVersion:   dp.goVersion,
+		BuildKey:    key,
+		Config: resource.ManifestConfig{
+			RuleJsonFiles: strings.Join(ruleFiles, ","),
+			DisableRules:  conf.DisableRules,
+			ReplacePolicy: conf.GetReplacePolicy(),
+			Verbose:       conf.Verbose,
+			Debug:         conf.Debug,
+		},
+		RuleSets: ruleSetHashes(),
+		Packages: resource.NewManifestPackages(report),
+	}
+	embedded, err := manifest.Embedded()
+	if err != nil {
+		return err
+	}
+	content, err := util.ReadFile(dp.generatedImporter)
+	if err != nil {
+		return err
+	}
+	content += fmt.Sprintf("//go:linkname _otel_manifest %s\n", manifestVar)
+	content += fmt.Sprintf("var _otel_manifest = %q\n", embedded)
+	_, err = util.WriteFile(dp.generatedImporter, content)
+	return err
+}
@@ -15,13 +15,13 @@
 package preprocess
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
+	"sort"
 	"strings"
 
 	"github.com/alibaba/loongsuite-go-agent/tool/config"
@@ -30,14 +30,15 @@ import (
 	"github.com/alibaba/loongsuite-go-agent/tool/resource"
 	"github.com/alibaba/loongsuite-go-agent/tool/util"
 	"github.com/dave/dst"
-	"golang.org/x/mod/module"
-	"golang.org/x/mod/semver"
 	"golang.org/x/sync/errgroup"
 )
 
 type ruleMatcher struct {
 	availableRules map[string][]resource.InstRule
-	moduleVersions []*vendorModule // vendor used only
+	// Rules disabled by configuration and the files they are defined in, they
+	// are only reported but never matched
+	disabledRules map[string][]resource.InstRule
+	disabledBy    map[resource.InstRule]string
 }
 
 func newRuleMatcher() *ruleMatcher {
@@ -51,6 +52,40 @@ func newRuleMatcher() *ruleMatcher {
 	return &ruleMatcher{availableRules: rules}
 }
 
+// withDisabledRules makes the matcher aware of the disabled default rules, so
+// that they are reported as skipped for the packages they target
+func (rm *ruleMatcher) withDisabledRules() *ruleMatcher {
+	rm.disabledRules = make(map[string][]resource.InstRule)
+	rm.disabledBy = make(map[resource.InstRule]string)
+	files, err := data.ListRuleFiles()
+	if err != nil {
+		util.Log("Failed to list default rule json files: %v", err)
+		return rm
+	}
+	enabled := enabledRuleFiles(files)
+	for _, name := range files {
+		if slices.Contains(enabled, name) {
+			continue
+		}
+		raw, err := data.ReadRuleFile(name)
+		if err != nil {
+			util.Log("Failed to read rule file %s: %v", name, err)
+			continue
+		}
+		rules, err := loadRuleRaw(string(raw))
+		if err != nil {
+			util.Log("Failed to parse rule file %s: %v", name, err)
+			continue
+		}
+		for _, rule := range rules {
+			importPath := rule.GetImportPath()
+			rm.disabledRules[importPath] = append(rm.disabledRules[importPath], rule)
+			rm.disabledBy[rule] = name
+		}
+	}
+	return rm
+}
+
 type ruleHolder struct {
 	resource.InstBaseRule
 	resource.InstFileRule
@@ -76,6 +111,17 @@ func loadRuleRaw(content string) ([]resource.InstRule, error) {
 	}
 	rules := make([]resource.InstRule, 0)
 	for _, rule := range h {
+		// Empty legacy ranges were accepted before, skip such rules only
+		if util.EmptyLegacyRange(rule.Version) ||
+			util.EmptyLegacyRange(rule.GoVersion) {
+			util.Log("Warning: skip rule %s with empty version range %s %s",
+				rule.ImportPath, rule.Version, rule.GoVersion)
+			continue
+		}
+		err = rule.InstBaseRule.Verify()
+		if err != nil {
+			return nil, errc.Adhere(err, "rule", rule.ImportPath)
+		}
 		if rule.StructType != "" {
 			r := &rule.InstStructRule
 			r.InstBaseRule = rule.InstBaseRule
@@ -97,15 +143,8 @@ func loadRuleRaw(content string) ([]resource.InstRule, error) {
 
 type chunk []resource.InstRule
 
-func loadDefaultRules() []resource.InstRule {
-	// Read all default embedded rule files
-	files, err := data.ListRuleFiles()
-	if err != nil {
-		util.Log("Failed to list default rule json files: %v", err)
-		return nil
-	}
-
-	// Disable specific rules if specified
+// enabledRuleFiles filters out the default rule files disabled by configuration
+func enabledRuleFiles(files []string) []string {
 	filteredFiles := make([]string, 0)
 	disable := config.GetConf().GetDisabledRules()
 	switch disable {
@@ -124,6 +163,19 @@ func loadDefaultRules() []resource.InstRule {
 			}
 		}
 	}
+	return filteredFiles
+}
+
+func loadDefaultRules() []resource.InstRule {
+	// Read all default embedded rule files
+	files, err := data.ListRuleFiles()
+	if err != nil {
+		util.Log("Failed to list default rule json files: %v", err)
+		return nil
+	}
+
+	// Disable specific rules if specified
+	filteredFiles := enabledRuleFiles(files)
 
 	// Load and parse each rule file concurrently
 	ruleChunks := make([]chunk, len(filteredFiles))
@@ -172,8 +224,6 @@ func loadDefaultRules() []resource.InstRule {
 }
 
 func findAvailableRules() []resource.InstRule {
-	util.GuaranteeInPreprocess()
-
 	rules := make([]resource.InstRule, 0)
 
 	// Load default rules (filtering is handled inside loadDefaultRules)
@@ -219,77 +269,35 @@ func extractVersion(path string) string {
 	return version[1 : len(version)-1]
 }
 
-// splitVersionRange splits the version range into two parts, start and end.
-func splitVersionRange(vr string) (string, string) {
-	util.Assert(strings.Contains(vr, ","), "invalid version range format")
-	util.Assert(strings.Contains(vr, "["), "invalid version range format")
-	util.Assert(strings.Contains(vr, ")"), "invalid version range format")
-
-	start := vr[1:strings.Index(vr, ",")]
-	end := vr[strings.Index(vr, ",")+1 : len(vr)-1]
-	return "v" + start, "v" + end
-}
-
-// matchVersion checks if the version string matches the version range in the
-// rule. The version range is in format [start, end), where start is inclusive
-// and end is exclusive. If the rule version string is empty, it always matches.
-func matchVersion(version string, ruleVersion string) (bool, error) {
-	// Fast path, always match if the rule version is not specified
-	if ruleVersion == "" {
-		return true, nil
-	}
-	// Check if both rule version and package version are in sane
-	if !strings.Contains(version, "v") {
-		return false, errc.New(errc.ErrMatchRule,
-			fmt.Sprintf("invalid version %v", version))
-	}
-	if !strings.Contains(ruleVersion, "[") ||
-		!strings.Contains(ruleVersion, ")") ||
-		!strings.Contains(ruleVersion, ",") ||
-		strings.Contains(ruleVersion, "v") {
-		return false, errc.New(errc.ErrMatchRule,
-			fmt.Sprintf("invalid rule version %v", ruleVersion))
-	}
-	// Remove extra whitespace from the rule version string
-	ruleVersion = strings.ReplaceAll(ruleVersion, " ", "")
-
-	// Compare the version with the rule version, the rule version is in the
-	// format [start, end), where start is inclusive and end is exclusive
-	// and start or end can be omitted, which means the range is open-ended.
-	ruleVersionStart, ruleVersionEnd := splitVersionRange(ruleVersion)
-	switch {
-	case ruleVersionStart != "v" && ruleVersionEnd != "v":
-		// Full version range
-		if semver.Compare(version, ruleVersionStart) >= 0 &&
-			semver.Compare(version, ruleVersionEnd) < 0 {
-			return true, nil
-		}
-	case ruleVersionStart == "v":
-		// Only end is specified
-		util.Assert(ruleVersionEnd != "v", "sanity check")
-		if semver.Compare(version, ruleVersionEnd) < 0 {
-			return true, nil
-		}
-	case ruleVersionEnd == "v":
-		// Only start is specified
-		util.Assert(ruleVersionStart != "v", "sanity check")
-		if semver.Compare(version, ruleVersionStart) >= 0 {
-			return true, nil
-		}
-	default:
-		return false, errc.New(errc.ErrMatchRule,
-			fmt.Sprintf("invalid rule version range %v", ruleVersion))
+// goSemver converts the version of the Go toolchain to the semantic version
+// that rules are matched with, e.g. go1.23.1 to v1.23.1 and go1.24rc1 to
+// v1.24.0-rc1. Anything after the version, e.g. " X:boringcrypto", is dropped.
+func goSemver(goVersion string) string {
+	goVersion, _, _ = strings.Cut(strings.TrimPrefix(goVersion, "go"), " ")
+	i := strings.IndexFunc(goVersion, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		return "v" + goVersion
+	}
+	core, pre := goVersion[:i], goVersion[i:]
+	for strings.Count(core, ".") < 2 {
+		core += ".0"
 	}
-	return false, nil
+	return "v" + core + "-" + pre
 }
 
-// match gives compilation arguments and finds out all interested rules
-// for it.
-func (rm *ruleMatcher) match(cmdArgs []string) *resource.RuleBundle {
-	importPath := findFlagValue(cmdArgs, util.BuildPattern)
+// match gives the package to be compiled and finds out all interested rules
+// for it. It also reports which of the rules targeting the package are applied
+// and why the others are skipped, the report is nil if there are none. The
+// cgo files are not instrumented as the compiler only sees their generated
+// variants, rules that target them are reported as skipped.
+func (rm *ruleMatcher) match(importPath string, version modVersion,
+	goVersion string, files []string, cgoFiles []string) (*resource.RuleBundle,
+	*resource.PackageReport) {
 	util.Assert(importPath != "", "sanity check")
 	if config.GetConf().Verbose {
-		util.Log("RunMatch: %v (%v)", importPath, cmdArgs)
+		util.Log("RunMatch: %v (%v)", importPath, files)
 	}
 	availables := make([]resource.InstRule, len(rm.availableRules[importPath]))
 
@@ -297,56 +305,58 @@ func (rm *ruleMatcher) match(cmdArgs []string) *resource.RuleBundle {
 	// the instrumentation rule, but first we need to check if the package name
 	// are already registered, to avoid futile effort
 	copy(availables, rm.availableRules[importPath])
-	if len(availables) == 0 {
-		return nil // fast fail
+	disabled := rm.disabledRules[importPath]
+	if len(availables) == 0 && len(disabled) == 0 {
+		return nil, nil // fast fail
 	}
 	parsedAst := make(map[string]*dst.File)
 	bundle := resource.NewRuleBundle(importPath)
+	report := &resource.PackageReport{
+		ImportPath: importPath,
+		Version:    version.version,
+	}
+	// Why the rule does not match so far, it's reported if it never matches
+	reasons := make(map[resource.InstRule]string)
+	applied := func(rule resource.InstRule, file string) {
+		rr := resource.NewRuleReport(rule)
+		rr.File = file
+		report.Applied = append(report.Applied, rr)
+	}
 
-	goVersion := findFlagValue(cmdArgs, util.BuildGoVer)
 	util.Assert(goVersion != "", "sanity check")
 	util.Assert(strings.HasPrefix(goVersion, "go"), "sanity check")
-	goVersion = strings.Replace(goVersion, "go", "v", 1)
-	for _, candidate := range cmdArgs {
-		// It's not a go file, ignore silently
-		if !util.IsGoFile(candidate) {
-			continue
-		}
-		file := candidate
-
-		// If it's a vendor build, we need to extract the version of the module
-		// from vendor/modules.txt, otherwise we find the version from source
-		// code file path
-		version := extractVersion(file)
-		if rm.moduleVersions != nil {
-			recorded := findVendorModuleVersion(rm.moduleVersions, importPath)
-			if recorded != "" {
-				version = recorded
-			}
-		}
-
+	goVersion = goSemver(goVersion)
+	for _, file := range files {
 		for i := len(availables) - 1; i >= 0; i-- {
 			rule := availables[i]
 
 			// Check if the version is supported
-			matched, err := matchVersion(version, rule.GetVersion())
+			matched, err := version.match(rule.GetVersion())
 			if err != nil {
 				util.Log("Bad match: file %s, rule %s, version %s",
-					file, rule, version)
+					file, rule, version.version)
+				reasons[rule] = fmt.Sprintf("invalid version %q or rule version %q",
+					version.version, rule.GetVersion())
 				continue
 			}
 			if !matched {
+				reasons[rule] = fmt.Sprintf("version %s is out of range %s",
+					version.version, rule.GetVersion())
 				continue
 			}
 			// Check if the rule requires a specific Go version(range)
 			if rule.GetGoVersion() != "" {
-				matched, err = matchVersion(goVersion, rule.GetGoVersion())
+				matched, err = util.MatchVersion(goVersion, rule.GetGoVersion())
 				if err != nil {
 					util.Log("Bad match: file %s, rule %s, go version %s",
 						file, rule, goVersion)
+					reasons[rule] = fmt.Sprintf("invalid go version %q",
+						rule.GetGoVersion())
 					continue
 				}
 				if !matched {
+					reasons[rule] = fmt.Sprintf("go version %s is out of range %s",
+						goVersion, rule.GetGoVersion())
 					continue
 				}
 			}
@@ -357,11 +367,13 @@ func (rm *ruleMatcher) match(cmdArgs []string) *resource.RuleBundle {
 				ast, err := util.ParseAstFromFileOnlyPackage(file)
 				if ast == nil || err != nil {
 					util.Log("Failed to parse %s: %v", file, err)
+					reasons[rule] = "failed to parse " + file
 					continue
 				}
 				util.Log("Match file rule %s", rule)
 				bundle.AddFileRule(rule.(*resource.InstFileRule))
 				bundle.SetPackageName(ast.Name.Name)
+				applied(rule, "")
 				availables = append(availables[:i], availables[i+1:]...)
 				continue
 			}
@@ -372,6 +384,7 @@ func (rm *ruleMatcher) match(cmdArgs []string) *resource.RuleBundle {
 				fileAst, err := util.ParseAstFromFileFast(file)
 				if fileAst == nil || err != nil {
 					util.Log("failed to parse file %s: %v", file, err)
+					reasons[rule] = "failed to parse " + file
 					continue
 				}
 				parsedAst[file] = fileAst
@@ -386,6 +399,7 @@ func (rm *ruleMatcher) match(cmdArgs []string) *resource.RuleBundle {
 				// Failed to parse the file, stop here and log only
 				// since it's a tolerant failure
 				util.Log("Failed to parse file %s", file)
+				reasons[rule] = "failed to parse " + file
 				continue
 			}
 
@@ -396,10 +410,11 @@ func (rm *ruleMatcher) match(cmdArgs []string) *resource.RuleBundle {
 					if rl, ok := rule.(*resource.InstStructRule); ok {
 						if util.MatchStructDecl(genDecl, rl.StructType) {
 							util.Log("Match struct rule %s with %v",
-								rule, cmdArgs)
+								rule, importPath)
 							err = bundle.AddFile2StructRule(file, rl)
 							if err != nil {
 								util.Log("Failed to add struct rule: %v", err)
+								reasons[rule] = err.Error()
 								continue
 							}
 							valid = true
@@ -409,10 +424,11 @@ func (rm *ruleMatcher) match(cmdArgs []string) *resource.RuleBundle {
 				} else if funcDecl, ok := decl.(*dst.FuncDecl); ok {
 					if rl, ok := rule.(*resource.InstFuncRule); ok {
 						if util.MatchFuncDecl(funcDecl, rl.Function, rl.ReceiverType) {
-							util.Log("Match func rule %s with %v", rule, cmdArgs)
+							util.Log("Match func rule %s with %v", rule, importPath)
 							err = bundle.AddFile2FuncRule(file, rl)
 							if err != nil {
 								util.Log("Failed to add func rule: %v", err)
+								reasons[rule] = err.Error()
 								continue
 							}
 							valid = true
@@ -422,201 +438,149 @@ func (rm *ruleMatcher) match(cmdArgs []string) *resource.RuleBundle {
 				}
 			}
 			if valid {
+				applied(rule, file)
 				// Remove the rule from the available rules
 				availables = append(availables[:i], availables[i+1:]...)
 			}
 		}
 	}
-	return bundle
-}
 
-func findFlagValue(cmd []string, flag string) string {
-	for i, v := range cmd {
-		if v == flag {
-			return cmd[i+1]
-		}
-	}
-	return ""
-}
-
-// vendorModule represents a module in vendor/modules.txt file, it contains
-// the module name, version and all submodules of the module, which looks like
-//
-// # golang.org/x/text v0.21.0
-// ## explicit; go 1.18
-// golang.org/x/text/secure/bidirule
-// golang.org/x/text/transform
-// golang.org/x/text/unicode/bidi
-// golang.org/x/text/unicode/norm
-// # golang.org/x/time v0.5.0
-// ## explicit; go 1.18
-// golang.org/x/time/rate
-//
-// The module name is the first line of the module, the version is the second
-// part of the first line, and all submodules are listed in the following lines
-// starting with the module name.
-type vendorModule struct {
-	path       string
-	version    string
-	submodules []string
-}
-
-func findVendorModuleVersion(modules []*vendorModule, importPath string) string {
-	for _, module := range modules {
-		if module.path == importPath {
-			return module.version
+	// Tell why the rules declared in cgo files are not applied, the matching
+	// is done as above but the result is only reported
+	for _, file := range cgoFiles {
+		tree, err := util.ParseAstFromFileFast(file)
+		if tree == nil || err != nil {
+			util.Log("failed to parse file %s: %v", file, err)
+			continue
 		}
-		for _, submodule := range module.submodules {
-			if submodule == importPath {
-				return module.version
+		for _, rule := range availables {
+			if matchDecls(tree, rule) {
+				util.Log("Skip rule %s as it targets cgo file %s", rule, file)
+				reasons[rule] = "cgo file " + file + " is not instrumented"
 			}
 		}
 	}
-	return ""
-}
 
-func cutPrefix(s, prefix string) (after string, found bool) {
-	// Compatible with go1.18 as we use this version internally
-	if !strings.HasPrefix(s, prefix) {
-		return s, false
-	}
-	return s[len(prefix):], true
-}
-
-func parseVendorModules(projDir string) ([]*vendorModule, error) {
-	vendorFile := filepath.Join(projDir, "vendor", "modules.txt")
-	if util.PathNotExists(vendorFile) {
-		return nil, errc.New(errc.ErrNotExist, "vendor/modules.txt not found")
+	// Whatever remains is skipped
+	for _, rule := range availables {
+		rr := resource.NewRuleReport(rule)
+		rr.Reason = reasons[rule]
+		if rr.Reason == "" {
+			rr.Reason = fmt.Sprintf("%s %s is not found", rr.Kind, rr.Target)
+		}
+		report.Skipped = append(report.Skipped, rr)
 	}
-	file, err := os.Open(vendorFile)
-	if err != nil {
-		return nil, errc.New(errc.ErrOpenFile, err.Error())
+	for _, rule := range disabled {
+		rr := resource.NewRuleReport(rule)
+		rr.Reason = "disabled by " + rm.disabledBy[rule]
+		report.Skipped = append(report.Skipped, rr)
 	}
-	defer func(dryRunLog *os.File) {
-		err := dryRunLog.Close()
-		if err != nil {
-			util.Log("Failed to close dry run log file: %v", err)
-		}
-	}(file)
-
-	scanner := bufio.NewScanner(file)
-	// 10MB should be enough to accommodate most long line
-	buffer := make([]byte, 0, 10*1024*1024)
-	scanner.Buffer(buffer, cap(buffer))
-
-	vms := make([]*vendorModule, 0)
-	mod := &vendorModule{}
-	vendorVersion := make(map[string]string)
-	// From src/cmd/go/internal/modload/vendor.go
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "# ") {
-			f := strings.Fields(line)
-
-			if len(f) < 3 {
-				continue
-			}
-			if semver.IsValid(f[2]) {
-				// A module, but we don't yet know whether it is in the build list or
-				// only included to indicate a replacement.
-				mod = &vendorModule{path: f[1], version: f[2]}
-				f = f[3:]
-			} else if f[2] == "=>" {
-				// A wildcard replacement found in the main module's go.mod file.
-				mod = &vendorModule{path: f[1]}
-				f = f[2:]
-			} else {
-				// Not a version or a wildcard replacement.
-				// We don't know how to interpret this module line, so ignore it.
-				mod = &vendorModule{}
-				continue
-			}
+	return bundle, report
+}
 
-			if len(f) >= 2 && f[0] == "=>" {
-				// Skip replacement lines
+// matchDecls checks if the func or struct rule targets a declaration of the file
+func matchDecls(tree *dst.File, rule resource.InstRule) bool {
+	for _, decl := range tree.Decls {
+		switch rl := rule.(type) {
+		case *resource.InstStructRule:
+			if util.MatchStructDecl(decl, rl.StructType) {
+				return true
 			}
-			continue
-		}
-
-		// Not a module line. Must be a package within a module or a metadata
-		// directive, either of which requires a preceding module line.
-		if mod.path == "" {
-			continue
-		}
-
-		if _, ok := cutPrefix(line, "## "); ok {
-			// Skip annotations lines
-			continue
-		}
-
-		if f := strings.Fields(line); len(f) == 1 && module.CheckImportPath(f[0]) == nil {
-			// A package within the current module.
-			mod.submodules = append(mod.submodules, f[0])
-
-			// Since this module provides a package for the build, we know that it
-			// is in the build list and is the selected version of its path.
-			// If this information is new, record it.
-			if v, ok := vendorVersion[mod.path]; !ok || semver.Compare(v, mod.version) < 0 {
-				vms = append(vms, mod)
-				vendorVersion[mod.path] = mod.version
+		case *resource.InstFuncRule:
+			if util.MatchFuncDecl(decl, rl.Function, rl.ReceiverType) {
+				return true
 			}
 		}
 	}
-	err = scanner.Err()
-	if err != nil {
-		return nil, errc.New(errc.ErrParseCode,
-			"cannot parse vendor/modules.txt")
-	}
-	return vms, nil
+	return false
 }
 
-func runMatch(matcher *ruleMatcher, cmd string, ch chan *resource.RuleBundle) {
-	bundle := matcher.match(util.SplitCmds(cmd))
-	ch <- bundle
+type matchResult struct {
+	bundle *resource.RuleBundle
+	report *resource.PackageReport
 }
 
-func (dp *DepProcessor) matchRules() ([]*resource.RuleBundle, error) {
-	defer util.PhaseTimer("Match")()
-	// Run a dry build to get all dependencies needed for the project
-	// Match the dependencies with available rules and prepare them
-	// for the actual instrumentation
-	// Run dry build to the build blueprint
-	compileCmds, err := runDryBuild(dp.goBuildCmd)
-	if err != nil {
-		// Tell us more about what happened in the dry run
-		errLog, _ := util.ReadFile(util.GetLogPath(DryRunLog))
-		err = errc.Adhere(err, "reason", errLog)
-		return nil, err
+func runMatch(matcher *ruleMatcher, goVersion string, pkg *listedPackage,
+	requires map[string]string, ch chan matchResult) {
+	// The compiler knows the main package as "main" rather than its import
+	// path, which is what the rules refer to as well
+	importPath := pkg.ImportPath
+	if pkg.Name == "main" {
+		importPath = "main"
 	}
-
-	matcher := newRuleMatcher()
-
-	// If we are in vendor mode, we need to parse the vendor/modules.txt file
-	// to get the version of each module for future matching
-	if dp.vendorMode {
-		modules, err := parseVendorModules(dp.getGoModDir())
-		if err != nil {
-			return nil, err
-		}
-		if config.GetConf().Verbose {
-			util.Log("Vendor modules: %v", modules)
-		}
-		matcher.moduleVersions = modules
+	files := make([]string, 0, len(pkg.GoFiles))
+	for _, file := range pkg.GoFiles {
+		files = append(files, filepath.Join(pkg.Dir, file))
 	}
+	cgoFiles := make([]string, 0, len(pkg.CgoFiles))
+	for _, file := range pkg.CgoFiles {
+		cgoFiles = append(cgoFiles, filepath.Join(pkg.Dir, file))
+	}
+	// Find the version from the module graph, or from the source file path
+	// if the package is not loaded in module mode
+	version := modVersion{}
+	if pkg.Module != nil {
+		version = resolveVersion(pkg.Module, requires)
+	} else if len(files) > 0 {
+		version.version = extractVersion(files[0])
+	}
+	bundle, report := matcher.match(importPath, version, goVersion, files,
+		cgoFiles)
+	ch <- matchResult{bundle, report}
+}
 
-	// Find used instrumentation rule according to compile commands
-	ch := make(chan *resource.RuleBundle)
-	for _, cmd := range compileCmds {
-		go runMatch(matcher, cmd, ch)
+// matchPackages matches the packages with available rules and prepares them
+// for the actual instrumentation
+func (dp *DepProcessor) matchPackages(matcher *ruleMatcher,
+	pkgs []*listedPackage, requires map[string]string) (
+	[]*resource.RuleBundle, []*resource.PackageReport) {
+	ch := make(chan matchResult)
+	for _, pkg := range pkgs {
+		go runMatch(matcher, dp.goVersion, pkg, requires, ch)
 	}
 	cnt := 0
 	bundles := make([]*resource.RuleBundle, 0)
-	for cnt < len(compileCmds) {
-		bundle := <-ch
-		if bundle.IsValid() {
-			bundles = append(bundles, bundle)
+	reports := make([]*resource.PackageReport, 0)
+	for cnt < len(pkgs) {
+		result := <-ch
+		if result.bundle.IsValid() {
+			bundles = append(bundles, result.bundle)
+		}
+		if result.report != nil {
+			reports = append(reports, result.report)
 		}
 		cnt++
 	}
-	return bundles, nil
+	return bundles, reports
+}
+
+// storeReport saves the report of the resolved rules
+func (dp *DepProcessor) storeReport(reports []*resource.PackageReport) (
+	*resource.Report, error) {
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ImportPath < reports[j].ImportPath
+	})
+	byTarget := func(rrs []*resource.RuleReport) func(i, j int) bool {
+		return func(i, j int) bool {
+			if rrs[i].Kind != rrs[j].Kind {
+				return rrs[i].Kind < rrs[j].Kind
+			}
+			return rrs[i].Target < rrs[j].Target
+		}
+	}
+	for _, report := range reports {
+		sort.SliceStable(report.Applied, byTarget(report.Applied))
+		sort.SliceStable(report.Skipped, byTarget(report.Skipped))
+	}
+	report := &resource.Report{
+		ToolVersion: config.ToolVersion,
+		GoVersion:   dp.goVersion,
+		Command:     os.Args[1:],
+		Packages:    reports,
+	}
+	err := resource.StoreReport(report)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
 }
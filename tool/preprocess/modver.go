@@ -0,0 +1,186 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/config"
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+	"golang.org/x/mod/semver"
+)
+
+// Rules may require a range of versions of the module they target. The version
+// is taken from the module graph rather than the path of the source files,
+// which carries no version if the module is replaced by a local directory, used
+// by go.work or vendored. Which version a replaced module is matched with is up
+// to the replace policy.
+
+// listedModule is the subset of module information reported by go list
+type listedModule struct {
+	Path    string
+	Version string
+	Main    bool
+	GoMod   string
+	Replace *listedModule
+}
+
+// modVersion is the version of the module a package belongs to
+type modVersion struct {
+	version string // Semantic version, empty if unknown
+	any     bool   // Whether it matches any version range, see "match" policy
+}
+
+// match checks if the module version matches the version range of the rule
+func (mv modVersion) match(ruleVersion string) (bool, error) {
+	if mv.any {
+		return true, nil
+	}
+	return util.MatchVersion(mv.version, ruleVersion)
+}
+
+// resolveVersion determines the version of the module with the replace policy
+// applied. Modules used by go.work are main modules, their original versions
+// are what other main modules require, as recorded in requires.
+func resolveVersion(m *listedModule, requires map[string]string) modVersion {
+	original := m.Version
+	if m.Main {
+		original = requires[m.Path]
+		if original == "" {
+			// The main module we are building, or not required by anyone
+			return modVersion{}
+		}
+	} else if m.Replace == nil {
+		return modVersion{version: m.Version}
+	}
+	switch config.GetConf().GetReplacePolicy() {
+	case config.ReplacePolicyMatch:
+		return modVersion{version: original, any: true}
+	case config.ReplacePolicyReplaced:
+		// Versions of a fork with another module path or a local directory
+		// are not comparable with the original one, fall back to it
+		if m.Replace != nil && m.Replace.Path == m.Path &&
+			m.Replace.Version != "" {
+			return modVersion{version: m.Replace.Version}
+		}
+	}
+	return modVersion{version: original}
+}
+
+// mainRequires collects the versions of modules required by the main modules,
+// the highest one wins if a module is required by several of them
+func mainRequires(pkgs []*listedPackage) map[string]string {
+	requires := make(map[string]string)
+	visited := make(map[string]bool)
+	for _, pkg := range pkgs {
+		m := pkg.Module
+		if m == nil || !m.Main || m.GoMod == "" || visited[m.GoMod] {
+			continue
+		}
+		visited[m.GoMod] = true
+		mf, err := parseGoMod(m.GoMod)
+		if err != nil {
+			util.Log("Failed to parse %s: %v", m.GoMod, err)
+			continue
+		}
+		for _, r := range mf.Require {
+			path, version := r.Mod.Path, r.Mod.Version
+			if semver.Compare(version, requires[path]) > 0 {
+				requires[path] = version
+			}
+		}
+	}
+	return requires
+}
+
+// vendorModules reads the modules recorded in vendor/modules.txt along with
+// their replacements, the format is defined by modload/vendor.go of the go
+// command, e.g.
+//
+//	# golang.org/x/text v0.21.0
+//	## explicit; go 1.18
+//	golang.org/x/text/transform
+//	# example.com/lib v1.2.0 => example.com/fork v1.2.1
+//	example.com/lib
+//
+// Lines of wildcard replacements come without versions, they are skipped.
+func vendorModules(vendorDir string) (map[string]*listedModule, error) {
+	data, err := util.ReadFile(filepath.Join(vendorDir, "modules.txt"))
+	if err != nil {
+		return nil, err
+	}
+	modules := make(map[string]*listedModule)
+	for _, line := range strings.Split(data, "\n") {
+		f := strings.Fields(line)
+		if len(f) < 3 || f[0] != "#" || !semver.IsValid(f[2]) {
+			continue
+		}
+		m := &listedModule{Path: f[1], Version: f[2]}
+		if len(f) >= 5 && f[3] == "=>" {
+			m.Replace = &listedModule{Path: f[4]}
+			if len(f) >= 6 {
+				m.Replace.Version = f[5]
+			}
+		}
+		modules[m.Path] = m
+	}
+	return modules, nil
+}
+
+// useVendored replaces the modules of the packages with the vendored ones, as
+// their versions are what the project is vendored with. They differ from the
+// module graph only if the hook modules require higher versions.
+func useVendored(pkgs []*listedPackage, vendored map[string]*listedModule) {
+	for _, pkg := range pkgs {
+		if pkg.Module == nil || pkg.Module.Main {
+			continue
+		}
+		m, ok := vendored[pkg.Module.Path]
+		if !ok {
+			continue
+		}
+		if m.Version != pkg.Module.Version {
+			util.Log("Vendored %s@%s differs from %s in the module graph",
+				m.Path, m.Version, pkg.Module.Version)
+		}
+		pkg.Module = m
+	}
+}
+
+// lookupModule asks go list for the module that provides the package, which is
+// nil for standard library packages. It's used when the tool is used as a plain
+// -toolexec, where the go command resolves the package in the same way.
+func lookupModule(importPath string) (*listedModule, error) {
+	args := []string{"go", "list", "-find", "-json=Module", importPath}
+	cmd := exec.Command(args[0], args[1:]...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	err := cmd.Run()
+	if err != nil {
+		return nil, errc.New(errc.ErrRunCmd, err.Error()).
+			With("command", strings.Join(args, " "))
+	}
+	pkg := &listedPackage{}
+	err = json.Unmarshal(out.Bytes(), pkg)
+	if err != nil {
+		return nil, errc.New(errc.ErrInvalidJSON, err.Error())
+	}
+	return pkg.Module, nil
+}
@@ -0,0 +1,381 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"encoding/json"
+	"go/version"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+	"golang.org/x/mod/modfile"
+)
+
+// The preprocess phase never touches the user's source tree. Additional
+// dependencies are added to a generated copy of go.mod, which is passed to the
+// go command via -modfile, while generated sources such as otel_importer.go
+// are placed into the tree virtually via -overlay. In workspace mode, go.work
+// is replaced by a generated copy via GOWORK as well, where the go command
+// records checksums of the additional dependencies. An interrupted build thus
+// leaves nothing behind but the .otel-build directory.
+
+const (
+	OtelOverlay = "overlay.json"
+	FlagModfile = "-modfile"
+	FlagOverlay = "-overlay"
+	FlagMod     = "-mod"
+	VendorDir   = "vendor"
+)
+
+// overlayJSON is the format of the file passed to -overlay, see "go help build"
+type overlayJSON struct {
+	Replace map[string]string
+}
+
+// extractBuildFlag removes the given flag from the build command and returns
+// its value, both "-flag=value" and "-flag value" forms are recognized.
+func extractBuildFlag(cmd []string, name string) ([]string, string) {
+	value := ""
+	rest := make([]string, 0, len(cmd))
+	for i := 0; i < len(cmd); i++ {
+		arg := cmd[i]
+		if strings.HasPrefix(arg, "--") {
+			arg = arg[1:]
+		}
+		if arg == name && i+1 < len(cmd) {
+			value = cmd[i+1]
+			i++
+			continue
+		}
+		if strings.HasPrefix(arg, name+"=") {
+			value = strings.TrimPrefix(arg, name+"=")
+			continue
+		}
+		rest = append(rest, cmd[i])
+	}
+	return rest, value
+}
+
+func abs(path string) (string, error) {
+	p, err := filepath.Abs(path)
+	if err != nil {
+		return "", errc.New(errc.ErrAbsPath, err.Error())
+	}
+	return p, nil
+}
+
+func (dp *DepProcessor) initBuildFlags() (err error) {
+	cmd := dp.goBuildCmd
+	cmd, dp.userModfile = extractBuildFlag(cmd, FlagModfile)
+	cmd, dp.userOverlay = extractBuildFlag(cmd, FlagOverlay)
+	cmd, dp.buildMode = extractBuildFlag(cmd, FlagMod)
+	dp.goBuildCmd = cmd
+
+	env, err := goEnv("GOMOD", "GOWORK", "GOVERSION", "GOFLAGS")
+	if err != nil {
+		return err
+	}
+	gomod, gowork := env[0], env[1]
+	dp.workspace = gowork != "" && gowork != "off"
+	dp.goVersion = env[2]
+	dp.initVendorMode(gomod, gowork, env[3])
+	dp.modfile, err = abs(util.GetLogPath(util.GoModFile))
+	if err != nil {
+		return err
+	}
+	dp.overlay, err = abs(util.GetLogPath(OtelOverlay))
+	if err != nil {
+		return err
+	}
+	if dp.workspace {
+		// -modfile is not allowed in workspace mode, the module file of the
+		// main module is replaced via the overlay instead, which is generated
+		// once the main module is known
+		return dp.initWorkfile(gowork)
+	}
+	// Even loading packages may update go.sum, let it work on the generated
+	// module file from the very beginning
+	if dp.userModfile != "" {
+		gomod = dp.userModfile
+	}
+	if gomod == "" || gomod == os.DevNull {
+		return nil
+	}
+	return dp.initModfile(gomod)
+}
+
+// initWorkfile generates the workspace file used in place of go.work. It uses
+// the same modules, with relative paths resolved against the original go.work.
+// The go command writes missing checksums to go.work.sum next to the workspace
+// file in workspace mode, which is the generated one then.
+func (dp *DepProcessor) initWorkfile(gowork string) error {
+	data, err := util.ReadFile(gowork)
+	if err != nil {
+		return err
+	}
+	wf, err := modfile.ParseWork(gowork, []byte(data), nil)
+	if err != nil {
+		return errc.New(errc.ErrParseCode, err.Error())
+	}
+	dir := filepath.Dir(gowork)
+	for _, use := range slices.Clone(wf.Use) {
+		path, modulePath := use.Path, use.ModulePath
+		if filepath.IsAbs(path) {
+			continue
+		}
+		// Dropped entries are cleared in place, the path is kept above
+		err = wf.DropUse(path)
+		if err != nil {
+			return errc.New(errc.ErrParseCode, err.Error())
+		}
+		err = wf.AddUse(filepath.Join(dir, path), modulePath)
+		if err != nil {
+			return errc.New(errc.ErrParseCode, err.Error())
+		}
+	}
+	for _, r := range slices.Clone(wf.Replace) {
+		if r.New.Version != "" || filepath.IsAbs(r.New.Path) {
+			continue
+		}
+		err = wf.AddReplace(r.Old.Path, r.Old.Version,
+			filepath.Join(dir, r.New.Path), "")
+		if err != nil {
+			return errc.New(errc.ErrParseCode, err.Error())
+		}
+	}
+	wf.Cleanup()
+
+	dp.workfile, err = abs(util.GetLogPath(util.GoWorkFile))
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(dp.workfile), 0777)
+	if err != nil {
+		return errc.New(errc.ErrMkdirAll, err.Error())
+	}
+	_, err = util.WriteFile(dp.workfile, string(modfile.Format(wf.Syntax)))
+	if err != nil {
+		return err
+	}
+	// The checksum file is named after the workspace file, i.e. go.work.sum
+	if util.PathExists(gowork + ".sum") {
+		err = util.CopyFile(gowork+".sum", dp.workfile+".sum")
+		if err != nil {
+			return err
+		}
+	}
+	dp.goEnv = []string{"GOWORK=" + dp.workfile}
+	return nil
+}
+
+// initVendorMode finds out if the build uses vendored dependencies. The go
+// command reads vendor/modules.txt from disk rather than through the overlay,
+// there is thus no way to add the hook modules to the vendor directory without
+// modifying the project. Such projects are built in module mode instead, with
+// dependencies taken from the module cache at the versions go.mod requires,
+// which are the vendored versions as long as the vendor directory is in sync.
+// Versions of the vendored modules are still resolved from vendor/modules.txt.
+func (dp *DepProcessor) initVendorMode(gomod, gowork, goflags string) {
+	mode := dp.buildMode
+	if mode == "" {
+		_, mode = extractBuildFlag(strings.Fields(goflags), FlagMod)
+	}
+	if mode == "" {
+		mode = defaultBuildMode(gomod, gowork, dp.workspace)
+	}
+	if mode != "vendor" {
+		return
+	}
+	dp.vendorDir = filepath.Join(filepath.Dir(gomod), VendorDir)
+	if dp.workspace {
+		dp.vendorDir = filepath.Join(filepath.Dir(gowork), VendorDir)
+	}
+	dp.buildMode = ""
+	util.Log("Build with vendored dependencies in module mode")
+}
+
+// defaultBuildMode tells if the go command uses the vendor directory when -mod
+// is not set, that is, the directory exists, was vendored in the same mode the
+// build runs in, and go.mod or go.work requires at least Go 1.14.
+func defaultBuildMode(gomod, gowork string, workspace bool) string {
+	root, file := filepath.Dir(gomod), gomod
+	if workspace {
+		root, file = filepath.Dir(gowork), gowork
+	} else if gomod == "" || gomod == os.DevNull {
+		return ""
+	}
+	modules, err := util.ReadFile(filepath.Join(root, VendorDir, "modules.txt"))
+	if err != nil {
+		return ""
+	}
+	if strings.Contains(modules, "## workspace") != workspace {
+		return ""
+	}
+	data, err := util.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	goVersion := ""
+	if workspace {
+		wf, err := modfile.ParseWork(file, []byte(data), nil)
+		if err == nil && wf.Go != nil {
+			goVersion = wf.Go.Version
+		}
+	} else {
+		mf, err := modfile.ParseLax(file, []byte(data), nil)
+		if err == nil && mf.Go != nil {
+			goVersion = mf.Go.Version
+		}
+	}
+	if goVersion == "" || version.Compare("go"+goVersion, "go1.14") < 0 {
+		return ""
+	}
+	return "vendor"
+}
+
+// injectBuildFlags inserts the generated module file and overlay right after
+// "go build" and leaves the rest of the build command as is.
+func (dp *DepProcessor) injectBuildFlags() {
+	flags := []string{FlagOverlay + "=" + dp.overlay}
+	mode := dp.buildMode
+	if !dp.workspace {
+		flags = append(flags, FlagModfile+"="+dp.modfile)
+		// Neither -mod=mod is allowed in workspace mode, where the default
+		// -mod=readonly works since dependencies are tidy
+		if mode == "" {
+			mode = "mod"
+		}
+	} else if mode == "" && dp.vendorDir != "" {
+		// Override -mod=vendor given by GOFLAGS, if any
+		mode = "readonly"
+	}
+	if mode != "" {
+		flags = append(flags, FlagMod+"="+mode)
+	}
+	cmd := dp.goBuildCmd
+	dp.goBuildCmd = append(cmd[:2:2], append(flags, cmd[2:]...)...)
+}
+
+// loadFlags returns the build flags used when loading packages
+func (dp *DepProcessor) loadFlags() []string {
+	if dp.workspace || !util.PathExists(dp.modfile) {
+		return nil
+	}
+	return []string{FlagModfile + "=" + dp.modfile}
+}
+
+// goEnv returns values of the given go environment variables in order
+func goEnv(keys ...string) ([]string, error) {
+	args := append([]string{"go", "env"}, keys...)
+	out, err := runCmdCombinedOutput("", nil, args...)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(out, "\n")
+	for len(lines) < len(keys) {
+		lines = append(lines, "")
+	}
+	values := make([]string, len(keys))
+	for i := range keys {
+		values[i] = strings.TrimSpace(lines[i])
+	}
+	return values, nil
+}
+
+// initModfile generates the module file and its checksum file which are used
+// in place of the original ones, the source is go.mod of the main module, or
+// the file specified by the user via -modfile.
+func (dp *DepProcessor) initModfile(src string) error {
+	err := os.MkdirAll(filepath.Dir(dp.modfile), 0777)
+	if err != nil {
+		return errc.New(errc.ErrMkdirAll, err.Error())
+	}
+	err = util.CopyFile(src, dp.modfile)
+	if err != nil {
+		return err
+	}
+	srcSum := strings.TrimSuffix(src, ".mod") + ".sum"
+	if util.PathExists(srcSum) {
+		err = util.CopyFile(srcSum, dp.getGoSumPath())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getGoSumPath returns the checksum file of the generated module file, the go
+// command derives it from the module file name
+func (dp *DepProcessor) getGoSumPath() string {
+	return strings.TrimSuffix(dp.modfile, ".mod") + ".sum"
+}
+
+// writeOverlay generates the file passed to -overlay, which places generated
+// files into the source tree virtually. Replacements specified by the user
+// via -overlay are preserved.
+func (dp *DepProcessor) writeOverlay() error {
+	overlay := overlayJSON{Replace: map[string]string{}}
+	if dp.userOverlay != "" {
+		content, err := util.ReadFile(dp.userOverlay)
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal([]byte(content), &overlay)
+		if err != nil {
+			return errc.New(errc.ErrInvalidJSON, err.Error())
+		}
+		if overlay.Replace == nil {
+			overlay.Replace = map[string]string{}
+		}
+	}
+	target, err := abs(dp.otelImporter)
+	if err != nil {
+		return err
+	}
+	overlay.Replace[target] = dp.generatedImporter
+	if dp.workspace {
+		overlay.Replace[dp.getGoModPath()] = dp.modfile
+	}
+	bs, err := json.MarshalIndent(overlay, "", "  ")
+	if err != nil {
+		return errc.New(errc.ErrInvalidJSON, err.Error())
+	}
+	_, err = util.WriteFile(dp.overlay, string(bs))
+	return err
+}
+
+// initOverlay prepares the generated files and the overlay that refers them,
+// the content of the generated files is refreshed later on.
+func (dp *DepProcessor) initOverlay() (err error) {
+	if dp.workspace {
+		src := dp.getGoModPath()
+		if dp.userModfile != "" {
+			src = dp.userModfile
+		}
+		err = dp.initModfile(src)
+		if err != nil {
+			return err
+		}
+	}
+	dp.generatedImporter, err = abs(util.GetLogPath(OtelImporter))
+	if err != nil {
+		return err
+	}
+	return dp.writeOverlay()
+}
@@ -15,15 +15,16 @@
 package preprocess
 
 import (
-	"bufio"
+	"bytes"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
-	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
-	"syscall"
 
 	"github.com/alibaba/loongsuite-go-agent/tool/config"
 	"github.com/alibaba/loongsuite-go-agent/tool/errc"
@@ -43,30 +44,35 @@ import (
 // for preparing these dependencies in advance.
 
 const (
-	OtelImporter     = "otel_importer.go"
-	OtelRuleCache    = "rule_cache"
-	OtelBackups      = "backups"
-	OtelBackupSuffix = ".bk"
-	DryRunLog        = "dry_run.log"
-	CompileRemix     = "remix"
-	VendorDir        = "vendor"
-	GoCacheDir       = "gocache"
+	OtelImporter  = "otel_importer.go"
+	OtelRuleCache = "rule_cache"
+	ListLog       = "go_list.log"
+	CompileRemix  = "remix"
 )
 
 type DepProcessor struct {
-	backups       map[string]string
-	moduleName    string // Module name from go.mod
-	modulePath    string // Where go.mod is located
-	goBuildCmd    []string
-	vendorMode    bool
-	pkgLocalCache string // Local module cache path of alibaba-otel pkg module
-	otelImporter  string // Path to the otel_importer.go file
+	moduleName        string // Module name from go.mod
+	modulePath        string // Where go.mod is located
+	goBuildCmd        []string
+	workspace         bool     // Whether building in workspace mode
+	buildFiles        bool     // Whether building source files rather than packages
+	goVersion         string   // Version of the go toolchain, e.g. go1.23.0
+	modfile           string   // Generated module file used in place of go.mod
+	workfile          string   // Generated workspace file used in place of go.work
+	overlay           string   // Generated overlay file passed to -overlay
+	userModfile       string   // Module file specified by the user via -modfile
+	userOverlay       string   // Overlay file specified by the user via -overlay
+	buildMode         string   // Value of -mod passed to the go command
+	vendorDir         string   // Vendor directory if building with vendored deps
+	pkgLocalCache     string   // Local module cache path of alibaba-otel pkg module
+	otelImporter      string   // Path to the otel_importer.go file in source tree
+	generatedImporter string   // Path to the generated otel_importer.go file
+	run               *runCmd  // Program to execute after building for go run
+	goEnv             []string // Environment of go commands run for the build
 }
 
 func newDepProcessor() *DepProcessor {
 	dp := &DepProcessor{
-		backups:       map[string]string{},
-		vendorMode:    false,
 		pkgLocalCache: "",
 		otelImporter:  "",
 	}
@@ -74,9 +80,9 @@ func newDepProcessor() *DepProcessor {
 }
 
 func (dp *DepProcessor) String() string {
-	return fmt.Sprintf("moduleName: %s, modulePath: %s, goBuildCmd: %v, vendorMode: %v, pkgLocalCache: %s, otelImporter: %s",
-		dp.moduleName, dp.modulePath, dp.goBuildCmd, dp.vendorMode,
-		dp.pkgLocalCache, dp.otelImporter)
+	return fmt.Sprintf("moduleName: %s, modulePath: %s, goBuildCmd: %v, workspace: %v, modfile: %s, overlay: %s, pkgLocalCache: %s, otelImporter: %s",
+		dp.moduleName, dp.modulePath, dp.goBuildCmd, dp.workspace,
+		dp.modfile, dp.overlay, dp.pkgLocalCache, dp.otelImporter)
 }
 
 func (dp *DepProcessor) getGoModPath() string {
@@ -137,12 +143,19 @@ func parseGoMod(gomod string) (*modfile.File, error) {
 	return modFile, nil
 }
 
-func (dp *DepProcessor) initCmd() {
+func (dp *DepProcessor) initCmd() error {
 	// There is a tricky, all arguments after the otel tool itself are saved for
 	// later use, which means the subcommand "go build" itself are also included
 	dp.goBuildCmd = make([]string, len(os.Args)-1)
 	copy(dp.goBuildCmd, os.Args[1:])
+	if dp.goBuildCmd[1] == CmdRun {
+		err := dp.initRunCmd()
+		if err != nil {
+			return err
+		}
+	}
 	util.AssertGoBuild(dp.goBuildCmd)
+	return nil
 }
 
 func findMainDir(pkgs []*packages.Package) (string, error) {
@@ -174,7 +187,7 @@ func findMainDir(pkgs []*packages.Package) (string, error) {
 
 func (dp *DepProcessor) initMod() (err error) {
 	// Find compiling module and package information from the build command
-	pkgs, err := findModule(dp.goBuildCmd)
+	pkgs, err := findModule(dp.goBuildCmd, dp.loadFlags())
 	if err != nil {
 		return err
 	}
@@ -189,9 +202,10 @@ func (dp *DepProcessor) initMod() (err error) {
 			// Best case, we find the module information from the package field
 			util.Log("Find Go module %v", util.Jsonify(pkg.Module))
 			util.Assert(pkg.Module.Path != "", "pkg.Module.Path is empty")
-			util.Assert(pkg.Module.GoMod != "", "pkg.Module.GoMod is empty")
+			util.Assert(pkg.Module.Dir != "", "pkg.Module.Dir is empty")
 			dp.moduleName = pkg.Module.Path
-			dp.modulePath = pkg.Module.GoMod
+			// GoMod refers to the generated module file, if any
+			dp.modulePath = filepath.Join(pkg.Module.Dir, util.GoModFile)
 			dir, err := findMainDir(pkgs)
 			if err != nil {
 				return err
@@ -203,6 +217,7 @@ func (dp *DepProcessor) initMod() (err error) {
 			// we try to find it from the go.mod file, where go.mod file is in
 			// the same directory as the source file.
 			util.Assert(pkg.Name != "", "pkg.Name is empty")
+			dp.buildFiles = true
 			if pkg.Name == "main" {
 				gofile := pkg.GoFiles[0]
 				gomod, err := findGoMod(filepath.Dir(gofile))
@@ -266,54 +281,20 @@ func (dp *DepProcessor) initMod() (err error) {
 	return nil
 }
 
-func (dp *DepProcessor) initBuildMode() {
-	// Check if the build mode
-	ignoreVendor := false
-	for _, arg := range dp.goBuildCmd {
-		// -mod=mod and -mod=readonly tells the go command to ignore the vendor
-		// directory. We should not use the vendor directory in this case.
-		if strings.HasPrefix(arg, "-mod=mod") ||
-			strings.HasPrefix(arg, "-mod=readonly") {
-			dp.vendorMode = false
-			ignoreVendor = true
-			break
-		}
+func (dp *DepProcessor) init() error {
+	err := dp.initCmd()
+	if err != nil {
+		return err
 	}
-	if !ignoreVendor {
-		// FIXME: vendor directory name can be anything, but we assume it's "vendor"
-		// for now
-		vendor := filepath.Join(dp.getGoModDir(), VendorDir)
-		dp.vendorMode = util.PathExists(vendor)
+	err = dp.initBuildFlags()
+	if err != nil {
+		return err
 	}
-	// If we are building with vendored dependencies, we should not pull any
-	// additional dependencies online, which means all dependencies should be
-	// available in the vendor directory. This requires users to add these
-	// dependencies proactively
-}
-
-func (dp *DepProcessor) initSignalHandler() {
-	// Register signal handler to catch up SIGINT/SIGTERM interrupt signals and
-	// do necessary cleanup
-	sigc := make(chan os.Signal, 1)
-	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		s := <-sigc
-		switch s {
-		case syscall.SIGTERM, syscall.SIGINT:
-			util.Log("Interrupted instrumentation, cleaning up")
-		default:
-		}
-	}()
-}
-
-func (dp *DepProcessor) init() error {
-	dp.initCmd()
-	err := dp.initMod()
+	err = dp.initMod()
 	if err != nil {
 		return err
 	}
-	dp.initBuildMode()
-	dp.initSignalHandler()
+	dp.injectBuildFlags()
 	// Once all the initialization is done, let's log the configuration
 	util.Log("ToolVersion: %s", config.ToolVersion)
 	util.Log("%s", dp.String())
@@ -323,79 +304,17 @@ func (dp *DepProcessor) init() error {
 func (dp *DepProcessor) postProcess() {
 	util.GuaranteeInPreprocess()
 
+	// The program built for go run is of no use after running, even with -debug
+	if dp.run != nil {
+		dp.run.remove()
+	}
+
 	// Using -debug? Leave all changes for debugging
 	if config.GetConf().Debug {
 		return
 	}
 
-	_ = os.RemoveAll(dp.otelImporter)
 	_ = os.RemoveAll(util.GetTempBuildDirWith("alibaba-pkg"))
-	_ = dp.restoreBackupFiles()
-}
-
-func (dp *DepProcessor) backupFile(origin string) error {
-	util.GuaranteeInPreprocess()
-	backup := filepath.Base(origin) + OtelBackupSuffix
-	backup = util.GetLogPath(filepath.Join(OtelBackups, backup))
-	err := os.MkdirAll(filepath.Dir(backup), 0777)
-	if err != nil {
-		return errc.New(errc.ErrMkdirAll, err.Error())
-	}
-	if _, exist := dp.backups[origin]; !exist {
-		err = util.CopyFile(origin, backup)
-		if err != nil {
-			return err
-		}
-		dp.backups[origin] = backup
-		util.Log("Backup %v", origin)
-	} else if config.GetConf().Verbose {
-		util.Log("Backup %v already exists", origin)
-	}
-	return nil
-}
-
-func (dp *DepProcessor) restoreBackupFiles() error {
-	util.GuaranteeInPreprocess()
-	for origin, backup := range dp.backups {
-		err := util.CopyFile(backup, origin)
-		if err != nil {
-			return err
-		}
-		util.Log("Restore %v", origin)
-	}
-	return nil
-}
-
-func getCompileCommands() ([]string, error) {
-	dryRunLog, err := os.Open(util.GetLogPath(DryRunLog))
-	if err != nil {
-		return nil, errc.New(errc.ErrOpenFile, err.Error())
-	}
-	defer func(dryRunLog *os.File) {
-		err := dryRunLog.Close()
-		if err != nil {
-			util.Log("Failed to close dry run log file: %v", err)
-		}
-	}(dryRunLog)
-
-	// Filter compile commands from dry run log
-	compileCmds := make([]string, 0)
-	scanner := bufio.NewScanner(dryRunLog)
-	// 10MB should be enough to accommodate most long line
-	buffer := make([]byte, 0, 10*1024*1024)
-	scanner.Buffer(buffer, cap(buffer))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if util.IsCompileCommand(line) {
-			line = strings.Trim(line, " ")
-			compileCmds = append(compileCmds, line)
-		}
-	}
-	err = scanner.Err()
-	if err != nil {
-		return nil, errc.New(errc.ErrParseCode, "cannot parse dry run log")
-	}
-	return compileCmds, nil
 }
 
 // $ go help packages
@@ -480,10 +399,11 @@ func getCompileCommands() ([]string, error) {
 // Directory and file names that begin with "." or "_" are ignored
 // by the go tool, as are directories named "testdata".
 
-func tryLoadPackage(path string) ([]*packages.Package, error) {
+func tryLoadPackage(path string, flags []string) ([]*packages.Package, error) {
 	cfg := &packages.Config{
 		// Change it unless you know what you are doing
-		Mode: packages.NeedModule | packages.NeedFiles | packages.NeedName,
+		Mode:       packages.NeedModule | packages.NeedFiles | packages.NeedName,
+		BuildFlags: flags,
 	}
 
 	pkgs, err := packages.Load(cfg, path)
@@ -493,7 +413,7 @@ func tryLoadPackage(path string) ([]*packages.Package, error) {
 	return pkgs, nil
 }
 
-func findModule(buildCmd []string) ([]*packages.Package, error) {
+func findModule(buildCmd []string, flags []string) ([]*packages.Package, error) {
 	candidates := make([]*packages.Package, 0)
 	found := false
 
@@ -526,7 +446,7 @@ func findModule(buildCmd []string) ([]*packages.Package, error) {
 		// because we dont know what the build argument is. One exception is
 		// when we already found packages, in this case, we expect subsequent
 		// build arguments are packages, so we should not tolerate any error.
-		pkgs, err := tryLoadPackage(buildArg)
+		pkgs, err := tryLoadPackage(buildArg, flags)
 		if err != nil {
 			if found {
 				// If packages are already found, we expect subsequent build
@@ -548,7 +468,7 @@ func findModule(buildCmd []string) ([]*packages.Package, error) {
 	// If no import paths are given, the action applies to the package in the
 	// current directory.
 	if !found {
-		pkgs, err := tryLoadPackage(".")
+		pkgs, err := tryLoadPackage(".", flags)
 		if err != nil {
 			return nil, err
 		}
@@ -566,29 +486,50 @@ func findModule(buildCmd []string) ([]*packages.Package, error) {
 	return candidates, nil
 }
 
-// runDryBuild runs a dry build to get all dependencies needed for the project.
-func runDryBuild(goBuildCmd []string) ([]string, error) {
-	dryRunLog, err := os.Create(util.GetLogPath(DryRunLog))
+// listedPackage is the subset of "go list -json" output we are interested in,
+// GoFiles excludes cgo files as the compiler sees their generated variants,
+// which are listed in CgoFiles. DepOnly is false for the packages being built.
+type listedPackage struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	GoFiles    []string
+	CgoFiles   []string
+	Imports    []string
+	DepOnly    bool
+	Module     *listedModule
+}
+
+// listPackages loads the package graph of the build, i.e. all packages along
+// with their source files that would be compiled, without building anything.
+func (dp *DepProcessor) listPackages() ([]*listedPackage, error) {
+	listLog, err := os.Create(util.GetLogPath(ListLog))
 	if err != nil {
 		return nil, errc.New(errc.ErrCreateFile, err.Error())
 	}
-	// The full build command is: "go build/install -a -x -n  {...}"
-	args := []string{}
-	args = append(args, goBuildCmd[:2]...)             // go build/install
-	args = append(args, []string{"-a", "-x", "-n"}...) // -a -x -n
-	args = append(args, goBuildCmd[2:]...)             // {...} remaining
-	util.AssertGoBuild(goBuildCmd)
-	util.AssertGoBuild(args)
-
-	// Run the dry build
-	util.Log("Run dry build %v", args)
+	defer listLog.Close()
+	// The full list command is: "go list -deps -json=... {...}", where the
+	// build flags and packages are taken from the build command except -o,
+	// which is the only one not accepted by go list
+	util.AssertGoBuild(dp.goBuildCmd)
+	rest, _ := extractBuildFlag(dp.goBuildCmd[2:], "-o")
+	args := []string{"go", "list", "-deps",
+		"-json=ImportPath,Name,Dir,GoFiles,CgoFiles,Imports,DepOnly,Module"}
+	if !dp.workspace {
+		// Let go list complete the generated module file on demand, which
+		// accesses the network only if new modules are required
+		rest, _ = extractBuildFlag(rest, FlagMod)
+		args = append(args, FlagMod+"=mod")
+	}
+	args = append(args, rest...)
+
+	util.Log("Run go list %v", args)
 	cmd := exec.Command(args[0], args[1:]...)
-	// This is a little anti-intuitive as the error message is not printed to
-	// the stderr, instead it is printed to the stdout, only the build tool
-	// knows the reason why.
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = dryRunLog
-	// @@Note that dir should not be set, as the dry build should be run in the
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = listLog
+	cmd.Env = append(os.Environ(), dp.goEnv...)
+	// @@Note that dir should not be set, as go list should be run in the
 	// same directory as the original build command
 	cmd.Dir = ""
 	err = cmd.Run()
@@ -597,66 +538,44 @@ func runDryBuild(goBuildCmd []string) ([]string, error) {
 			With("command", fmt.Sprintf("%v", args))
 	}
 
-	// Find compile commands from dry run log
-	compileCmds, err := getCompileCommands()
-	if err != nil {
-		return nil, err
+	pkgs := make([]*listedPackage, 0)
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		pkg := &listedPackage{}
+		err = decoder.Decode(pkg)
+		if err != nil {
+			return nil, errc.New(errc.ErrInvalidJSON, err.Error())
+		}
+		pkgs = append(pkgs, pkg)
 	}
-	return compileCmds, nil
+	return pkgs, nil
 }
 
 func (dp *DepProcessor) runModTidy() error {
+	// Tidy the generated module file rather than go.mod, the overlay makes
+	// otel_importer.go visible so that its imports are resolved as well
 	out, err := runCmdCombinedOutput(dp.getGoModDir(), nil,
-		"go", "mod", "tidy")
+		"go", "mod", "tidy",
+		FlagModfile+"="+dp.modfile, FlagOverlay+"="+dp.overlay)
 	util.Log("Run go mod tidy: %v", out)
 	return err
 }
 
-func (dp *DepProcessor) runModVendor() error {
-	out, err := runCmdCombinedOutput(dp.getGoModDir(), nil,
-		"go", "mod", "vendor")
-	util.Log("Run go mod vendor: %v", out)
-	return err
-}
-
 func (dp *DepProcessor) refreshDeps() error {
-	// Run go mod tidy to remove unused dependencies
-	err := dp.runModTidy()
-	if err != nil {
-		return err
-	}
-
-	// Run go mod vendor to update the vendor directory
-	if dp.vendorMode {
-		err = dp.runModVendor()
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-func getTempGoCache() (string, error) {
-	goCachePath, err := filepath.Abs(filepath.Join(util.TempBuildDir, GoCacheDir))
-	if err != nil {
-		return "", err
-	}
-
-	if !util.PathExists(goCachePath) {
-		err = os.MkdirAll(goCachePath, 0755)
-		if err != nil {
-			return "", err
-		}
+	// go list completes the generated module file on demand, except when
+	// building source files, where only the modules providing the imported
+	// packages are required but not the rest of the module graph they need.
+	// Tidy it explicitly then, otherwise packages introduced by the hook
+	// modules may be ambiguous in the next round. In workspace mode, modules
+	// are resolved through go.work and the requirements of the workspace
+	// modules, nothing needs to be recorded.
+	if dp.workspace || !dp.buildFiles {
+		return nil
 	}
-	return goCachePath, nil
-}
-
-func buildGoCacheEnv(value string) []string {
-	return []string{"GOCACHE=" + value}
+	return dp.runModTidy()
 }
 
-func runBuildWithToolexec(goBuildCmd []string) error {
+func runBuildWithToolexec(goBuildCmd []string, env []string) error {
 	exe, err := os.Executable()
 	if err != nil {
 		return errc.New(errc.ErrGetExecutable, err.Error())
@@ -670,9 +589,6 @@ func runBuildWithToolexec(goBuildCmd []string) error {
 	// Leave the temporary compilation directory
 	args = append(args, util.BuildWork)
 
-	// Force rebuilding
-	args = append(args, "-a")
-
 	if config.GetConf().Debug {
 		// Disable compiler optimizations for debugging mode
 		args = append(args, "-gcflags=all=-N -l")
@@ -684,17 +600,13 @@ func runBuildWithToolexec(goBuildCmd []string) error {
 	util.Log("Run toolexec build: %v", args)
 	util.AssertGoBuild(args)
 
-	// get the temporary build cache path
-	goCachePath, err := getTempGoCache()
-	if err != nil {
-		return err
-	}
-	util.Log("Using isolated GOCACHE: %s", goCachePath)
-
 	// @@ Note that we should not set the working directory here, as the build
 	// with toolexec should be run in the same directory as the original build
-	// command
-	out, err := runCmdCombinedOutput("", buildGoCacheEnv(goCachePath), args...)
+	// command. There is no need to force rebuilding or to isolate the build
+	// cache either, the build key is part of the tool id reported by the
+	// instrument phase, instrumented outputs are therefore cached under their
+	// own action ids and reused as long as the instrumentation is unchanged
+	out, err := runCmdCombinedOutput("", env, args...)
 	util.Log("Output from toolexec build: %v", out)
 	return err
 }
@@ -784,7 +696,8 @@ func precheck() error {
 		config.PrintVersion()
 		os.Exit(0)
 	}
-	if os.Args[2] != "build" && os.Args[2] != "install" {
+	if os.Args[2] != "build" && os.Args[2] != "install" &&
+		os.Args[2] != CmdRun {
 		// exec original go command
 		err := util.RunCmd(os.Args[1:]...)
 		if err != nil {
@@ -795,18 +708,28 @@ func precheck() error {
 	return nil
 }
 
-func (dp *DepProcessor) saveDebugFiles() {
-	dir := filepath.Join(util.GetTempBuildDir(), "changed")
-	err := os.MkdirAll(dir, os.ModePerm)
-	if err == nil {
-		for origin := range dp.backups {
-			util.CopyFile(origin, filepath.Join(dir, filepath.Base(origin)))
+// collectHookPaths returns import paths of the hook packages that are used by
+// the rule bundles, which are imported by otel_importer.go
+func collectHookPaths(bundles []*resource.RuleBundle) map[string]bool {
+	paths := map[string]bool{}
+	for _, bundle := range bundles {
+		for _, funcRules := range bundle.File2FuncRules {
+			for _, rules := range funcRules {
+				for _, rule := range rules {
+					if rule.GetPath() != "" {
+						paths[rule.GetPath()] = true
+					}
+				}
+			}
 		}
 	}
-	_ = util.CopyFile(dp.otelImporter, filepath.Join(dir, OtelImporter))
+	return paths
 }
 
-func (dp *DepProcessor) newRuleImporterWith(bundles []*resource.RuleBundle) error {
+// newRuleImporterWith generates otel_importer.go that imports the given hook
+// packages and declares the stack helpers of the rule bundles
+func (dp *DepProcessor) newRuleImporterWith(bundles []*resource.RuleBundle,
+	hooks map[string]bool) error {
 	content := "package main\n"
 	builtin := map[string]string{
 		// for go:linkname when declaring printstack/getstack variable
@@ -823,14 +746,15 @@ func (dp *DepProcessor) newRuleImporterWith(bundles []*resource.RuleBundle) erro
 		"go.opentelemetry.io/otel/sdk/trace":         "_",
 		"go.opentelemetry.io/otel/baggage":           "_",
 	}
-	for pkg, alias := range builtin {
-		content += fmt.Sprintf("import %s %q\n", alias, pkg)
+	// Imports are sorted so that the generated file is always the same
+	for _, pkg := range slices.Sorted(maps.Keys(builtin)) {
+		content += fmt.Sprintf("import %s %q\n", builtin[pkg], pkg)
 	}
 
 	// No rule bundles? We still need to generate the otel_importer.go file whose
 	// purpose is to import the fundamental dependencies
-	if len(bundles) == 0 {
-		_, err := util.WriteFile(dp.otelImporter, content)
+	if len(bundles) == 0 && len(hooks) == 0 {
+		_, err := util.WriteFile(dp.generatedImporter, content)
 		if err != nil {
 			return err
 		}
@@ -838,27 +762,15 @@ func (dp *DepProcessor) newRuleImporterWith(bundles []*resource.RuleBundle) erro
 	}
 
 	// Generate the otel_importer.go file with the rule bundles
-	paths := map[string]bool{}
-	for _, bundle := range bundles {
-		for _, funcRules := range bundle.File2FuncRules {
-			for _, rules := range funcRules {
-				for _, rule := range rules {
-					if rule.GetPath() != "" {
-						paths[rule.GetPath()] = true
-					}
-				}
-			}
-		}
-	}
 	addDeps := make([]Dependency, 0)
-	for path := range paths {
+	for _, path := range slices.Sorted(maps.Keys(hooks)) {
 		content += fmt.Sprintf("import _ %q\n", path)
 		t := strings.TrimPrefix(path, pkgPrefix)
 		addDeps = append(addDeps, Dependency{
 			ImportPath:     path,
 			Version:        "v0.0.0-00010101000000-000000000000", // use latest version for the rule import
 			Replace:        true,
-			ReplacePath:    filepath.Join(dp.pkgLocalCache, t),
+			ReplacePath:    dp.pkgReplacePath(t),
 			ReplaceVersion: "",
 		})
 	}
@@ -885,9 +797,12 @@ func (dp *DepProcessor) newRuleImporterWith(bundles []*resource.RuleBundle) erro
 		content += s
 		cnt++
 	}
-	util.WriteFile(dp.otelImporter, content)
+	_, err := util.WriteFile(dp.generatedImporter, content)
+	if err != nil {
+		return err
+	}
 
-	err := dp.addDependency(dp.getGoModPath(), addDeps)
+	err = dp.addDependency(dp.modfile, addDeps)
 	if err != nil {
 		return err
 	}
@@ -910,49 +825,36 @@ func Preprocess() error {
 	defer func() { dp.postProcess() }()
 	{
 		defer util.PhaseTimer("Preprocess")()
-		defer dp.saveDebugFiles()
 
-		// Backup go.mod and add additional replace directives for the pkg module
+		// Generate the module file and the overlay used in place of the
+		// original go.mod and source tree
+		err = dp.initOverlay()
+		if err != nil {
+			return err
+		}
+
+		// Add additional replace directives for the pkg module
 		err = dp.rectifyMod()
 		if err != nil {
 			return err
 		}
 
-		// Two round of rule matching
-		//    {prepare->refresh}
-		//        1st match
-		//    {prepare->refresh}
-		//        2nd match
-		//    {prepare->refresh}
-		// Let's break it down a little bit. We first prepare the rule import,
-		// which is used to import foundational dependencies (e.g., otel, as we
-		// will instrument the otel SDK itself). Then, we perform a refresh to
-		// ensure dependencies are ready and proceed to the 1st match. During
-		// this phase, some rules matching specific criteria are identified. We
-		// then update the rule import again to include these newly matched rules.
-		// Since these rules may (and likely will) break the original dependency
-		// graph, a 2nd match is required to resolve the final set of rules.
-		// These final rules are used to perform a final update of the rule import.
-		// At this point, all preparations are complete, and the process can
-		// advance to the second stage: instrumentation.
-		bundles := make([]*resource.RuleBundle, 0)
-		for i := 0; i < 3; i++ {
-			err = dp.newRuleImporterWith(bundles)
-			if err != nil {
-				return err
-			}
+		// Resolve the final set of rules along with the dependencies they
+		// introduce, see resolve.go
+		bundles, reports, err := dp.resolveRules()
+		if err != nil {
+			return err
+		}
+		// Update the rule import according to the final rules
+		err = dp.newRuleImporterWith(bundles, collectHookPaths(bundles))
+		if err != nil {
+			return err
+		}
 
-			err = dp.refreshDeps()
-			if err != nil {
-				return err
-			}
-			if i == 2 {
-				continue
-			}
-			bundles, err = dp.matchRules()
-			if err != nil {
-				return err
-			}
+		// Tell what is instrumented and what is not, see otel report
+		report, err := dp.storeReport(reports)
+		if err != nil {
+			return err
 		}
 
 		// Rectify file rules to make sure we can find them locally
@@ -966,17 +868,40 @@ func Preprocess() error {
 		if err != nil {
 			return err
 		}
+
+		// Identify the instrumentation so that unchanged packages can be
+		// reused from the build cache
+		key, err := dp.computeBuildKey(bundles)
+		if err != nil {
+			return err
+		}
+		util.Log("Build key: %s", key)
+		err = resource.StoreBuildKey(key)
+		if err != nil {
+			return err
+		}
+
+		// Tell how the binary is instrumented, see otel inspect
+		err = dp.embedManifest(key, report)
+		if err != nil {
+			return err
+		}
 	}
 
 	{
 		defer util.PhaseTimer("Instrument")()
 
 		// Run go build with toolexec to start instrumentation
-		err = runBuildWithToolexec(dp.goBuildCmd)
+		err = runBuildWithToolexec(dp.goBuildCmd, dp.goEnv)
 		if err != nil {
 			return err
 		}
 	}
 	util.Log("Build completed successfully")
+
+	// Run the program if we are serving go run
+	if dp.run != nil {
+		return dp.run.execute()
+	}
 	return nil
 }
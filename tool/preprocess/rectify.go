@@ -160,7 +160,7 @@ func findModCacheDir() (string, error) {
 func (dp *DepProcessor) rectifyRule(bundles []*resource.RuleBundle) error {
 	util.GuaranteeInPreprocess()
 	defer util.PhaseTimer("Fetch")()
-	modfile, err := parseGoMod(dp.getGoModPath())
+	modfile, err := parseGoMod(dp.modfile)
 	if err != nil {
 		return err
 	}
@@ -170,6 +170,14 @@ func (dp *DepProcessor) rectifyRule(bundles []*resource.RuleBundle) error {
 	for _, replace := range modfile.Replace {
 		replaceMap[replace.Old.Path] = replace.New.Path
 	}
+	return rectifyBundles(bundles, dp.pkgLocalCache, replaceMap)
+}
+
+// rectifyBundles points the rules to where their code is located locally, rules
+// shipped with the tool are found in pkgDir, while custom rules are found by the
+// replace directives of the module.
+func rectifyBundles(bundles []*resource.RuleBundle, pkgDir string,
+	replaceMap map[string]string) error {
 	rectified := map[string]bool{}
 	for _, bundle := range bundles {
 		for _, funcRules := range bundle.File2FuncRules {
@@ -183,7 +191,7 @@ func (dp *DepProcessor) rectifyRule(bundles []*resource.RuleBundle) error {
 					}
 					if strings.HasPrefix(rule.Path, pkgPrefix) {
 						p := strings.TrimPrefix(rule.Path, pkgPrefix)
-						p = filepath.Join(dp.pkgLocalCache, p)
+						p = filepath.Join(pkgDir, p)
 						rule.SetPath(p)
 						rectified[p] = true
 					} else {
@@ -204,7 +212,7 @@ func (dp *DepProcessor) rectifyRule(bundles []*resource.RuleBundle) error {
 			}
 			if strings.HasPrefix(fileRule.Path, pkgPrefix) {
 				p := strings.TrimPrefix(fileRule.Path, pkgPrefix)
-				p = filepath.Join(dp.pkgLocalCache, p)
+				p = filepath.Join(pkgDir, p)
 				fileRule.SetPath(p)
 				fileRule.FileName = filepath.Join(p, fileRule.FileName)
 				rectified[p] = true
@@ -223,28 +231,32 @@ func (dp *DepProcessor) rectifyRule(bundles []*resource.RuleBundle) error {
 	return nil
 }
 
-func (dp *DepProcessor) rectifyMod() error {
-	// Backup go.mod and go.sum files
-	gomodDir := dp.getGoModDir()
-	files := []string{}
-	files = append(files, filepath.Join(gomodDir, util.GoModFile))
-	files = append(files, filepath.Join(gomodDir, util.GoSumFile))
-	files = append(files, filepath.Join(gomodDir, util.GoWorkSumFile))
-	for _, file := range files {
-		if util.PathExists(file) {
-			err := dp.backupFile(file)
-			if err != nil {
-				return err
-			}
-		}
+// pkgReplacePath returns the replacement of the pkg module, or the module at
+// subdir under it, in the generated module file. The go command records the
+// replaced directories in the build info as they are written, even with
+// -trimpath, so they are relative to the main module in order to keep the
+// binary independent of where the project is located.
+func (dp *DepProcessor) pkgReplacePath(subdir string) string {
+	dir := filepath.Join(dp.pkgLocalCache, subdir)
+	rel, err := filepath.Rel(dp.getGoModDir(), dir)
+	if err != nil {
+		return dir
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
 	}
-	// Add the alibaba-otel pkg module to the go.mod file
+	return rel
+}
+
+func (dp *DepProcessor) rectifyMod() error {
+	// Add the alibaba-otel pkg module to the generated module file
 	addDeps := make([]Dependency, 0)
 	dep := Dependency{
 		ImportPath:     pkgPrefix,
 		Version:        "v0.0.0-00010101000000-000000000000",
 		Replace:        true,
-		ReplacePath:    dp.pkgLocalCache,
+		ReplacePath:    dp.pkgReplacePath(""),
 		ReplaceVersion: "",
 	}
 	addDeps = append(addDeps, dep)
@@ -261,16 +273,12 @@ func (dp *DepProcessor) rectifyMod() error {
 			ReplaceVersion: version,
 		})
 	}
-	err := dp.addDependency(dp.getGoModPath(), addDeps)
+	err := dp.addDependency(dp.modfile, addDeps)
 	if err != nil {
 		return err
 	}
 	// Update the existing replace directives to use the local module cache
-	// Very bad, we must guarantee the replace path is consistent either in
-	// go.mod or vendor/modules.txt, otherwise, the go build toolchian will fail
-	// so we must parse go.mod to check if there is any existing replace directive
-	// and update the vendor/modules.txt accordingly.
-	modfile, err := parseGoMod(dp.getGoModPath())
+	modfile, err := parseGoMod(dp.modfile)
 	if err != nil {
 		return err
 	}
@@ -281,7 +289,7 @@ func (dp *DepProcessor) rectifyMod() error {
 			if err != nil {
 				return err
 			}
-			err = modfile.AddReplace(pkgPrefix, "", dp.pkgLocalCache, "")
+			err = modfile.AddReplace(pkgPrefix, "", dp.pkgReplacePath(""), "")
 			if err != nil {
 				return err
 			}
@@ -294,7 +302,7 @@ func (dp *DepProcessor) rectifyMod() error {
 		if err != nil {
 			return err
 		}
-		_, err = util.WriteFile(dp.getGoModPath(), string(bs))
+		_, err = util.WriteFile(dp.modfile, string(bs))
 		if err != nil {
 			return err
 		}
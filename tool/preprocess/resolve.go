@@ -0,0 +1,332 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/resource"
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// Matched rules import their hook packages, which may in turn bring new
+// packages into the build (e.g., packages of the instrumented library that the
+// project itself does not use), and these may match further rules. The package
+// graph is loaded once nevertheless: hook packages of the rules that may match
+// are imported by otel_importer.go beforehand, so that go list loads their
+// import closure along with the rest of the graph. Rules are then matched with
+// the packages reachable from the packages being built, which are extended by
+// the closure of the hook packages as the rules using them are matched. Hook
+// packages that are imported but not used by any matched rule are dropped from
+// otel_importer.go afterwards.
+
+// maxLoads is how many times the package graph is loaded at most, it's loaded
+// again only if a matched rule uses a hook package that is not loaded yet
+const maxLoads = 2
+
+// buildRequires collects the versions of modules required by the main modules
+// along with the modules they replace
+func (dp *DepProcessor) buildRequires() (map[string]string, map[string]bool,
+	error) {
+	gomods := []string{dp.modfile}
+	replaced := make(map[string]bool)
+	if dp.workspace {
+		data, err := util.ReadFile(dp.workfile)
+		if err != nil {
+			return nil, nil, err
+		}
+		wf, err := modfile.ParseWork(dp.workfile, []byte(data), nil)
+		if err != nil {
+			return nil, nil, errc.New(errc.ErrParseCode, err.Error())
+		}
+		for _, use := range wf.Use {
+			gomod := filepath.Join(use.Path, util.GoModFile)
+			if gomod != dp.getGoModPath() {
+				gomods = append(gomods, gomod)
+			}
+		}
+		for _, r := range wf.Replace {
+			replaced[r.Old.Path] = true
+		}
+	}
+	requires := make(map[string]string)
+	for _, gomod := range gomods {
+		mf, err := parseGoMod(gomod)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range mf.Require {
+			path, version := r.Mod.Path, r.Mod.Version
+			if semver.Compare(version, requires[path]) > 0 {
+				requires[path] = version
+			}
+		}
+		for _, r := range mf.Replace {
+			replaced[r.Old.Path] = true
+		}
+	}
+	return requires, replaced, nil
+}
+
+// addHookRequires adds the modules required by the module of the package under
+// the pkg module, they are part of the build once the package is imported
+func (dp *DepProcessor) addHookRequires(path string,
+	requires map[string]string) {
+	if !strings.HasPrefix(path, pkgPrefix) {
+		return
+	}
+	dir := filepath.Join(dp.pkgLocalCache, strings.TrimPrefix(path, pkgPrefix))
+	for strings.HasPrefix(dir, dp.pkgLocalCache) {
+		gomod := filepath.Join(dir, util.GoModFile)
+		if util.PathExists(gomod) {
+			mf, err := parseGoMod(gomod)
+			if err != nil {
+				util.Log("Failed to parse %s: %v", gomod, err)
+				return
+			}
+			for _, r := range mf.Require {
+				p, version := r.Mod.Path, r.Mod.Version
+				if semver.Compare(version, requires[p]) > 0 {
+					requires[p] = version
+				}
+			}
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// requirePkgModules records the modules required by the pkg module in the
+// generated module file. They are in the module graph anyway, but the go
+// command looks up imported packages in the modules required by the main module
+// first, which keeps imports from being ambiguous between modules split from
+// each other, e.g. google.golang.org/genproto, once hook modules are imported.
+func (dp *DepProcessor) requirePkgModules() error {
+	mf, err := parseGoMod(filepath.Join(dp.pkgLocalCache, util.GoModFile))
+	if err != nil {
+		return err
+	}
+	deps := make([]Dependency, 0, len(mf.Require))
+	for _, r := range mf.Require {
+		if !strings.HasPrefix(r.Mod.Path, pkgPrefix) {
+			deps = append(deps, Dependency{
+				ImportPath: r.Mod.Path,
+				Version:    r.Mod.Version,
+			})
+		}
+	}
+	return dp.addDependency(dp.modfile, deps)
+}
+
+// mayMatch checks if the rule targets the standard library, or a module in the
+// build at a version in range. Versions of replaced modules are up to the
+// replace policy, rules targeting them may always match.
+func mayMatch(rule resource.InstRule, requires map[string]string,
+	replaced map[string]bool) bool {
+	importPath := rule.GetImportPath()
+	if !strings.Contains(strings.Split(importPath, "/")[0], ".") {
+		return true
+	}
+	module := ""
+	for path := range requires {
+		if (importPath == path || strings.HasPrefix(importPath, path+"/")) &&
+			len(path) > len(module) {
+			module = path
+		}
+	}
+	if module == "" {
+		return false
+	}
+	if replaced[module] || rule.GetVersion() == "" {
+		return true
+	}
+	matched, err := util.MatchVersion(requires[module], rule.GetVersion())
+	return err == nil && matched
+}
+
+// candidateHookPaths returns import paths of the hook packages used by the
+// rules that may match, which are imported by otel_importer.go when loading
+// the package graph
+func (dp *DepProcessor) candidateHookPaths(matcher *ruleMatcher) (
+	map[string]bool, error) {
+	requires, replaced, err := dp.buildRequires()
+	if err != nil {
+		return nil, err
+	}
+	// The pkg module is always imported by otel_importer.go
+	dp.addHookRequires(pkgPrefix, requires)
+	paths := make(map[string]bool)
+	// Hook modules may require further modules targeted by rules, repeat until
+	// no more hook packages are found
+	for changed := true; changed; {
+		changed = false
+		for _, rules := range matcher.availableRules {
+			for _, rule := range rules {
+				if _, ok := rule.(*resource.InstFuncRule); !ok {
+					continue
+				}
+				path := rule.GetPath()
+				if path == "" || paths[path] ||
+					!mayMatch(rule, requires, replaced) {
+					continue
+				}
+				paths[path] = true
+				dp.addHookRequires(path, requires)
+				changed = true
+			}
+		}
+	}
+	return paths, nil
+}
+
+// loadPackages loads the package graph with the hook packages imported
+func (dp *DepProcessor) loadPackages(hooks map[string]bool) (
+	[]*listedPackage, error) {
+	err := dp.newRuleImporterWith(nil, hooks)
+	if err != nil {
+		return nil, err
+	}
+	err = dp.refreshDeps()
+	if err != nil {
+		return nil, err
+	}
+	pkgs, err := dp.listPackages()
+	if err != nil {
+		// Tell us more about what happened in the go list
+		errLog, _ := util.ReadFile(util.GetLogPath(ListLog))
+		err = errc.Adhere(err, "reason", errLog)
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// matchReachable matches the rules with the packages compiled for the build,
+// i.e. packages reachable from the packages being built, where hook packages
+// are followed only if they are used by matched rules. Matched rules whose
+// hook packages are not loaded are returned as well.
+func (dp *DepProcessor) matchReachable(matcher *ruleMatcher,
+	pkgs []*listedPackage, hooks map[string]bool) ([]*resource.RuleBundle,
+	[]*resource.PackageReport, []string, error) {
+	defer util.PhaseTimer("Match")()
+	requires := mainRequires(pkgs)
+	if dp.vendorDir != "" {
+		vendored, err := vendorModules(dp.vendorDir)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		useVendored(pkgs, vendored)
+	}
+	loaded := make(map[string]*listedPackage, len(pkgs))
+	for _, pkg := range pkgs {
+		loaded[pkg.ImportPath] = pkg
+	}
+
+	// Packages are matched in waves, each of which is the closure of the hook
+	// packages used by the rules matched in the previous one
+	reached := make(map[string]bool)
+	used := make(map[string]bool)
+	wave := make([]*listedPackage, 0)
+	var reach func(path string)
+	reach = func(path string) {
+		pkg := loaded[path]
+		if pkg == nil || reached[path] || (hooks[path] && !used[path]) {
+			return
+		}
+		reached[path] = true
+		wave = append(wave, pkg)
+		for _, imp := range pkg.Imports {
+			reach(imp)
+		}
+	}
+	for _, pkg := range pkgs {
+		if !pkg.DepOnly {
+			reach(pkg.ImportPath)
+		}
+	}
+	bundles := make([]*resource.RuleBundle, 0)
+	reports := make([]*resource.PackageReport, 0)
+	missing := make([]string, 0)
+	for len(wave) > 0 {
+		matched, matchReports := dp.matchPackages(matcher, wave, requires)
+		bundles = append(bundles, matched...)
+		reports = append(reports, matchReports...)
+		wave = wave[:0]
+		for _, path := range slices.Sorted(maps.Keys(collectHookPaths(matched))) {
+			if used[path] {
+				continue
+			}
+			used[path] = true
+			if loaded[path] == nil {
+				missing = append(missing, path)
+				continue
+			}
+			reach(path)
+		}
+	}
+	// Results arrive in random order, sort them to keep the generated code
+	// stable across builds
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].ImportPath < bundles[j].ImportPath
+	})
+	return bundles, reports, missing, nil
+}
+
+// resolveRules finds the final set of rules along with the dependencies they
+// introduce
+func (dp *DepProcessor) resolveRules() ([]*resource.RuleBundle,
+	[]*resource.PackageReport, error) {
+	err := dp.requirePkgModules()
+	if err != nil {
+		return nil, nil, err
+	}
+	matcher := newRuleMatcher().withDisabledRules()
+	hooks, err := dp.candidateHookPaths(matcher)
+	if err != nil {
+		return nil, nil, err
+	}
+	for loads := 1; ; loads++ {
+		pkgs, err := dp.loadPackages(hooks)
+		if err != nil {
+			return nil, nil, err
+		}
+		bundles, reports, missing, err := dp.matchReachable(matcher, pkgs,
+			hooks)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(missing) == 0 {
+			util.Log("Resolve rules with %d load(s) of the package graph",
+				loads)
+			return bundles, reports, nil
+		}
+		if loads == maxLoads {
+			return nil, nil, errc.New(errc.ErrMatchRule,
+				"hook packages are not loaded").
+				With("hooks", fmt.Sprintf("%v", missing))
+		}
+		util.Log("Load the package graph again for hook packages %v", missing)
+		for _, path := range missing {
+			hooks[path] = true
+		}
+	}
+}
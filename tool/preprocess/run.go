@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+)
+
+// "otel go run" is served by the same pipeline as "otel go build", the run
+// command is rewritten to build the program into a temporary directory first,
+// the resulting binary is then executed with the remaining arguments.
+
+const (
+	CmdRun   = "run"
+	FlagExec = "-exec"
+)
+
+// valueFlags are build flags that consume the next argument as their value
+// when not given in the "-flag=value" form, see "go help build"
+var valueFlags = map[string]bool{
+	"-C":             true,
+	"-asmflags":      true,
+	"-buildmode":     true,
+	"-compiler":      true,
+	"-covermode":     true,
+	"-coverpkg":      true,
+	"-exec":          true,
+	"-gccgoflags":    true,
+	"-gcflags":       true,
+	"-installsuffix": true,
+	"-ldflags":       true,
+	"-mod":           true,
+	"-modfile":       true,
+	"-overlay":       true,
+	"-p":             true,
+	"-pgo":           true,
+	"-pkgdir":        true,
+	"-tags":          true,
+	"-toolexec":      true,
+}
+
+// runCmd describes the program to be executed after building for "go run"
+type runCmd struct {
+	binary string   // absolute path of the built program
+	exec   string   // program specified by -exec to run the binary with
+	args   []string // arguments passed to the program
+}
+
+// splitRunCmd splits the arguments of "go run" into build flags, packages and
+// program arguments. As go run does, the package is either a list of .go files
+// or a single import path, everything after it belongs to the program.
+func splitRunCmd(args []string) (flags, pkgs, progArgs []string) {
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		name := strings.TrimPrefix(args[i], "-")
+		name = "-" + strings.TrimPrefix(name, "-")
+		if valueFlags[name] && i+1 < len(args) {
+			i++
+		}
+		i++
+	}
+	flags = args[:i]
+	j := i
+	for j < len(args) && strings.HasSuffix(args[j], ".go") {
+		j++
+	}
+	if j == i && j < len(args) {
+		j++
+	}
+	return flags, args[i:j], args[j:]
+}
+
+// runBinaryName names the program after its first source file or the last
+// element of its import path, just like go run does
+func runBinaryName(pkgs []string) (string, error) {
+	name := "main"
+	if len(pkgs) > 0 {
+		pkg := pkgs[0]
+		switch {
+		case strings.HasSuffix(pkg, ".go"):
+			name = strings.TrimSuffix(filepath.Base(pkg), ".go")
+		case pkg == "." || strings.HasPrefix(pkg, "./") ||
+			strings.HasPrefix(pkg, "../") || filepath.IsAbs(pkg):
+			dir, err := abs(pkg)
+			if err != nil {
+				return "", err
+			}
+			name = filepath.Base(dir)
+		default:
+			name = path.Base(pkg)
+		}
+	}
+	if util.IsWindows() {
+		name += ".exe"
+	}
+	return name, nil
+}
+
+// initRunCmd rewrites "go run" to "go build -o" and records how to execute the
+// built program afterwards
+func (dp *DepProcessor) initRunCmd() error {
+	flags, pkgs, progArgs := splitRunCmd(dp.goBuildCmd[2:])
+	// -exec is not a build flag, it tells how to run the program
+	flags, execProg := extractBuildFlag(flags, FlagExec)
+	for _, pkg := range pkgs {
+		if !strings.HasSuffix(pkg, ".go") && strings.Contains(pkg, "@") {
+			// Such package is built outside the current module, which we are
+			// not able to instrument
+			return errc.New(errc.ErrPreprocess,
+				"go run pkg@version is not supported, run it in its module").
+				With("package", pkg)
+		}
+	}
+	name, err := runBinaryName(pkgs)
+	if err != nil {
+		return err
+	}
+	// Build the program in a directory of its own under GOTMPDIR as go run
+	// does, so that concurrent runs never share the binary
+	dir, err := os.MkdirTemp(os.Getenv("GOTMPDIR"), "otel-run-")
+	if err != nil {
+		return errc.New(errc.ErrMkdirAll, err.Error())
+	}
+	binary := filepath.Join(dir, name)
+	dp.run = &runCmd{binary: binary, exec: execProg, args: progArgs}
+
+	cmd := []string{dp.goBuildCmd[0], "build"}
+	cmd = append(cmd, flags...)
+	cmd = append(cmd, "-o", binary)
+	cmd = append(cmd, pkgs...)
+	dp.goBuildCmd = cmd
+	util.Log("Rewrite go run as %v", cmd)
+	return nil
+}
+
+// execute runs the built program in the current directory with the standard
+// streams attached. A non-zero exit status is reported as *exec.ExitError so
+// that the caller is able to exit with the same code.
+func (r *runCmd) execute() error {
+	args := []string{r.binary}
+	if r.exec != "" {
+		// The -exec value is split on spaces, the same as the go command does
+		args = append(strings.Fields(r.exec), args...)
+	}
+	args = append(args, r.args...)
+	util.Log("Run program: %v", args)
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// Interrupts are delivered to the whole process group, let the program
+	// decide how to handle them while we wait for its exit status. Note that
+	// ignoring the signal instead would be inherited by the program. A
+	// termination request is sent to us only, pass it on to the program so
+	// that we are still around to clean up after it.
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+
+	err := cmd.Start()
+	if err != nil {
+		return errc.New(errc.ErrRunCmd, err.Error()).
+			With("command", strings.Join(args, " "))
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case sig := <-sigc:
+				if sig == syscall.SIGTERM {
+					_ = cmd.Process.Signal(sig)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	err = cmd.Wait()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return err
+		}
+		return errc.New(errc.ErrRunCmd, err.Error()).
+			With("command", strings.Join(args, " "))
+	}
+	return nil
+}
+
+// remove deletes the directory of the built program once it exits or fails to
+// build, as go run does
+func (r *runCmd) remove() {
+	dir := filepath.Dir(r.binary)
+	err := os.RemoveAll(dir)
+	if err != nil {
+		util.Log("Failed to remove %s: %v", dir, err)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/resource"
+)
+
+// -----------------------------------------------------------------------------
+// Report
+//
+// Every build records how the available rules were matched against the packages
+// of the project, the report command renders the record of the latest build as
+// text for humans, JSON for machines, or a standalone HTML page for CI.
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatHTML = "html"
+)
+
+func Report() error {
+	format := flag.String("format", FormatText,
+		"Output format, one of text, json and html")
+	output := flag.String("o", "",
+		"Write the report to the file instead of stdout")
+	flag.CommandLine.Parse(os.Args[2:])
+
+	report, err := resource.LoadReport()
+	if err != nil {
+		return errc.Adhere(err, "hint", "build the project with otel first")
+	}
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return errc.New(errc.ErrCreateFile, err.Error())
+		}
+		defer file.Close()
+		w = file
+	}
+	switch *format {
+	case FormatText:
+		err = renderText(w, report)
+	case FormatJSON:
+		err = renderJSON(w, report)
+	case FormatHTML:
+		err = renderHTML(w, report)
+	default:
+		return errc.New(errc.ErrReport, "unknown format "+*format)
+	}
+	if err != nil {
+		return errc.New(errc.ErrReport, err.Error())
+	}
+	return nil
+}
+
+func coverage(report *resource.Report) string {
+	pkgs, instrumented, rules, applied := report.Coverage()
+	return fmt.Sprintf("%d/%d packages instrumented, %d/%d rules applied",
+		instrumented, pkgs, applied, rules)
+}
+
+func renderText(w io.Writer, report *resource.Report) error {
+	fmt.Fprintf(w, "Command: %s\n", strings.Join(report.Command, " "))
+	fmt.Fprintf(w, "Toolchain: %s, otel %s\n", report.GoVersion,
+		report.ToolVersion)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, pkg := range report.Packages {
+		fmt.Fprintf(tw, "\n%s\n", strings.TrimSpace(pkg.ImportPath+" "+pkg.Version))
+		for _, rr := range pkg.Applied {
+			file := "-"
+			if rr.File != "" {
+				file = filepath.Base(rr.File)
+			}
+			hook := ""
+			if rr.Hook != "" {
+				hook = "hook " + rr.Hook
+			}
+			fmt.Fprintf(tw, "  applied\t%s\t%s\t%s\t%s\n",
+				rr.Kind, rr.Target, file, hook)
+		}
+		for _, rr := range pkg.Skipped {
+			fmt.Fprintf(tw, "  skipped\t%s\t%s\t%s\n",
+				rr.Kind, rr.Target, rr.Reason)
+		}
+	}
+	err := tw.Flush()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "\nCoverage: %s\n", coverage(report))
+	return err
+}
+
+func renderJSON(w io.Writer, report *resource.Report) error {
+	pkgs, instrumented, rules, applied := report.Coverage()
+	out := struct {
+		*resource.Report
+		Coverage map[string]int
+	}{
+		Report: report,
+		Coverage: map[string]int{
+			"Packages":     pkgs,
+			"Instrumented": instrumented,
+			"Rules":        rules,
+			"Applied":      applied,
+		},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"base": filepath.Base,
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Instrumentation Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+.applied { color: #2a7d2a; }
+.skipped { color: #a15c00; }
+</style>
+</head>
+<body>
+<h1>Instrumentation Report</h1>
+<p>Command: <code>{{join .Report.Command " "}}</code></p>
+<p>Toolchain: {{.Report.GoVersion}}, otel {{.Report.ToolVersion}}</p>
+<p>Coverage: {{.Coverage}}</p>
+{{range .Report.Packages}}
+<h2>{{.ImportPath}}{{with .Version}} {{.}}{{end}}</h2>
+<table>
+<tr><th>Status</th><th>Kind</th><th>Target</th><th>Details</th></tr>
+{{range .Applied}}<tr class="applied"><td>applied</td><td>{{.Kind}}</td><td>{{.Target}}</td><td>{{if .File}}{{base .File}} {{end}}{{if .Hook}}hook {{.Hook}}{{end}}</td></tr>
+{{end}}{{range .Skipped}}<tr class="skipped"><td>skipped</td><td>{{.Kind}}</td><td>{{.Target}}</td><td>{{.Reason}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+func renderHTML(w io.Writer, report *resource.Report) error {
+	return htmlReport.Execute(w, struct {
+		Report   *resource.Report
+		Coverage string
+	}{report, coverage(report)})
+}
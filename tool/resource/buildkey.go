@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+)
+
+const (
+	BuildKeyFile = "build_key.txt"
+)
+
+// StoreBuildKey saves the key that identifies the instrumentation applied by
+// the current build, the instrument phase reports it as part of the tool id
+// so that go build caches instrumented outputs apart from regular ones.
+func StoreBuildKey(key string) error {
+	util.GuaranteeInPreprocess()
+	_, err := util.WriteFile(util.GetPreprocessLogPath(BuildKeyFile), key)
+	return err
+}
+
+func LoadBuildKey() (string, error) {
+	util.GuaranteeInInstrument()
+	key, err := util.ReadFile(util.GetPreprocessLogPath(BuildKeyFile))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(key), nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+)
+
+// The manifest describes how a binary was instrumented. It's embedded in the
+// binary as a string variable of the main package, enclosed by the markers so
+// that it can be found by scanning the binary, even if it's stripped. The
+// variable is linked to the pkg module as well, which exposes it at runtime.
+const (
+	ManifestBegin = "otel.manifest.begin:"
+	ManifestEnd   = ":otel.manifest.end"
+)
+
+type Manifest struct {
+	ToolVersion string
+	GoVersion   string
+	// BuildKey identifies the tool and the rules applied, see build key
+	BuildKey string
+	// Configuration the binary was built with
+	Config ManifestConfig
+	// SHA-256 of the available rule files, keyed by the file name
+	RuleSets map[string]string
+	// Instrumented packages and the rules applied to them
+	Packages []*ManifestPackage
+}
+
+type ManifestConfig struct {
+	RuleJsonFiles string `json:",omitempty"`
+	DisableRules  string `json:",omitempty"`
+	ReplacePolicy string `json:",omitempty"`
+	Verbose       bool   `json:",omitempty"`
+	Debug         bool   `json:",omitempty"`
+}
+
+type ManifestPackage struct {
+	ImportPath string
+	Version    string `json:",omitempty"`
+	// Applied rules, e.g. "func (*Engine).Run"
+	Rules []string
+}
+
+// NewManifestPackages summarizes the instrumented packages of the report
+func NewManifestPackages(report *Report) []*ManifestPackage {
+	pkgs := make([]*ManifestPackage, 0)
+	for _, pkg := range report.Packages {
+		if len(pkg.Applied) == 0 {
+			continue
+		}
+		mp := &ManifestPackage{ImportPath: pkg.ImportPath, Version: pkg.Version}
+		for _, rr := range pkg.Applied {
+			mp.Rules = append(mp.Rules, rr.Kind+" "+rr.Target)
+		}
+		pkgs = append(pkgs, mp)
+	}
+	return pkgs
+}
+
+// Embedded returns the manifest along with the markers, which is the value of
+// the variable embedded in the binary
+func (m *Manifest) Embedded() (string, error) {
+	bs, err := json.Marshal(m)
+	if err != nil {
+		return "", errc.New(errc.ErrInvalidJSON, err.Error())
+	}
+	return ManifestBegin + string(bs) + ManifestEnd, nil
+}
+
+// FindManifest finds the embedded manifest in the content of the binary, it
+// returns nil if the binary is not instrumented. The markers may appear
+// elsewhere, e.g. in the tool itself, only the one enclosing a valid manifest
+// counts.
+func FindManifest(content []byte) *Manifest {
+	begin, end := []byte(ManifestBegin), []byte(ManifestEnd)
+	for {
+		i := bytes.Index(content, begin)
+		if i < 0 {
+			return nil
+		}
+		content = content[i+len(begin):]
+		j := bytes.Index(content, end)
+		if j < 0 {
+			return nil
+		}
+		raw := content[:j]
+		if bytes.HasPrefix(raw, []byte("{")) {
+			m := &Manifest{}
+			if json.Unmarshal(raw, m) == nil && m.ToolVersion != "" {
+				return m
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+)
+
+const (
+	ReportJsonFile = "report.json"
+)
+
+// Report records how the available rules were matched against the packages of
+// the latest build. Only packages that have rules targeting them are reported.
+type Report struct {
+	ToolVersion string
+	GoVersion   string
+	Command     []string
+	Packages    []*PackageReport
+}
+
+// PackageReport lists the rules applied to one package and the rules skipped
+// along with the reason
+type PackageReport struct {
+	ImportPath string
+	Version    string        `json:",omitempty"`
+	Applied    []*RuleReport `json:",omitempty"`
+	Skipped    []*RuleReport `json:",omitempty"`
+}
+
+type RuleReport struct {
+	// Kind of the rule, i.e. func, struct or file
+	Kind string
+	// What the rule targets, e.g. "(*Engine).Run", "Engine" or "engine.go"
+	Target string
+	// Hook package of the rule, empty for raw rules and struct rules
+	Hook string `json:",omitempty"`
+	// Version range of the rule
+	Version string `json:",omitempty"`
+	// Source file the rule applied to
+	File string `json:",omitempty"`
+	// Why the rule was skipped
+	Reason string `json:",omitempty"`
+}
+
+func NewRuleReport(rule InstRule) *RuleReport {
+	rr := &RuleReport{Version: rule.GetVersion()}
+	switch rl := rule.(type) {
+	case *InstFuncRule:
+		rr.Kind = "func"
+		rr.Target = rl.Function
+		if rl.ReceiverType != "" {
+			// Receiver types are escaped as in regular expressions, e.g. \*Engine
+			recv := strings.ReplaceAll(rl.ReceiverType, "\\", "")
+			rr.Target = fmt.Sprintf("(%s).%s", recv, rl.Function)
+		}
+		if !rl.UseRaw {
+			rr.Hook = rl.Path
+		}
+	case *InstStructRule:
+		rr.Kind = "struct"
+		rr.Target = rl.StructType
+	case *InstFileRule:
+		rr.Kind = "file"
+		rr.Target = rl.FileName
+		rr.Hook = rl.Path
+	default:
+		util.ShouldNotReachHere()
+	}
+	return rr
+}
+
+// Coverage returns how many of the reported packages are instrumented, and how
+// many of the rules targeting them are applied
+func (r *Report) Coverage() (pkgs, instrumented, rules, applied int) {
+	for _, pkg := range r.Packages {
+		pkgs++
+		if len(pkg.Applied) > 0 {
+			instrumented++
+		}
+		rules += len(pkg.Applied) + len(pkg.Skipped)
+		applied += len(pkg.Applied)
+	}
+	return pkgs, instrumented, rules, applied
+}
+
+// StoreReport saves the report of the build outside of the phase directories,
+// so that it survives subsequent commands until the next build
+func StoreReport(report *Report) error {
+	util.GuaranteeInPreprocess()
+	bs, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errc.New(errc.ErrInvalidJSON, err.Error())
+	}
+	_, err = util.WriteFile(util.GetTempBuildDirWith(ReportJsonFile), string(bs))
+	return err
+}
+
+func LoadReport() (*Report, error) {
+	file := util.GetTempBuildDirWith(ReportJsonFile)
+	data, err := util.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	err = json.Unmarshal([]byte(data), report)
+	if err != nil {
+		return nil, errc.New(errc.ErrInvalidJSON, "bad "+file)
+	}
+	return report, nil
+}
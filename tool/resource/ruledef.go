@@ -15,7 +15,6 @@ package resource
 
 import (
 	"encoding/json"
-	"strings"
 
 	"github.com/alibaba/loongsuite-go-agent/tool/errc"
 	"github.com/alibaba/loongsuite-go-agent/tool/util"
@@ -49,11 +48,13 @@ type InstRule interface {
 type InstBaseRule struct {
 	// Local path of the rule, it designates where we can found the hook code
 	Path string `json:"Path,omitempty"`
-	// Version of the rule, e.g. "[1.9.1,1.9.2)" or "", it designates the
-	// version range of rule, all other version will not be instrumented
+	// Version of the rule, e.g. "[1.9.1,1.9.2)", ">=1.9.1 <=1.9.5, !=1.9.3"
+	// or "", it designates the version constraint of rule, all other version
+	// will not be instrumented. See util.MatchVersion for the syntax
 	Version string `json:"Version,omitempty"`
-	// Go version of the rule, e.g. "[1.22.0,)" or "", it designates the go
-	// version range of rule, all other go version will not be instrumented
+	// Go version of the rule, e.g. "[1.22.0,)", ">=1.22" or "", it designates
+	// the go version constraint of rule, all other go version will not be
+	// instrumented
 	GoVersion string `json:"GoVersion,omitempty"`
 	// Import path of the rule, e.g. "github.com/gin-gonic/gin", it designates
 	// the import path of rule, all other import path will not be instrumented
@@ -138,19 +139,20 @@ func verifyRule(rule *InstBaseRule, checkPath bool) error {
 			return errc.New(errc.ErrInvalidRule, "local path is empty")
 		}
 	}
+	return rule.Verify()
+}
+
+// Verify checks the import path is specified and the version constraints, if
+// any, are well-formed
+func (rule *InstBaseRule) Verify() error {
 	// Import path should not be empty
 	if rule.ImportPath == "" {
 		return errc.New(errc.ErrInvalidRule, "import path is empty")
 	}
-	// If version is specified, it should be in the format of [start,end)
 	for _, v := range []string{rule.Version, rule.GoVersion} {
-		if v != "" {
-			if !strings.Contains(v, "[") ||
-				!strings.Contains(v, ")") ||
-				!strings.Contains(v, ",") ||
-				strings.Contains(v, "v") {
-				return errc.New(errc.ErrInvalidRule, "bad version "+v)
-			}
+		err := util.VerifyVersion(v)
+		if err != nil {
+			return err
 		}
 	}
 	return nil
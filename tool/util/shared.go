@@ -27,6 +27,7 @@ const (
 	GoBuildIgnoreComment = "//go:build ignore"
 	GoModFile            = "go.mod"
 	GoSumFile            = "go.sum"
+	GoWorkFile           = "go.work"
 	GoWorkSumFile        = "go.work.sum"
 	DebugLogFile         = "debug.log"
 	TempBuildDir         = ".otel-build"
@@ -153,6 +153,186 @@ func TestMatchVersion(t *testing.T) {
 			want:    false,
 			wantErr: false,
 		},
+		{
+			name: "version is in the first clause of union",
+			args: args{
+				version:     "v1.3.0",
+				ruleVersion: ">=1.2.0 <1.5.0 || >=2.0.0, !=2.1.3",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "version is in the second clause of union",
+			args: args{
+				version:     "v2.0.5",
+				ruleVersion: ">=1.2.0 <1.5.0 || >=2.0.0, !=2.1.3",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "version is between clauses of union",
+			args: args{
+				version:     "v1.6.0",
+				ruleVersion: ">=1.2.0 <1.5.0 || >=2.0.0, !=2.1.3",
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "version is excluded",
+			args: args{
+				version:     "v2.1.3",
+				ruleVersion: ">=1.2.0 <1.5.0 || >=2.0.0, !=2.1.3",
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "version equals inclusive end",
+			args: args{
+				version:     "v1.5.0",
+				ruleVersion: ">=1.2.0 <=1.5.0",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "version equals exclusive start",
+			args: args{
+				version:     "v1.2.0",
+				ruleVersion: ">1.2.0",
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "version equals exact version",
+			args: args{
+				version:     "v1.2.0",
+				ruleVersion: "1.2",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "operator is separated from version",
+			args: args{
+				version:     "v1.22.3",
+				ruleVersion: ">= v1.22, < 2",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "pre-release of exclusive end",
+			args: args{
+				version:     "v1.5.0-rc.1",
+				ruleVersion: ">=1.2.0 <1.5.0",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "pre-release of exclusive end of legacy range",
+			args: args{
+				version:     "v1.5.0-rc.1",
+				ruleVersion: "[1.2.0,1.5.0)",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "pre-release excluded by lowest pre-release end",
+			args: args{
+				version:     "v1.5.0-rc.1",
+				ruleVersion: ">=1.2.0 <1.5.0-0",
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "pre-release below pre-release end",
+			args: args{
+				version:     "v1.5.0-rc.1",
+				ruleVersion: "<1.5.0-rc.2",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "pre-release of start",
+			args: args{
+				version:     "v1.5.0-rc.1",
+				ruleVersion: ">=1.5.0",
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "pseudo-version of exclusive end",
+			args: args{
+				version:     "v1.5.0-0.20240101000000-abcdef123456",
+				ruleVersion: "<1.5.0",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "pseudo-version within range",
+			args: args{
+				version:     "v1.5.1-0.20240101000000-abcdef123456",
+				ruleVersion: ">=1.5.0 <1.6.0",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "legacy range in union",
+			args: args{
+				version:     "v3.1.0",
+				ruleVersion: "[1.0.0,2.0.0) || [3.0.0,)",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "unknown operator",
+			args: args{
+				version:     "v1.2.0",
+				ruleVersion: "~>1.2",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "empty clause",
+			args: args{
+				version:     "v1.2.0",
+				ruleVersion: ">=1.0.0 ||",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "invalid version in constraint",
+			args: args{
+				version:     "v1.2.0",
+				ruleVersion: ">=1.x",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "empty legacy range",
+			args: args{
+				version:     "v1.2.0",
+				ruleVersion: "[1.5.0,1.2.0)",
+			},
+			want:    false,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -166,4 +346,36 @@ func TestMatchVersion(t *testing.T) {
 			}
 		})
 	}
+	t.Run("pre-release of exclusive end in both forms", func(t *testing.T) {
+		for _, version := range []string{"v1.5.0-rc.1",
+			"v1.5.0-0.20240101000000-abcdef123456"} {
+			got, err := MatchVersion(version, ">=1.2.0 <1.5.0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			legacy, err := MatchVersion(version, "[1.2.0,1.5.0)")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got || got != legacy {
+				t.Errorf("MatchVersion(%s) = %v and %v in legacy form, want true",
+					version, got, legacy)
+			}
+		}
+	})
+}
+
+func TestEmptyLegacyRange(t *testing.T) {
+	for constraint, want := range map[string]bool{
+		"[1.5.0,1.2.0)":    true,
+		"[1.5.0,1.5.0)":    true,
+		"[1.2.0,1.5.0)":    false,
+		"[1.5.0,)":         false,
+		">=1.5.0 <1.2.0":   false,
+		"[1.5.0,1.2.0) ||": false,
+	} {
+		if got := EmptyLegacyRange(constraint); got != want {
+			t.Errorf("EmptyLegacyRange(%q) = %v, want %v", constraint, got, want)
+		}
+	}
 }
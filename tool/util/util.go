@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"math/rand"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -90,25 +89,6 @@ func ShouldNotReachHereT(msg string) {
 	panic("should not reach here: " + msg)
 }
 
-var recordedRand = make(map[string]bool)
-
-// RandomString generates a globally unique random string of length n
-func RandomString(n int) string {
-	for {
-		var letters = []rune("0123456789")
-		b := make([]rune, n)
-		for i := range b {
-			b[i] = letters[rand.Intn(len(letters))]
-		}
-		s := string(b)
-		// Random suffix collision? Reroll until we get a unique one
-		if _, ok := recordedRand[s]; !ok {
-			recordedRand[s] = true
-			return s
-		}
-	}
-}
-
 func RunCmd(args ...string) error {
 	path := args[0]
 	args = args[1:]
@@ -0,0 +1,237 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/errc"
+	"golang.org/x/mod/semver"
+)
+
+// Version constraints of rules come in two forms. The legacy one is a single
+// half-open range [start,end), where either side can be omitted. The other one
+// is a union of clauses separated by "||", each clause is a list of comparisons
+// separated by commas or whitespace, all of which must hold, e.g.
+//
+//	>=1.2.0 <1.5.0 || >=2.0.0, !=2.1.3
+//
+// The operators are =, !=, >, >=, < and <=, a bare version means =. Versions
+// may have the "v" prefix and omit the minor or patch number, e.g. 1.2 means
+// 1.2.0. A legacy range can be used as a clause on its own as well.
+//
+// Versions are ordered by semantic versioning, build metadata is ignored. The
+// pre-releases of a version, including the pseudo-versions of commits leading
+// to it, e.g. v1.5.0-rc.1 and v1.5.0-0.20240101000000-abcdef123456, precede the
+// version itself, so both <1.5.0 and [,1.5.0) include them, while <1.5.0-0
+// excludes them. A legacy range whose start is not below its end matches no
+// version at all, see EmptyLegacyRange.
+
+const (
+	opEQ = "="
+	opNE = "!="
+	opGT = ">"
+	opGE = ">="
+	opLT = "<"
+	opLE = "<="
+)
+
+type comparison struct {
+	op      string
+	version string // Semantic version with the "v" prefix
+}
+
+func (c comparison) holds(version string) bool {
+	cmp := semver.Compare(version, c.version)
+	switch c.op {
+	case opEQ:
+		return cmp == 0
+	case opNE:
+		return cmp != 0
+	case opGT:
+		return cmp > 0
+	case opGE:
+		return cmp >= 0
+	case opLT:
+		return cmp < 0
+	case opLE:
+		return cmp <= 0
+	}
+	ShouldNotReachHere()
+	return false
+}
+
+// versionConstraint is a union of clauses, each of which is an intersection
+// of comparisons
+type versionConstraint [][]comparison
+
+func (vc versionConstraint) allows(version string) bool {
+	for _, clause := range vc {
+		allowed := true
+		for _, c := range clause {
+			if !c.holds(version) {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func badConstraint(constraint, reason string) error {
+	return errc.New(errc.ErrInvalidRule,
+		fmt.Sprintf("bad version %s: %s", constraint, reason))
+}
+
+// parseLegacyRange parses the version range in format [start,end), where
+// neither start nor end has the "v" prefix
+func parseLegacyRange(constraint, clause string) ([]comparison, error) {
+	if !strings.HasPrefix(clause, "[") ||
+		!strings.HasSuffix(clause, ")") ||
+		strings.Count(clause, ",") != 1 ||
+		strings.Contains(clause, "v") {
+		return nil, badConstraint(constraint, "want [start,end)")
+	}
+	clause = strings.ReplaceAll(clause, " ", "")
+	start, end, _ := strings.Cut(clause[1:len(clause)-1], ",")
+	comparisons := make([]comparison, 0, 2)
+	if start != "" {
+		comparisons = append(comparisons, comparison{opGE, "v" + start})
+	}
+	if end != "" {
+		comparisons = append(comparisons, comparison{opLT, "v" + end})
+	}
+	for _, c := range comparisons {
+		if !semver.IsValid(c.version) {
+			return nil, badConstraint(constraint, "invalid version "+c.version)
+		}
+	}
+	return comparisons, nil
+}
+
+func parseComparison(constraint, term string) (comparison, error) {
+	op := opEQ
+	for _, candidate := range []string{opNE, opGE, opLE, opGT, opLT, opEQ} {
+		if strings.HasPrefix(term, candidate) {
+			op = candidate
+			term = strings.TrimSpace(strings.TrimPrefix(term, candidate))
+			break
+		}
+	}
+	version := term
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) {
+		return comparison{}, badConstraint(constraint, "invalid version "+term)
+	}
+	return comparison{op, version}, nil
+}
+
+// splitTerms splits the clause into comparisons, the operator may be separated
+// from its version by whitespace, e.g. ">= 1.2.0"
+func splitTerms(clause string) []string {
+	terms := make([]string, 0)
+	pending := ""
+	for _, field := range strings.FieldsFunc(clause, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}) {
+		if strings.Trim(field, "=!<>") == "" {
+			pending += field
+			continue
+		}
+		terms = append(terms, pending+field)
+		pending = ""
+	}
+	if pending != "" {
+		terms = append(terms, pending)
+	}
+	return terms
+}
+
+func parseVersionConstraint(constraint string) (versionConstraint, error) {
+	vc := make(versionConstraint, 0)
+	for _, clause := range strings.Split(constraint, "||") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			return nil, badConstraint(constraint, "empty clause")
+		}
+		if strings.HasPrefix(clause, "[") || strings.HasPrefix(clause, "(") {
+			comparisons, err := parseLegacyRange(constraint, clause)
+			if err != nil {
+				return nil, err
+			}
+			vc = append(vc, comparisons)
+			continue
+		}
+		comparisons := make([]comparison, 0)
+		for _, term := range splitTerms(clause) {
+			c, err := parseComparison(constraint, term)
+			if err != nil {
+				return nil, err
+			}
+			comparisons = append(comparisons, c)
+		}
+		vc = append(vc, comparisons)
+	}
+	return vc, nil
+}
+
+// EmptyLegacyRange checks if the constraint is a legacy range [start,end) whose
+// start is not below its end. Such ranges used to be accepted silently, rules
+// with them are skipped rather than failing the whole rule file.
+func EmptyLegacyRange(constraint string) bool {
+	constraint = strings.TrimSpace(constraint)
+	if !strings.HasPrefix(constraint, "[") {
+		return false
+	}
+	comparisons, err := parseLegacyRange(constraint, constraint)
+	if err != nil || len(comparisons) != 2 {
+		return false
+	}
+	return semver.Compare(comparisons[0].version, comparisons[1].version) >= 0
+}
+
+// VerifyVersion checks the version constraint of the rule is well-formed
+func VerifyVersion(constraint string) error {
+	if constraint == "" {
+		return nil
+	}
+	_, err := parseVersionConstraint(constraint)
+	return err
+}
+
+// MatchVersion checks if the version satisfies the version constraint of the
+// rule. The version must be a semantic version with the "v" prefix, and an
+// empty constraint is satisfied by any version.
+func MatchVersion(version string, constraint string) (bool, error) {
+	// Fast path, always match if the rule version is not specified
+	if constraint == "" {
+		return true, nil
+	}
+	if !semver.IsValid(version) {
+		return false, errc.New(errc.ErrMatchRule,
+			fmt.Sprintf("invalid version %v", version))
+	}
+	vc, err := parseVersionConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	return vc.allows(version), nil
+}